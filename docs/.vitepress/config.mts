@@ -75,6 +75,7 @@ export default defineConfig({
           items: [
             { text: 'Configuration', link: '/reference/configuration' },
             { text: 'CLI Commands', link: '/reference/cli-commands' },
+            { text: 'Go API', link: '/reference/go-api' },
             { text: 'Template Variables', link: '/reference/template-variables' },
           ]
         },
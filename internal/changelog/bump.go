@@ -0,0 +1,22 @@
+package changelog
+
+import "github.com/alexjoedt/forge/internal/version"
+
+// SuggestedBump infers the SemVer bump from the changelog's commits: major for
+// breaking changes, minor for features and patch otherwise. Reverted commits are
+// already reconciled by Parse, so a feature reverted within the range does not
+// force a minor bump.
+func (cl *Changelog) SuggestedBump() version.BumpType {
+	bump := version.BumpPatch
+	for _, commit := range cl.Commits {
+		for _, c := range append([]Commit{commit}, commit.Grouped...) {
+			if c.Breaking {
+				return version.BumpMajor
+			}
+			if c.Type == TypeFeat {
+				bump = version.BumpMinor
+			}
+		}
+	}
+	return bump
+}
@@ -0,0 +1,238 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/git"
+)
+
+// Identity identifies a person by name and email.
+type Identity struct {
+	Name  string
+	Email string
+}
+
+// String returns the identity in "Name <email>" form.
+func (id Identity) String() string {
+	return fmt.Sprintf("%s <%s>", id.Name, id.Email)
+}
+
+// key returns the value identities are deduplicated by: the email if known, the name otherwise.
+func (id Identity) key() string {
+	if id.Email != "" {
+		return strings.ToLower(id.Email)
+	}
+	return strings.ToLower(id.Name)
+}
+
+// Contributor is a person credited in a changelog.
+type Contributor struct {
+	Identity
+	// Commits is the number of commits in the range the person authored or co-authored.
+	Commits int
+	// FirstTime is set when the person has no commits before the start of the range.
+	FirstTime bool
+}
+
+//nolint:gochecknoglobals // compiled regex is immutable and reused across parses
+var identityRegex = regexp.MustCompile(`^(.*?)\s*<([^>]*)>$`)
+
+// parseCoAuthors extracts the identities named in Co-authored-by trailers.
+func parseCoAuthors(body string) []Identity {
+	var ids []Identity
+	for _, m := range coAuthorRegex.FindAllStringSubmatch(body, -1) {
+		ids = append(ids, Identity{Name: m[1], Email: m[2]})
+	}
+	return ids
+}
+
+// contributors builds the contributor list for the given commits (newest first) of the
+// range from..to, excluding bots and marking people without commits before from as
+// first-time contributors.
+func (p *Parser) contributors(ctx context.Context, from, to string, commits []Commit) ([]Contributor, error) {
+	bots := compileIdentityPatterns(p.opts.Bots)
+
+	var coAuthors []Identity
+	byKey := make(map[string]*Contributor)
+	order := []string{}
+	credit := func(id Identity) {
+		if (id.Name == "" && id.Email == "") || matchesIdentity(bots, id) {
+			return
+		}
+		c, ok := byKey[id.key()]
+		if !ok {
+			c = &Contributor{Identity: id}
+			byKey[id.key()] = c
+			order = append(order, id.key())
+		}
+		c.Commits++
+	}
+
+	var visit func(c *Commit)
+	visit = func(c *Commit) {
+		credit(Identity{Name: c.Author, Email: c.Email})
+		if p.opts.CoAuthors {
+			coAuthors = append(coAuthors, c.CoAuthors...)
+		}
+		for i := range c.Grouped {
+			visit(&c.Grouped[i])
+		}
+	}
+	for i := range commits {
+		visit(&commits[i])
+	}
+
+	// Co-authors come from free-form trailers, so normalise them through .mailmap
+	// the same way git already did for commit authors.
+	if len(coAuthors) > 0 {
+		normalized, err := p.checkMailmap(ctx, coAuthors)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range normalized {
+			credit(id)
+		}
+	}
+
+	known, err := p.previousContributors(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if to != "" {
+		if p.ranges == nil {
+			p.ranges = make(map[string]string)
+		}
+		p.ranges[to] = from
+	}
+
+	contributors := make([]Contributor, 0, len(order))
+	for _, key := range order {
+		c := *byKey[key]
+		c.FirstTime = !known[c.key()]
+		contributors = append(contributors, c)
+	}
+	slices.SortStableFunc(contributors, func(a, b Contributor) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return contributors, nil
+}
+
+// checkMailmap maps identities through the repository's .mailmap in a single git call
+// and returns them in the same order. Each identity is looked up once.
+func (p *Parser) checkMailmap(ctx context.Context, ids []Identity) ([]Identity, error) {
+	var identities []string
+	index := make(map[string]int)
+	for _, id := range ids {
+		if _, ok := index[id.key()]; !ok {
+			index[id.key()] = len(identities)
+			identities = append(identities, id.String())
+		}
+	}
+
+	mapped, err := p.git(ctx).CheckMailmap(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	if len(mapped) != len(identities) {
+		return nil, fmt.Errorf("mailmap returned %d identities for %d", len(mapped), len(identities))
+	}
+
+	unique := make([]Identity, 0, len(mapped))
+	for _, line := range mapped {
+		m := identityRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			return nil, fmt.Errorf("unexpected mailmap identity %q", line)
+		}
+		unique = append(unique, Identity{Name: m[1], Email: m[2]})
+	}
+
+	normalized := make([]Identity, 0, len(ids))
+	for _, id := range ids {
+		normalized = append(normalized, unique[index[id.key()]])
+	}
+	return normalized, nil
+}
+
+// previousContributors returns the keys of everyone who authored (or co-authored) a
+// commit reachable from from. Co-authors are normalised through .mailmap like authors,
+// so people are recognised by their canonical email. It returns an empty set when from
+// is empty, since the range then covers the whole history.
+//
+// Results are cached per ref. If an earlier parse ended at from, only the commits of
+// that range are scanned on top of the contributors before it, so parsing releases
+// oldest first walks the history once.
+func (p *Parser) previousContributors(ctx context.Context, from string) (map[string]bool, error) {
+	if known, ok := p.history[from]; ok {
+		return known, nil
+	}
+
+	known := make(map[string]bool)
+	if from == "" {
+		return known, nil
+	}
+
+	scan := from
+	if prev, ok := p.ranges[from]; ok {
+		if prevKnown, ok := p.history[prev]; ok {
+			known = maps.Clone(prevKnown)
+			scan = logRange(prev, from)
+		}
+	}
+
+	// Only whether a co-author appeared matters, so each is mapped once
+	coAuthors := make(map[string]Identity)
+	for entry, err := range p.git(ctx).Log(ctx, git.LogOptions{Range: scan}) {
+		if err != nil {
+			return nil, err
+		}
+		known[Identity{Name: entry.AuthorName, Email: entry.AuthorEmail}.key()] = true
+		if p.opts.CoAuthors {
+			for _, id := range parseCoAuthors(entry.Body) {
+				coAuthors[id.key()] = id
+			}
+		}
+	}
+
+	if len(coAuthors) > 0 {
+		normalized, err := p.checkMailmap(ctx, slices.Collect(maps.Values(coAuthors)))
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range normalized {
+			known[id.key()] = true
+		}
+	}
+
+	if p.history == nil {
+		p.history = make(map[string]map[string]bool)
+	}
+	p.history[from] = known
+	return known, nil
+}
+
+// compileIdentityPatterns turns name or email patterns into case-insensitive regexes
+// where "*" matches any sequence of characters.
+func compileIdentityPatterns(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+		compiled = append(compiled, regexp.MustCompile("(?i)^"+expr+"$"))
+	}
+	return compiled
+}
+
+// matchesIdentity reports whether the identity's name or email matches any pattern.
+func matchesIdentity(patterns []*regexp.Regexp, id Identity) bool {
+	for _, re := range patterns {
+		if re.MatchString(id.Name) || (id.Email != "" && re.MatchString(id.Email)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/git"
+)
+
+// ExcludeReason names the rule that excluded a commit from a changelog.
+type ExcludeReason string
+
+const (
+	ExcludeSubject ExcludeReason = "subject"
+	ExcludeAuthor  ExcludeReason = "author"
+	ExcludeScope   ExcludeReason = "scope"
+	ExcludePaths   ExcludeReason = "paths"
+)
+
+// ExcludeRules lists commits to leave out of a changelog. A commit is excluded
+// as soon as one rule matches.
+type ExcludeRules struct {
+	// Subjects are regular expressions matched against the subject line.
+	Subjects []string
+	// Authors are author names or emails; "*" matches any sequence of characters.
+	Authors []string
+	// Scopes are Conventional Commit scopes, compared case-insensitively.
+	Scopes []string
+	// Paths excludes commits whose changed files all match one of these patterns.
+	// "dir/" and "dir/**" match everything below dir, patterns without a slash
+	// match file names in any directory, others are matched with [path.Match].
+	Paths []string
+}
+
+// IsZero reports whether no rules are configured.
+func (r ExcludeRules) IsZero() bool {
+	return len(r.Subjects) == 0 && len(r.Authors) == 0 && len(r.Scopes) == 0 && len(r.Paths) == 0
+}
+
+// Exclusion records a commit that was left out of a changelog and why.
+type Exclusion struct {
+	Commit Commit
+	Reason ExcludeReason
+	// Pattern is the rule value that matched.
+	Pattern string
+}
+
+// compiledExcludeRules holds the ExcludeRules in matchable form.
+type compiledExcludeRules struct {
+	subjects []*regexp.Regexp
+	authors  []*regexp.Regexp
+	rules    ExcludeRules
+}
+
+// compileSubjectPatterns compiles subject exclusion regexes, reporting the first invalid one.
+func compileSubjectPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// compileExcludeRules prepares the rules for matching.
+func compileExcludeRules(rules ExcludeRules) (*compiledExcludeRules, error) {
+	subjects, err := compileSubjectPatterns(rules.Subjects)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledExcludeRules{
+		subjects: subjects,
+		authors:  compileIdentityPatterns(rules.Authors),
+		rules:    rules,
+	}, nil
+}
+
+// match returns the reason and pattern of the first rule matching the commit.
+// files is nil when path rules are not configured.
+func (r *compiledExcludeRules) match(c *Commit, files []string) (ExcludeReason, string, bool) {
+	for i, re := range r.subjects {
+		if re.MatchString(c.Subject) {
+			return ExcludeSubject, r.rules.Subjects[i], true
+		}
+	}
+	for i, re := range r.authors {
+		if re.MatchString(c.Author) || (c.Email != "" && re.MatchString(c.Email)) {
+			return ExcludeAuthor, r.rules.Authors[i], true
+		}
+	}
+	if c.Scope != "" {
+		for _, scope := range r.rules.Scopes {
+			if strings.EqualFold(scope, c.Scope) {
+				return ExcludeScope, scope, true
+			}
+		}
+	}
+	if pattern, ok := onlyTouches(files, r.rules.Paths); ok {
+		return ExcludePaths, pattern, true
+	}
+	return "", "", false
+}
+
+// onlyTouches reports whether every file matches one of the patterns. The returned
+// pattern is the one that matched the first file. Commits without files never match.
+func onlyTouches(files, patterns []string) (string, bool) {
+	if len(files) == 0 || len(patterns) == 0 {
+		return "", false
+	}
+
+	first := ""
+	for _, file := range files {
+		i := slices.IndexFunc(patterns, func(pattern string) bool { return matchPath(pattern, file) })
+		if i < 0 {
+			return "", false
+		}
+		if first == "" {
+			first = patterns[i]
+		}
+	}
+	return first, true
+}
+
+// matchPath matches a repository-relative file path against an exclude path pattern.
+func matchPath(pattern, file string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return file == dir || strings.HasPrefix(file, dir+"/")
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(file, pattern)
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	ok, _ := path.Match(pattern, file)
+	return ok
+}
+
+// exclude splits commits into the ones kept and the ones matching the exclude rules.
+func (p *Parser) exclude(ctx context.Context, from, to string, commits []Commit) ([]Commit, []Exclusion, error) {
+	if p.opts.Exclude.IsZero() || len(commits) == 0 {
+		return commits, nil, nil
+	}
+
+	rules, err := compileExcludeRules(p.opts.Exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files map[string][]string
+	if len(p.opts.Exclude.Paths) > 0 {
+		files, err = p.changedFiles(ctx, from, to)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	kept := make([]Commit, 0, len(commits))
+	var excluded []Exclusion
+	for i := range commits {
+		if reason, pattern, ok := rules.match(&commits[i], files[commits[i].Hash]); ok {
+			excluded = append(excluded, Exclusion{Commit: commits[i], Reason: reason, Pattern: pattern})
+			continue
+		}
+		kept = append(kept, commits[i])
+	}
+	return kept, excluded, nil
+}
+
+// changedFiles returns the files changed by each commit in the range, keyed by hash.
+// Merge commits report their changes against the first parent.
+func (p *Parser) changedFiles(ctx context.Context, from, to string) (map[string][]string, error) {
+	opts := git.LogOptions{Range: logRange(from, to), FirstParent: p.opts.FirstParent, Files: true}
+
+	files := make(map[string][]string)
+	for entry, err := range p.git(ctx).Log(ctx, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("list changed files: %w", err)
+		}
+		files[entry.Hash] = entry.Files
+	}
+	return files, nil
+}
@@ -0,0 +1,177 @@
+package changelog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{pattern: "docs/**", file: "docs/guide/intro.md", want: true},
+		{pattern: "docs/**", file: "docs", want: true},
+		{pattern: "docs/**", file: "docsite/index.md", want: false},
+		{pattern: "docs/", file: "docs/README.md", want: true},
+		{pattern: "*.md", file: "internal/changelog/README.md", want: true},
+		{pattern: "*.md", file: "main.go", want: false},
+		{pattern: "deploy/*.yaml", file: "deploy/prod.yaml", want: true},
+		{pattern: "deploy/*.yaml", file: "deploy/k8s/prod.yaml", want: false},
+		{pattern: "go.sum", file: "go.sum", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.file, func(t *testing.T) {
+			if got := matchPath(tt.pattern, tt.file); got != tt.want {
+				t.Errorf("matchPath(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludeRulesMatch(t *testing.T) {
+	rules, err := compileExcludeRules(ExcludeRules{
+		Subjects: []string{`^chore\(release\)`, `^chore: bump version to `},
+		Authors:  []string{"dependabot*"},
+		Scopes:   []string{"deps"},
+		Paths:    []string{"docs/**", "*.md"},
+	})
+	if err != nil {
+		t.Fatalf("compileExcludeRules() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		commit      Commit
+		files       []string
+		wantReason  ExcludeReason
+		wantPattern string
+	}{
+		{
+			name:        "forge version commit",
+			commit:      Commit{Subject: "chore: bump version to 1.2.0", Type: TypeChore},
+			wantReason:  ExcludeSubject,
+			wantPattern: `^chore: bump version to `,
+		},
+		{
+			name:        "author by email",
+			commit:      Commit{Subject: "build: bump x", Author: "bot", Email: "dependabot[bot]@users.noreply.github.com"},
+			wantReason:  ExcludeAuthor,
+			wantPattern: "dependabot*",
+		},
+		{
+			name:        "scope is case-insensitive",
+			commit:      Commit{Subject: "fix(Deps): pin y", Scope: "Deps"},
+			wantReason:  ExcludeScope,
+			wantPattern: "deps",
+		},
+		{
+			name:        "only touches docs",
+			commit:      Commit{Subject: "fix: typo"},
+			files:       []string{"docs/a.md", "README.md"},
+			wantReason:  ExcludePaths,
+			wantPattern: "docs/**",
+		},
+		{
+			name:   "touches code too",
+			commit: Commit{Subject: "fix: typo"},
+			files:  []string{"docs/a.md", "main.go"},
+		},
+		{
+			name:   "no files",
+			commit: Commit{Subject: "fix: empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, pattern, ok := rules.match(&tt.commit, tt.files)
+			if ok != (tt.wantReason != "") || reason != tt.wantReason || pattern != tt.wantPattern {
+				t.Errorf("match() = %q, %q, %v, want %q, %q", reason, pattern, ok, tt.wantReason, tt.wantPattern)
+			}
+		})
+	}
+
+	if _, err := compileExcludeRules(ExcludeRules{Subjects: []string{"("}}); err == nil {
+		t.Error("compileExcludeRules() accepted an invalid regex")
+	}
+}
+
+func TestParseExclude(t *testing.T) {
+	dir := initTestRepo(t, "chore: initial commit")
+	ctx := t.Context()
+
+	git := func(args ...string) {
+		t.Helper()
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+	write := func(name string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		git("add", name)
+	}
+
+	git("tag", "v1.0.0")
+	write("main.go")
+	git("commit", "-m", "feat: keep me")
+	write("docs/guide.md")
+	git("commit", "-m", "fix: docs only")
+	git("commit", "--allow-empty", "--author", "dependabot[bot] <bot@example.com>", "-m", "build: bump x")
+	git("commit", "--allow-empty", "-m", "chore: bump version to 1.1.0")
+
+	parser := NewParserWithOptions(dir, "v", Options{
+		Exclude: ExcludeRules{
+			Subjects: []string{`^chore: bump version to `},
+			Authors:  []string{"*[bot]"},
+			Paths:    []string{"docs/**"},
+		},
+	})
+	cl, err := parser.Parse(ctx, "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(cl.Commits) != 1 || cl.Commits[0].Subject != "feat: keep me" {
+		t.Fatalf("Commits = %+v, want only the feature", cl.Commits)
+	}
+	if len(cl.Excluded) != 3 {
+		t.Fatalf("got %d excluded commits, want 3", len(cl.Excluded))
+	}
+
+	out, err := FormatJSON(cl)
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	var report struct {
+		Excluded struct {
+			Count   int            `json:"count"`
+			Reasons map[string]int `json:"reasons"`
+		} `json:"excluded"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"subject": 1, "author": 1, "paths": 1}
+	if report.Excluded.Count != 3 || len(report.Excluded.Reasons) != len(want) {
+		t.Fatalf("excluded = %+v, want %v", report.Excluded, want)
+	}
+	for reason, n := range want {
+		if report.Excluded.Reasons[reason] != n {
+			t.Errorf("reasons[%s] = %d, want %d", reason, report.Excluded.Reasons[reason], n)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package changelog
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	AtomFormat Format = "atom"
+	RSSFormat  Format = "rss"
+)
+
+// atomNamespace is the XML namespace of Atom 1.0 feeds.
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// Release is a tagged release and the changes it introduced.
+type Release struct {
+	Tag       string
+	Commit    string
+	Date      time.Time
+	Changelog *Changelog
+}
+
+// FeedInfo describes a release feed.
+type FeedInfo struct {
+	// Name is the app or project the releases belong to, e.g. "api".
+	Name string
+	// BaseURL is the repository's web URL used for release, commit and PR links,
+	// e.g. "https://github.com/org/repo". Links are relative when empty.
+	BaseURL string
+	// Author is the "Name <email>" or name credited as the Atom feed author. Atom
+	// feeds need one, so Name is used when empty.
+	Author string
+}
+
+// ReleaseID returns a stable identifier for a release, derived from its tag and commit.
+func ReleaseID(tag, commit string) string {
+	return fmt.Sprintf("urn:forge:release:%s:%s", tag, commit)
+}
+
+// title returns the feed title.
+func (f FeedInfo) title() string {
+	return f.Name + " releases"
+}
+
+// author returns the Atom author of the feed.
+func (f FeedInfo) author() atomAuthor {
+	if m := identityRegex.FindStringSubmatch(strings.TrimSpace(f.Author)); m != nil {
+		return atomAuthor{Name: m[1], Email: m[2]}
+	}
+	if f.Author != "" {
+		return atomAuthor{Name: f.Author}
+	}
+	return atomAuthor{Name: f.Name}
+}
+
+// link joins the base URL and a repository-relative path.
+func (f FeedInfo) link(path string) string {
+	if f.BaseURL == "" {
+		return path
+	}
+	return strings.TrimSuffix(f.BaseURL, "/") + "/" + path
+}
+
+// FormatHTML renders the changelog as an HTML fragment for feed entries.
+func FormatHTML(cl *Changelog, feed FeedInfo) string {
+	if len(cl.Commits) == 0 {
+		return "<p>No changes.</p>\n"
+	}
+
+	var sb strings.Builder
+
+	breakingChanges := []Commit{}
+	for _, commit := range cl.Commits {
+		if commit.Breaking {
+			breakingChanges = append(breakingChanges, commit)
+		}
+	}
+	writeHTMLSection(&sb, "⚠ BREAKING CHANGES", breakingChanges, feed)
+
+	types := make([]CommitType, 0, len(cl.ByType))
+	for t := range cl.ByType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return GetTypePriority(types[i]) < GetTypePriority(types[j])
+	})
+
+	for _, t := range types {
+		commits := []Commit{}
+		for _, commit := range cl.ByType[t] {
+			// Breaking changes are already listed
+			if !commit.Breaking {
+				commits = append(commits, commit)
+			}
+		}
+		writeHTMLSection(&sb, GetTypeTitle(t), commits, feed)
+	}
+
+	if len(cl.Contributors) > 0 {
+		sb.WriteString("<h3>Contributors</h3>\n<ul>\n")
+		for _, c := range cl.Contributors {
+			fmt.Fprintf(&sb, "<li>%s", html.EscapeString(c.Name))
+			if c.FirstTime {
+				sb.WriteString(" (first contribution)")
+			}
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	return sb.String()
+}
+
+// writeHTMLSection writes a heading and a list of commits, if there are any.
+func writeHTMLSection(sb *strings.Builder, title string, commits []Commit, feed FeedInfo) {
+	if len(commits) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "<h3>%s</h3>\n<ul>\n", html.EscapeString(title))
+	for i := range commits {
+		c := &commits[i]
+		sb.WriteString("<li>")
+		if c.Scope != "" {
+			fmt.Fprintf(sb, "<strong>%s:</strong> ", html.EscapeString(c.Scope))
+		}
+		sb.WriteString(html.EscapeString(displaySubject(c)))
+		fmt.Fprintf(sb, ` (<a href="%s">%s</a>)`,
+			html.EscapeString(feed.link("commit/"+c.Hash)), html.EscapeString(c.ShortHash))
+		if c.PRNumber != "" {
+			fmt.Fprintf(sb, ` <a href="%s">#%s</a>`,
+				html.EscapeString(feed.link("pull/"+c.PRNumber)), html.EscapeString(c.PRNumber))
+		}
+		for _, issue := range c.Issues {
+			if issue.URL == "" {
+				fmt.Fprintf(sb, " %s", html.EscapeString(issue.ID))
+				continue
+			}
+			fmt.Fprintf(sb, ` <a href="%s">%s</a>`, html.EscapeString(issue.URL), html.EscapeString(issue.ID))
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+}
+
+// atomFeed is the XML representation of an Atom feed.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email,omitempty"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// FormatAtom formats the releases (newest first) as an Atom 1.0 feed.
+func FormatAtom(releases []Release, feed FeedInfo) (string, error) {
+	af := atomFeed{
+		Xmlns:   atomNamespace,
+		ID:      "urn:forge:feed:" + feed.Name,
+		Title:   feed.title(),
+		Author:  feed.author(),
+		Entries: make([]atomEntry, 0, len(releases)),
+	}
+	if feed.BaseURL != "" {
+		af.Link = &atomLink{Rel: "alternate", Href: feed.link("releases")}
+	}
+
+	var updated time.Time
+	for _, r := range releases {
+		if r.Date.After(updated) {
+			updated = r.Date
+		}
+		entry := atomEntry{
+			ID:      ReleaseID(r.Tag, r.Commit),
+			Title:   r.Tag,
+			Updated: r.Date.Format(time.RFC3339),
+			Content: atomContent{Type: "html", Body: FormatHTML(r.Changelog, feed)},
+		}
+		if feed.BaseURL != "" {
+			entry.Link = &atomLink{Rel: "alternate", Href: feed.link("releases/tag/" + r.Tag)}
+		}
+		af.Entries = append(af.Entries, entry)
+	}
+	af.Updated = updated.Format(time.RFC3339)
+
+	return marshalFeed(af)
+}
+
+// rssFeed is the XML representation of an RSS 2.0 feed.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// FormatRSS formats the releases (newest first) as an RSS 2.0 feed.
+func FormatRSS(releases []Release, feed FeedInfo) (string, error) {
+	rf := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feed.title(),
+			Link:        feed.link("releases"),
+			Description: feed.title(),
+			Items:       make([]rssItem, 0, len(releases)),
+		},
+	}
+
+	var updated time.Time
+	for _, r := range releases {
+		if r.Date.After(updated) {
+			updated = r.Date
+		}
+		item := rssItem{
+			Title:       r.Tag,
+			GUID:        rssGUID{Value: ReleaseID(r.Tag, r.Commit)},
+			PubDate:     r.Date.Format(time.RFC1123Z),
+			Description: FormatHTML(r.Changelog, feed),
+		}
+		if feed.BaseURL != "" {
+			item.Link = feed.link("releases/tag/" + r.Tag)
+		}
+		rf.Channel.Items = append(rf.Channel.Items, item)
+	}
+	if !updated.IsZero() {
+		rf.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+
+	return marshalFeed(rf)
+}
+
+// marshalFeed encodes a feed as indented XML with an XML declaration.
+func marshalFeed(feed any) (string, error) {
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal feed: %w", err)
+	}
+	return xml.Header + string(data) + "\n", nil
+}
@@ -0,0 +1,164 @@
+package changelog
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReleases() []Release {
+	date := time.Date(2025, 3, 4, 15, 4, 5, 0, time.UTC)
+	return []Release{
+		{
+			Tag:    "v1.1.0",
+			Commit: "bbbbbbb",
+			Date:   date,
+			Changelog: newChangelog("v1.0.0", "v1.1.0", []Commit{
+				{Hash: "bbbbbbb", ShortHash: "bbbbbbb", Subject: "feat(api): add <filter> & sort (#7)",
+					Type: TypeFeat, Scope: "api", PRNumber: "7"},
+			}),
+		},
+		{
+			Tag:       "v1.0.0",
+			Commit:    "aaaaaaa",
+			Date:      date.AddDate(0, -1, 0),
+			Changelog: newChangelog("", "v1.0.0", nil),
+		},
+	}
+}
+
+func TestFormatHTML(t *testing.T) {
+	releases := testReleases()
+
+	tests := []struct {
+		name    string
+		cl      *Changelog
+		baseURL string
+		want    []string
+	}{
+		{
+			name:    "escapes subjects and links to the base URL",
+			cl:      releases[0].Changelog,
+			baseURL: "https://github.com/org/repo/",
+			want: []string{
+				"<h3>Features</h3>",
+				"<strong>api:</strong> add &lt;filter&gt; &amp; sort (#7)",
+				`<a href="https://github.com/org/repo/commit/bbbbbbb">bbbbbbb</a>`,
+				`<a href="https://github.com/org/repo/pull/7">#7</a>`,
+			},
+		},
+		{
+			name: "relative links without base URL",
+			cl:   releases[0].Changelog,
+			want: []string{`<a href="commit/bbbbbbb">`},
+		},
+		{
+			name: "empty release",
+			cl:   releases[1].Changelog,
+			want: []string{"<p>No changes.</p>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatHTML(tt.cl, FeedInfo{Name: "forge", BaseURL: tt.baseURL})
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("FormatHTML() missing %q in\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatAtom(t *testing.T) {
+	feed := FeedInfo{Name: "forge", BaseURL: "https://github.com/org/repo"}
+	out, err := FormatAtom(testReleases(), feed)
+	if err != nil {
+		t.Fatalf("FormatAtom() error = %v", err)
+	}
+
+	var got atomFeed
+	if err := xml.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("FormatAtom() produced invalid XML: %v\n%s", err, out)
+	}
+
+	if got.Title != "forge releases" || got.ID != "urn:forge:feed:forge" {
+		t.Errorf("feed title/id = %q/%q", got.Title, got.ID)
+	}
+	if got.Updated != "2025-03-04T15:04:05Z" {
+		t.Errorf("feed updated = %q, want newest release date", got.Updated)
+	}
+	if len(got.Entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(got.Entries))
+	}
+
+	entry := got.Entries[0]
+	if entry.ID != "urn:forge:release:v1.1.0:bbbbbbb" {
+		t.Errorf("entry id = %q", entry.ID)
+	}
+	if entry.Link == nil || entry.Link.Href != "https://github.com/org/repo/releases/tag/v1.1.0" {
+		t.Errorf("entry link = %+v", entry.Link)
+	}
+	if entry.Content.Type != "html" || !strings.Contains(entry.Content.Body, "<h3>Features</h3>") {
+		t.Errorf("entry content = %+v", entry.Content)
+	}
+	if got.Entries[1].Updated != "2025-02-04T15:04:05Z" {
+		t.Errorf("second entry updated = %q", got.Entries[1].Updated)
+	}
+}
+
+func TestFormatAtomAuthor(t *testing.T) {
+	tests := []struct {
+		author string
+		want   atomAuthor
+	}{
+		{author: "Jane Doe <jane@example.com>", want: atomAuthor{Name: "Jane Doe", Email: "jane@example.com"}},
+		{author: "Release Team", want: atomAuthor{Name: "Release Team"}},
+		{author: "", want: atomAuthor{Name: "forge"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.author, func(t *testing.T) {
+			out, err := FormatAtom(testReleases(), FeedInfo{Name: "forge", Author: tt.author})
+			if err != nil {
+				t.Fatalf("FormatAtom() error = %v", err)
+			}
+			var got atomFeed
+			if err := xml.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("FormatAtom() produced invalid XML: %v\n%s", err, out)
+			}
+			if got.Author != tt.want {
+				t.Errorf("feed author = %+v, want %+v", got.Author, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRSS(t *testing.T) {
+	out, err := FormatRSS(testReleases(), FeedInfo{Name: "forge"})
+	if err != nil {
+		t.Fatalf("FormatRSS() error = %v", err)
+	}
+
+	var got rssFeed
+	if err := xml.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("FormatRSS() produced invalid XML: %v\n%s", err, out)
+	}
+
+	if got.Version != "2.0" || len(got.Channel.Items) != 2 {
+		t.Fatalf("rss version = %q, items = %d", got.Version, len(got.Channel.Items))
+	}
+
+	item := got.Channel.Items[0]
+	if item.GUID.Value != "urn:forge:release:v1.1.0:bbbbbbb" || item.GUID.IsPermaLink {
+		t.Errorf("item guid = %+v", item.GUID)
+	}
+	if item.PubDate != "Tue, 04 Mar 2025 15:04:05 +0000" {
+		t.Errorf("item pubDate = %q", item.PubDate)
+	}
+	if item.Link != "" {
+		t.Errorf("item link = %q, want none without base URL", item.Link)
+	}
+}
@@ -15,6 +15,8 @@ const (
 	MarkdownFormat Format = "markdown"
 	JSONFormat     Format = "json"
 	PlainFormat    Format = "plain"
+	DebianFormat   Format = "debian"
+	RPMFormat      Format = "rpm"
 )
 
 const (
@@ -92,6 +94,28 @@ func FormatMarkdown(cl *Changelog) string {
 		sb.WriteString("\n")
 	}
 
+	// Issues
+	if len(cl.Issues) > 0 {
+		sb.WriteString("## 🎫 Issues in this release\n\n")
+		for _, issue := range cl.Issues {
+			fmt.Fprintf(&sb, "* %s\n", formatIssueLink(issue))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Contributors last
+	if len(cl.Contributors) > 0 {
+		sb.WriteString("## 👥 Contributors\n\n")
+		for _, c := range cl.Contributors {
+			fmt.Fprintf(&sb, "* %s", c.Name)
+			if c.FirstTime {
+				sb.WriteString(" 🎉 *first contribution*")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -106,24 +130,32 @@ func formatMarkdownCommit(c *Commit) string {
 	}
 
 	// Subject (remove conventional commit prefix if present)
-	subject := c.Subject
-	if c.Type != TypeOther {
-		// Remove "type(scope): " or "type: " prefix
-		parts := strings.SplitN(subject, ": ", splitParts)
-		if len(parts) == splitParts {
-			subject = parts[1]
-		}
-	}
-	sb.WriteString(subject)
+	sb.WriteString(displaySubject(c))
 
 	// Commit hash
 	fmt.Fprintf(&sb, " ([%s](commit/%s))", c.ShortHash, c.Hash)
 
+	// Reverted release
+	if c.RevertsRelease != "" {
+		fmt.Fprintf(&sb, ", reverts [%s](commit/%s) from [%s](releases/tag/%s)",
+			shortHash(c.Reverts), c.Reverts, c.RevertsRelease, c.RevertsRelease)
+	}
+
 	// PR number
 	if c.PRNumber != "" {
 		fmt.Fprintf(&sb, " [#%s](pull/%s)", c.PRNumber, c.PRNumber)
 	}
 
+	// Issues
+	for _, issue := range c.Issues {
+		fmt.Fprintf(&sb, " %s", formatIssueLink(issue))
+	}
+
+	// Collapsed commits
+	if len(c.Grouped) > 0 {
+		fmt.Fprintf(&sb, " (%d commits)", len(c.Grouped)+1)
+	}
+
 	sb.WriteString("\n")
 
 	return sb.String()
@@ -202,6 +234,32 @@ func FormatPlain(cl *Changelog) string {
 		sb.WriteString("\n")
 	}
 
+	// Issues
+	if len(cl.Issues) > 0 {
+		sb.WriteString("Issues in this release\n")
+		sb.WriteString(strings.Repeat("-", separatorWidth))
+		sb.WriteString("\n\n")
+		for _, issue := range cl.Issues {
+			fmt.Fprintf(&sb, "  * %s\n", issue.ID)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Contributors last
+	if len(cl.Contributors) > 0 {
+		sb.WriteString("Contributors\n")
+		sb.WriteString(strings.Repeat("-", separatorWidth))
+		sb.WriteString("\n\n")
+		for _, c := range cl.Contributors {
+			fmt.Fprintf(&sb, "  * %s", c.Name)
+			if c.FirstTime {
+				sb.WriteString(" (first contribution)")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -216,50 +274,143 @@ func formatPlainCommit(c *Commit) string {
 	}
 
 	// Subject
-	subject := c.Subject
-	if c.Type != TypeOther {
-		parts := strings.SplitN(subject, ": ", splitParts)
-		if len(parts) == splitParts {
-			subject = parts[1]
-		}
-	}
-	sb.WriteString(subject)
+	sb.WriteString(displaySubject(c))
 
 	// Commit hash
 	fmt.Fprintf(&sb, " (%s)", c.ShortHash)
 
+	// Reverted release
+	if c.RevertsRelease != "" {
+		fmt.Fprintf(&sb, ", reverts %s from %s", shortHash(c.Reverts), c.RevertsRelease)
+	}
+
 	// PR number
 	if c.PRNumber != "" {
 		fmt.Fprintf(&sb, " #%s", c.PRNumber)
 	}
 
+	// Issues not already named in the subject
+	for _, issue := range c.Issues {
+		if !strings.Contains(c.Subject, issue.ID) {
+			fmt.Fprintf(&sb, " %s", issue.ID)
+		}
+	}
+
+	// Collapsed commits
+	if len(c.Grouped) > 0 {
+		fmt.Fprintf(&sb, " (%d commits)", len(c.Grouped)+1)
+	}
+
 	sb.WriteString("\n")
 
 	return sb.String()
 }
 
-// FormatJSON formats the changelog as JSON.
-func FormatJSON(cl *Changelog) (string, error) {
-	// Create JSON-friendly structure
-	type JSONCommit struct {
-		Hash      string    `json:"hash"`
-		ShortHash string    `json:"short_hash"`
-		Subject   string    `json:"subject"`
-		Author    string    `json:"author"`
-		Date      time.Time `json:"date"`
-		Type      string    `json:"type"`
-		Scope     string    `json:"scope,omitempty"`
-		Breaking  bool      `json:"breaking,omitempty"`
-		PRNumber  string    `json:"pr_number,omitempty"`
+// displaySubject returns the subject without its "type(scope): " prefix.
+// Subjects that are not Conventional Commits (e.g. Revert "...") are kept as is.
+func displaySubject(c *Commit) string {
+	if c.Type == TypeOther || !conventionalRegex.MatchString(c.Subject) {
+		return c.Subject
+	}
+	parts := strings.SplitN(c.Subject, ": ", splitParts)
+	if len(parts) == splitParts {
+		return parts[1]
+	}
+	return c.Subject
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	const length = 7
+	if len(hash) > length {
+		return hash[:length]
+	}
+	return hash
+}
+
+// jsonCommit is the JSON representation of a commit.
+type jsonCommit struct {
+	Hash       string      `json:"hash"`
+	ShortHash  string      `json:"short_hash"`
+	Subject    string      `json:"subject"`
+	Author     string      `json:"author"`
+	Email      string      `json:"email,omitempty"`
+	CoAuthors  []string    `json:"co_authors,omitempty"`
+	Date       time.Time   `json:"date"`
+	CommitDate time.Time   `json:"commit_date,omitzero"`
+	Type       string      `json:"type"`
+	Scope      string      `json:"scope,omitempty"`
+	Breaking   bool        `json:"breaking,omitempty"`
+	PRNumber   string      `json:"pr_number,omitempty"`
+	Reverts    string      `json:"reverts,omitempty"`
+	RevertsRel string      `json:"reverts_release,omitempty"`
+	Issues     []jsonIssue `json:"issues,omitempty"`
+	Grouped    []string    `json:"grouped,omitempty"`
+}
+
+// toJSONCommit converts a commit to its JSON representation.
+func toJSONCommit(c *Commit) jsonCommit {
+	jc := jsonCommit{
+		Hash:       c.Hash,
+		ShortHash:  c.ShortHash,
+		Subject:    c.Subject,
+		Author:     c.Author,
+		Email:      c.Email,
+		Date:       c.Date,
+		CommitDate: c.CommitDate,
+		Type:       string(c.Type),
+		Scope:      c.Scope,
+		Breaking:   c.Breaking,
+		PRNumber:   c.PRNumber,
+		Reverts:    c.Reverts,
+		RevertsRel: c.RevertsRelease,
+		Issues:     toJSONIssues(c.Issues),
+	}
+	for _, id := range c.CoAuthors {
+		jc.CoAuthors = append(jc.CoAuthors, id.String())
+	}
+	for _, g := range c.Grouped {
+		jc.Grouped = append(jc.Grouped, g.Hash)
 	}
+	return jc
+}
+
+// jsonContributor is the JSON representation of a contributor.
+type jsonContributor struct {
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	Commits   int    `json:"commits"`
+	FirstTime bool   `json:"first_time"`
+}
+
+// jsonExcluded reports the commits dropped by the exclude rules.
+type jsonExcluded struct {
+	Count   int                  `json:"count"`
+	Reasons map[string]int       `json:"reasons"`
+	Commits []jsonExcludedCommit `json:"commits"`
+}
 
+// jsonExcludedCommit is the JSON representation of an excluded commit.
+type jsonExcludedCommit struct {
+	Hash      string `json:"hash"`
+	ShortHash string `json:"short_hash"`
+	Subject   string `json:"subject"`
+	Reason    string `json:"reason"`
+	Pattern   string `json:"pattern"`
+}
+
+// FormatJSON formats the changelog as JSON.
+func FormatJSON(cl *Changelog) (string, error) {
 	type JSONChangelog struct {
-		FromTag  string                  `json:"from_tag,omitempty"`
-		ToTag    string                  `json:"to_tag,omitempty"`
-		FromDate time.Time               `json:"from_date,omitzero"`
-		ToDate   time.Time               `json:"to_date,omitzero"`
-		Commits  []JSONCommit            `json:"commits"`
-		ByType   map[string][]JSONCommit `json:"by_type"`
+		FromTag      string                  `json:"from_tag,omitempty"`
+		ToTag        string                  `json:"to_tag,omitempty"`
+		FromDate     time.Time               `json:"from_date,omitzero"`
+		ToDate       time.Time               `json:"to_date,omitzero"`
+		Commits      []jsonCommit            `json:"commits"`
+		ByType       map[string][]jsonCommit `json:"by_type"`
+		Contributors []jsonContributor       `json:"contributors,omitempty"`
+		Issues       []jsonIssue             `json:"issues,omitempty"`
+		Excluded     *jsonExcluded           `json:"excluded,omitempty"`
 	}
 
 	jsonCL := JSONChangelog{
@@ -267,43 +418,51 @@ func FormatJSON(cl *Changelog) (string, error) {
 		ToTag:    cl.ToTag,
 		FromDate: cl.FromDate,
 		ToDate:   cl.ToDate,
-		Commits:  make([]JSONCommit, 0, len(cl.Commits)),
-		ByType:   make(map[string][]JSONCommit),
+		Commits:  make([]jsonCommit, 0, len(cl.Commits)),
+		ByType:   make(map[string][]jsonCommit),
+		Issues:   toJSONIssues(cl.Issues),
 	}
 
 	// Convert commits
 	for _, c := range cl.Commits {
-		jc := JSONCommit{
-			Hash:      c.Hash,
-			ShortHash: c.ShortHash,
-			Subject:   c.Subject,
-			Author:    c.Author,
-			Date:      c.Date,
-			Type:      string(c.Type),
-			Scope:     c.Scope,
-			Breaking:  c.Breaking,
-			PRNumber:  c.PRNumber,
-		}
-		jsonCL.Commits = append(jsonCL.Commits, jc)
+		jsonCL.Commits = append(jsonCL.Commits, toJSONCommit(&c))
 	}
 
 	// Convert by type
 	for t, commits := range cl.ByType {
 		typeStr := string(t)
-		jsonCL.ByType[typeStr] = make([]JSONCommit, 0, len(commits))
+		jsonCL.ByType[typeStr] = make([]jsonCommit, 0, len(commits))
 		for _, c := range commits {
-			jc := JSONCommit{
-				Hash:      c.Hash,
-				ShortHash: c.ShortHash,
-				Subject:   c.Subject,
-				Author:    c.Author,
-				Date:      c.Date,
-				Type:      string(c.Type),
-				Scope:     c.Scope,
-				Breaking:  c.Breaking,
-				PRNumber:  c.PRNumber,
-			}
-			jsonCL.ByType[typeStr] = append(jsonCL.ByType[typeStr], jc)
+			jsonCL.ByType[typeStr] = append(jsonCL.ByType[typeStr], toJSONCommit(&c))
+		}
+	}
+
+	// Convert contributors
+	for _, c := range cl.Contributors {
+		jsonCL.Contributors = append(jsonCL.Contributors, jsonContributor{
+			Name:      c.Name,
+			Email:     c.Email,
+			Commits:   c.Commits,
+			FirstTime: c.FirstTime,
+		})
+	}
+
+	// Report excluded commits
+	if len(cl.Excluded) > 0 {
+		jsonCL.Excluded = &jsonExcluded{
+			Count:   len(cl.Excluded),
+			Reasons: make(map[string]int),
+			Commits: make([]jsonExcludedCommit, 0, len(cl.Excluded)),
+		}
+		for _, e := range cl.Excluded {
+			jsonCL.Excluded.Reasons[string(e.Reason)]++
+			jsonCL.Excluded.Commits = append(jsonCL.Excluded.Commits, jsonExcludedCommit{
+				Hash:      e.Commit.Hash,
+				ShortHash: e.Commit.ShortHash,
+				Subject:   e.Commit.Subject,
+				Reason:    string(e.Reason),
+				Pattern:   e.Pattern,
+			})
 		}
 	}
 
@@ -0,0 +1,173 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// issueIDPlaceholder is replaced with the issue ID in issue URL templates.
+const issueIDPlaceholder = "{id}"
+
+// IssuePattern recognizes issue tracker references in commit messages.
+type IssuePattern struct {
+	// Pattern is a regular expression matching an issue reference, e.g. `PAY-\d+`.
+	// The ID is the first capture group if there is one, the whole match otherwise.
+	Pattern string
+	// URL is the link template; "{id}" is replaced with the issue ID,
+	// e.g. "https://example.atlassian.net/browse/{id}".
+	URL string
+}
+
+// Issue is an issue tracker reference found in a commit message.
+type Issue struct {
+	ID  string
+	URL string
+}
+
+// compiledIssuePattern is an IssuePattern in matchable form.
+type compiledIssuePattern struct {
+	re  *regexp.Regexp
+	url string
+}
+
+// compileIssuePatterns compiles the issue patterns, reporting the first invalid one.
+func compileIssuePatterns(patterns []IssuePattern) ([]compiledIssuePattern, error) {
+	compiled := make([]compiledIssuePattern, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern %q: %w", p.Pattern, err)
+		}
+		compiled = append(compiled, compiledIssuePattern{re: re, url: p.URL})
+	}
+	return compiled, nil
+}
+
+// extractIssues returns the issues referenced in the subject and body (including
+// trailers), in order of appearance and without duplicates.
+func extractIssues(patterns []compiledIssuePattern, subject, body string) []Issue {
+	var issues []Issue
+	text := subject + "\n" + body
+	for _, p := range patterns {
+		for _, m := range p.re.FindAllStringSubmatch(text, -1) {
+			id := m[0]
+			if len(m) > 1 && m[1] != "" {
+				id = m[1]
+			}
+			if slices.ContainsFunc(issues, func(i Issue) bool { return i.ID == id }) {
+				continue
+			}
+			issues = append(issues, Issue{ID: id, URL: strings.ReplaceAll(p.url, issueIDPlaceholder, id)})
+		}
+	}
+	return issues
+}
+
+// addIssues appends the issues not yet in the list.
+func addIssues(list []Issue, issues ...Issue) []Issue {
+	for _, issue := range issues {
+		if !slices.ContainsFunc(list, func(i Issue) bool { return i.ID == issue.ID }) {
+			list = append(list, issue)
+		}
+	}
+	return list
+}
+
+// collectIssues aggregates the issues of all commits, including grouped ones, sorted by ID.
+func collectIssues(commits []Commit) []Issue {
+	var issues []Issue
+	for _, c := range commits {
+		issues = addIssues(issues, c.Issues...)
+		for _, g := range c.Grouped {
+			issues = addIssues(issues, g.Issues...)
+		}
+	}
+	slices.SortFunc(issues, func(a, b Issue) int { return compareIssueIDs(a.ID, b.ID) })
+	return issues
+}
+
+// compareIssueIDs orders IDs by their project key, then numerically by their
+// trailing number, so that PAY-9 sorts before PAY-10.
+func compareIssueIDs(a, b string) int {
+	keyA, numA := splitIssueID(a)
+	keyB, numB := splitIssueID(b)
+	if c := strings.Compare(keyA, keyB); c != 0 {
+		return c
+	}
+	if numA != numB {
+		if numA < numB {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// splitIssueID splits an ID into the part before its trailing digits and their value.
+func splitIssueID(id string) (string, int) {
+	i := len(id)
+	for i > 0 && id[i-1] >= '0' && id[i-1] <= '9' {
+		i--
+	}
+	n, _ := strconv.Atoi(id[i:])
+	return id[:i], n
+}
+
+// formatIssueLink returns the issue as a Markdown link, or its ID if it has no URL.
+func formatIssueLink(issue Issue) string {
+	if issue.URL == "" {
+		return issue.ID
+	}
+	return fmt.Sprintf("[%s](%s)", issue.ID, issue.URL)
+}
+
+// jsonIssue is the JSON representation of an issue.
+type jsonIssue struct {
+	ID  string `json:"id"`
+	URL string `json:"url,omitempty"`
+}
+
+// toJSONIssues converts issues to their JSON representation.
+func toJSONIssues(issues []Issue) []jsonIssue {
+	var out []jsonIssue
+	for _, i := range issues {
+		out = append(out, jsonIssue(i))
+	}
+	return out
+}
+
+// FormatIssues lists the issues of a release on their own, e.g. for release tickets.
+func FormatIssues(cl *Changelog, format Format) (string, error) {
+	var sb strings.Builder
+
+	switch format {
+	case JSONFormat:
+		issues := toJSONIssues(cl.Issues)
+		if issues == nil {
+			issues = []jsonIssue{}
+		}
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal JSON: %w", err)
+		}
+		return string(data), nil
+	case MarkdownFormat:
+		for _, issue := range cl.Issues {
+			fmt.Fprintf(&sb, "* %s\n", formatIssueLink(issue))
+		}
+	default:
+		for _, issue := range cl.Issues {
+			sb.WriteString(issue.ID)
+			if issue.URL != "" {
+				fmt.Fprintf(&sb, " %s", issue.URL)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
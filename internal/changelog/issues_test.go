@@ -0,0 +1,110 @@
+package changelog
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExtractIssues(t *testing.T) {
+	patterns, err := compileIssuePatterns([]IssuePattern{
+		{Pattern: `\b[A-Z][A-Z0-9]+-\d+\b`, URL: "https://jira.example.com/browse/{id}"},
+		{Pattern: `(?i)\bissue (\d+)`, URL: "https://github.com/org/repo/issues/{id}"},
+	})
+	if err != nil {
+		t.Fatalf("compileIssuePatterns() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    []Issue
+	}{
+		{
+			name:    "subject and trailer, deduplicated",
+			subject: "fix(pay): retry PAY-1234",
+			body:    "Longer explanation.\n\nRefs: PAY-1234, OPS-7",
+			want: []Issue{
+				{ID: "PAY-1234", URL: "https://jira.example.com/browse/PAY-1234"},
+				{ID: "OPS-7", URL: "https://jira.example.com/browse/OPS-7"},
+			},
+		},
+		{
+			name:    "capture group is the ID",
+			subject: "fix: crash (see issue 42)",
+			want:    []Issue{{ID: "42", URL: "https://github.com/org/repo/issues/42"}},
+		},
+		{
+			name:    "no references",
+			subject: "chore: tidy up",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractIssues(patterns, tt.subject, tt.body); !slices.Equal(got, tt.want) {
+				t.Errorf("extractIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := compileIssuePatterns([]IssuePattern{{Pattern: "PAY-("}}); err == nil {
+		t.Error("compileIssuePatterns() accepted an invalid regex")
+	}
+}
+
+func TestCollectIssues(t *testing.T) {
+	commits := []Commit{
+		{Issues: []Issue{{ID: "PAY-10"}, {ID: "OPS-2"}}},
+		{Issues: []Issue{{ID: "PAY-9"}}, Grouped: []Commit{{Issues: []Issue{{ID: "PAY-10"}, {ID: "PAY-100"}}}}},
+	}
+
+	var got []string
+	for _, issue := range collectIssues(commits) {
+		got = append(got, issue.ID)
+	}
+	want := []string{"OPS-2", "PAY-9", "PAY-10", "PAY-100"}
+	if !slices.Equal(got, want) {
+		t.Errorf("collectIssues() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatIssues(t *testing.T) {
+	cl := newChangelog("v1.0.0", "v1.1.0", []Commit{
+		{Hash: "abc1234", ShortHash: "abc1234", Subject: "fix: retry PAY-1", Type: TypeFix,
+			Issues: []Issue{{ID: "PAY-1", URL: "https://jira/PAY-1"}, {ID: "PAY-2"}}},
+	})
+	cl.Issues = collectIssues(cl.Commits)
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{format: MarkdownFormat, want: "* [PAY-1](https://jira/PAY-1)\n* PAY-2\n"},
+		{format: PlainFormat, want: "PAY-1 https://jira/PAY-1\nPAY-2\n"},
+		{format: JSONFormat, want: `"id": "PAY-1",`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := FormatIssues(cl, tt.format)
+			if err != nil {
+				t.Fatalf("FormatIssues() error = %v", err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("FormatIssues() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+
+	md := FormatMarkdown(cl)
+	for _, want := range []string{
+		"retry PAY-1 ([abc1234](commit/abc1234)) [PAY-1](https://jira/PAY-1) PAY-2\n",
+		"## 🎫 Issues in this release\n\n* [PAY-1](https://jira/PAY-1)\n* PAY-2\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("FormatMarkdown() missing %q in\n%s", want, md)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Lint rule identifiers reported in violations.
+const (
+	RuleFormat         = "format"
+	RuleType           = "type"
+	RuleScope          = "scope"
+	RuleSubjectLength  = "subject-length"
+	RuleBreakingFooter = "breaking-footer"
+)
+
+// LintRules configures which Conventional Commit checks a message must pass.
+type LintRules struct {
+	// Types lists the allowed commit types.
+	Types []string
+	// Scopes lists the allowed scopes. Empty allows any scope.
+	Scopes []string
+	// MaxSubjectLength limits the length of the subject line. Zero disables the check.
+	MaxSubjectLength int
+}
+
+// Violation describes a single lint failure with a suggested fix.
+type Violation struct {
+	Rule       string
+	Message    string
+	Suggestion string
+}
+
+//nolint:gochecknoglobals // compiled regexes and prefixes are immutable and reused across lint runs
+var (
+	// breakingFooterRegex matches lines that look like a breaking change footer, in any spelling.
+	breakingFooterRegex = regexp.MustCompile(`(?i)^breaking[ _-]?changes?\s*:`)
+	// validBreakingFooterRegex matches a breaking change footer as the spec requires it.
+	validBreakingFooterRegex = regexp.MustCompile(`^BREAKING[ -]CHANGE: \S`)
+	// autosquashPrefixes are added by git commit --fixup/--squash and removed by rebase --autosquash.
+	autosquashPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+)
+
+// LintMessage validates a full commit message (subject, blank line, body).
+// Comment lines starting with '#' are ignored, as git strips them from the final message.
+func LintMessage(message string, rules LintRules) []Violation {
+	lines := []string{}
+	for line := range strings.Lines(message) {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, "\r\n"))
+		}
+	}
+	message = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	subject, body, _ := strings.Cut(message, "\n")
+	return Lint(subject, strings.TrimSpace(body), rules)
+}
+
+// Lint validates a commit subject and body against the rules.
+func Lint(subject, body string, rules LintRules) []Violation {
+	for _, prefix := range autosquashPrefixes {
+		subject = strings.TrimPrefix(subject, prefix)
+	}
+
+	// Reverts generated by git revert are accepted as they are.
+	if revertSubjectRegex.MatchString(subject) {
+		return nil
+	}
+
+	violations := lintConventional(subject, rules)
+
+	if rules.MaxSubjectLength > 0 && len([]rune(subject)) > rules.MaxSubjectLength {
+		violations = append(violations, Violation{
+			Rule:       RuleSubjectLength,
+			Message:    fmt.Sprintf("subject is %d characters long (max %d)", len([]rune(subject)), rules.MaxSubjectLength),
+			Suggestion: "shorten the subject and move details into the commit body",
+		})
+	}
+
+	return append(violations, lintBreakingFooter(body)...)
+}
+
+// lintConventional checks the subject format, type and scope.
+func lintConventional(subject string, rules LintRules) []Violation {
+	matches := conventionalRegex.FindStringSubmatch(subject)
+	if matches == nil {
+		return []Violation{{
+			Rule:       RuleFormat,
+			Message:    "subject does not follow the Conventional Commits format",
+			Suggestion: "use 'type(scope): description', e.g. 'fix(api): handle empty response'",
+		}}
+	}
+
+	violations := []Violation{}
+
+	commitType := matches[conventionalRegex.SubexpIndex("type")]
+	if len(rules.Types) > 0 && !slices.Contains(rules.Types, commitType) {
+		suggestion := "use one of: " + strings.Join(rules.Types, ", ")
+		if lower := strings.ToLower(commitType); lower != commitType && slices.Contains(rules.Types, lower) {
+			suggestion = fmt.Sprintf("write the type in lowercase: '%s'", lower)
+		}
+		violations = append(violations, Violation{
+			Rule:       RuleType,
+			Message:    fmt.Sprintf("type '%s' is not allowed", commitType),
+			Suggestion: suggestion,
+		})
+	}
+
+	scope := matches[conventionalRegex.SubexpIndex("scope")]
+	if scope != "" && len(rules.Scopes) > 0 && !slices.Contains(rules.Scopes, scope) {
+		violations = append(violations, Violation{
+			Rule:       RuleScope,
+			Message:    fmt.Sprintf("scope '%s' is not allowed", scope),
+			Suggestion: "use one of: " + strings.Join(rules.Scopes, ", "),
+		})
+	}
+
+	return violations
+}
+
+// lintBreakingFooter reports breaking change footers that tools would not recognise.
+func lintBreakingFooter(body string) []Violation {
+	violations := []Violation{}
+	for line := range strings.Lines(body) {
+		line = strings.TrimSpace(line)
+		if !breakingFooterRegex.MatchString(line) || validBreakingFooterRegex.MatchString(line) {
+			continue
+		}
+		violations = append(violations, Violation{
+			Rule:       RuleBreakingFooter,
+			Message:    fmt.Sprintf("malformed breaking change footer: '%s'", line),
+			Suggestion: "write 'BREAKING CHANGE: <description>' in uppercase, followed by a colon and a space",
+		})
+	}
+	return violations
+}
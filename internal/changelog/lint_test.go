@@ -0,0 +1,84 @@
+package changelog
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	rules := LintRules{
+		Types:            []string{"feat", "fix", "chore"},
+		Scopes:           []string{"api", "cli"},
+		MaxSubjectLength: 50,
+	}
+
+	tests := []struct {
+		name      string
+		subject   string
+		body      string
+		wantRules []string
+	}{
+		{name: "valid", subject: "feat(api): add pagination"},
+		{name: "valid without scope", subject: "fix: handle nil config"},
+		{name: "valid breaking footer", subject: "feat!: drop v1", body: "BREAKING CHANGE: v1 endpoints are gone"},
+		{name: "git revert", subject: `Revert "feat(api): add pagination"`},
+		{name: "fixup commit", subject: "fixup! fix: handle nil config"},
+		{name: "not conventional", subject: "update stuff", wantRules: []string{RuleFormat}},
+		{name: "unknown type", subject: "feature: add x", wantRules: []string{RuleType}},
+		{name: "uppercase type", subject: "Fix: add x", wantRules: []string{RuleType}},
+		{name: "scope not allowed", subject: "fix(db): add x", wantRules: []string{RuleScope}},
+		{
+			name:      "subject too long",
+			subject:   "fix: a very long subject line that keeps on going and going",
+			wantRules: []string{RuleSubjectLength},
+		},
+		{
+			name:      "malformed breaking footer",
+			subject:   "feat: x",
+			body:      "details\n\nBreaking change: lowercase",
+			wantRules: []string{RuleBreakingFooter},
+		},
+		{
+			name:      "breaking footer without description",
+			subject:   "feat: x",
+			body:      "BREAKING CHANGE:",
+			wantRules: []string{RuleBreakingFooter},
+		},
+		{
+			name:      "multiple violations",
+			subject:   "Feat(db): add x",
+			wantRules: []string{RuleType, RuleScope},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, v := range Lint(tt.subject, tt.body, rules) {
+				got = append(got, v.Rule)
+				if v.Suggestion == "" {
+					t.Errorf("violation %q has no suggestion", v.Rule)
+				}
+			}
+			if tt.wantRules == nil {
+				tt.wantRules = []string{}
+			}
+			if !slices.Equal(got, tt.wantRules) {
+				t.Errorf("Lint() rules = %v, want %v", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestLintMessage(t *testing.T) {
+	rules := LintRules{Types: []string{"feat"}, MaxSubjectLength: 72}
+
+	message := "feat: add x\n\nbody\n# Please enter the commit message for your changes.\n"
+	if v := LintMessage(message, rules); len(v) != 0 {
+		t.Errorf("LintMessage() = %v, want no violations", v)
+	}
+
+	if v := LintMessage("# comment only\nbad subject\n", rules); len(v) != 1 || v[0].Rule != RuleFormat {
+		t.Errorf("LintMessage() = %v, want format violation", v)
+	}
+}
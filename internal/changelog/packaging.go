@@ -0,0 +1,147 @@
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// debianDateFormat is the RFC 2822 date used in debian/changelog trailer lines.
+	debianDateFormat = "Mon, 02 Jan 2006 15:04:05 -0700"
+	// rpmDateFormat is the date used in RPM spec %changelog headers.
+	rpmDateFormat = "Mon Jan 02 2006"
+)
+
+// PackageInfo describes the package a Debian or RPM changelog entry belongs to.
+type PackageInfo struct {
+	// Name is the source package name.
+	Name string
+	// Version is the upstream version without tag prefix, e.g. "1.2.0-rc.1".
+	Version string
+	// Revision is the package revision appended to the version, e.g. "1".
+	Revision string
+	// HotfixSuffix is the prerelease identifier of hotfix versions, e.g. "hotfix".
+	HotfixSuffix string
+	// Maintainer is the "Name <email>" signing the entry.
+	Maintainer string
+	// Distribution is the Debian target distribution, e.g. "unstable".
+	Distribution string
+	// Urgency is the Debian upload urgency, e.g. "medium".
+	Urgency string
+}
+
+// PackageVersion maps an upstream version to a Debian/RPM package version.
+// Prereleases use "~" so that dpkg and rpm sort them before the release:
+// "1.2.0-rc.1" becomes "1.2.0~rc1". Hotfixes of a release, whose prerelease starts
+// with hotfixSuffix, use "+" to sort after it: "1.5.0-hotfix.1" becomes
+// "1.5.0+hotfix1". The revision, if set, is appended with "-".
+func PackageVersion(upstream, revision, hotfixSuffix string) string {
+	core, meta, hasMeta := strings.Cut(upstream, "+")
+
+	if base, pre, ok := strings.Cut(core, "-"); ok {
+		ids := strings.Split(pre, ".")
+		var sb strings.Builder
+		for i, id := range ids {
+			// Join a numeric identifier directly to the one before it (rc.1 -> rc1).
+			if i > 0 && !isNumeric(id) {
+				sb.WriteString(".")
+			}
+			sb.WriteString(id)
+		}
+		separator := "~"
+		if hotfixSuffix != "" && ids[0] == hotfixSuffix {
+			separator = "+"
+		}
+		core = base + separator + sb.String()
+	}
+
+	v := core
+	if hasMeta {
+		v += "+" + meta
+	}
+	if revision != "" {
+		v += "-" + revision
+	}
+	return v
+}
+
+// FormatDebian formats the changelog as a debian/changelog entry.
+func FormatDebian(cl *Changelog, pkg PackageInfo) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s (%s) %s; urgency=%s\n\n",
+		pkg.Name, PackageVersion(pkg.Version, pkg.Revision, pkg.HotfixSuffix), pkg.Distribution, pkg.Urgency)
+
+	for _, line := range packageEntries(cl) {
+		fmt.Fprintf(&sb, "  * %s\n", line)
+	}
+
+	fmt.Fprintf(&sb, "\n -- %s  %s\n", pkg.Maintainer, cl.ToDate.Format(debianDateFormat))
+
+	return sb.String()
+}
+
+// FormatRPM formats the changelog as an RPM spec %changelog entry.
+func FormatRPM(cl *Changelog, pkg PackageInfo) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "* %s %s - %s\n",
+		cl.ToDate.Format(rpmDateFormat), pkg.Maintainer, PackageVersion(pkg.Version, pkg.Revision, pkg.HotfixSuffix))
+
+	for _, line := range packageEntries(cl) {
+		fmt.Fprintf(&sb, "- %s\n", line)
+	}
+
+	return sb.String()
+}
+
+// packageEntries returns one line per commit, breaking changes first, then by
+// type priority. Packaging changelogs have no sections, so the type is kept as
+// a "type(scope):" prefix.
+func packageEntries(cl *Changelog) []string {
+	commits := make([]Commit, len(cl.Commits))
+	copy(commits, cl.Commits)
+	sort.SliceStable(commits, func(i, j int) bool {
+		if commits[i].Breaking != commits[j].Breaking {
+			return commits[i].Breaking
+		}
+		return GetTypePriority(commits[i].Type) < GetTypePriority(commits[j].Type)
+	})
+
+	lines := make([]string, 0, len(commits))
+	for i := range commits {
+		c := &commits[i]
+		var sb strings.Builder
+		if c.Breaking {
+			sb.WriteString("BREAKING: ")
+		}
+		if c.Type != TypeOther && conventionalRegex.MatchString(c.Subject) {
+			sb.WriteString(string(c.Type))
+			if c.Scope != "" {
+				fmt.Fprintf(&sb, "(%s)", c.Scope)
+			}
+			sb.WriteString(": ")
+		}
+		subject := displaySubject(c)
+		sb.WriteString(subject)
+		if c.PRNumber != "" && !strings.Contains(subject, "#"+c.PRNumber) {
+			fmt.Fprintf(&sb, " (#%s)", c.PRNumber)
+		}
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPackageVersion(t *testing.T) {
+	tests := []struct {
+		upstream     string
+		revision     string
+		hotfixSuffix string
+		want         string
+	}{
+		{upstream: "1.2.0", revision: "1", want: "1.2.0-1"},
+		{upstream: "1.2.0", want: "1.2.0"},
+		{upstream: "1.2.0-rc.1", revision: "1", want: "1.2.0~rc1-1"},
+		{upstream: "1.2.0-alpha.beta.2", want: "1.2.0~alpha.beta2"},
+		{upstream: "1.2.0-rc.1+build.5", revision: "2", want: "1.2.0~rc1+build.5-2"},
+		{upstream: "1.2.3-hotfix.1", want: "1.2.3+hotfix1"},
+		{upstream: "1.2.3-hotfix.2", revision: "1", want: "1.2.3+hotfix2-1"},
+		{upstream: "1.2.3-hotfix.1", hotfixSuffix: "fix", want: "1.2.3~hotfix1"},
+		{upstream: "1.2.3-fix.1", hotfixSuffix: "fix", want: "1.2.3+fix1"},
+		{upstream: "2025.10.02", revision: "1", want: "2025.10.02-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.upstream, func(t *testing.T) {
+			suffix := tt.hotfixSuffix
+			if suffix == "" {
+				suffix = "hotfix"
+			}
+			if got := PackageVersion(tt.upstream, tt.revision, suffix); got != tt.want {
+				t.Errorf("PackageVersion(%q, %q, %q) = %q, want %q", tt.upstream, tt.revision, suffix, got, tt.want)
+			}
+		})
+	}
+}
+
+func testPackageChangelog() (*Changelog, PackageInfo) {
+	date := time.Date(2025, 3, 4, 15, 4, 5, 0, time.FixedZone("", 3600))
+	cl := newChangelog("v1.1.0", "v1.2.0-rc.1", []Commit{
+		{Subject: "fix(db): close rows (#12)", Type: TypeFix, Scope: "db", PRNumber: "12", CommitDate: date},
+		{Subject: "feat!: new config format", Type: TypeFeat, Breaking: true, CommitDate: date},
+		{Subject: "feat(api): add pagination", Type: TypeFeat, Scope: "api", PRNumber: "9", CommitDate: date},
+	})
+	return cl, PackageInfo{
+		Name:         "forge",
+		Version:      "1.2.0-rc.1",
+		Revision:     "1",
+		Maintainer:   "Jane Doe <jane@example.com>",
+		Distribution: "unstable",
+		Urgency:      "medium",
+	}
+}
+
+func TestFormatDebian(t *testing.T) {
+	cl, pkg := testPackageChangelog()
+
+	want := `forge (1.2.0~rc1-1) unstable; urgency=medium
+
+  * BREAKING: feat: new config format
+  * feat(api): add pagination (#9)
+  * fix(db): close rows (#12)
+
+ -- Jane Doe <jane@example.com>  Tue, 04 Mar 2025 15:04:05 +0100
+`
+	if got := FormatDebian(cl, pkg); got != want {
+		t.Errorf("FormatDebian() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatRPM(t *testing.T) {
+	cl, pkg := testPackageChangelog()
+
+	want := `* Tue Mar 04 2025 Jane Doe <jane@example.com> - 1.2.0~rc1-1
+- BREAKING: feat: new config format
+- feat(api): add pagination (#9)
+- fix(db): close rows (#12)
+`
+	if got := FormatRPM(cl, pkg); got != want {
+		t.Errorf("FormatRPM() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -2,13 +2,13 @@ package changelog
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"regexp"
 	"slices"
 	"strings"
 	"time"
 
-	"github.com/alexjoedt/forge/internal/run"
+	"github.com/alexjoedt/forge/internal/git"
 )
 
 // CommitType represents the type of commit (feat, fix, etc.).
@@ -25,21 +25,40 @@ const (
 	TypeBuild    CommitType = "build"
 	TypeCI       CommitType = "ci"
 	TypeChore    CommitType = "chore"
+	TypeRevert   CommitType = "revert"
 	TypeOther    CommitType = "other"
 )
 
 // Commit represents a parsed git commit.
 type Commit struct {
-	Hash      string
-	ShortHash string
-	Subject   string
-	Body      string
-	Author    string
-	Date      time.Time
-	Type      CommitType
-	Scope     string
-	Breaking  bool
-	PRNumber  string
+	Hash       string
+	ShortHash  string
+	Subject    string
+	Body       string
+	Author     string    // author name, normalised through .mailmap
+	Email      string    // author email, normalised through .mailmap
+	Date       time.Time // author date
+	CommitDate time.Time // committer date
+	Type       CommitType
+	Scope      string
+	Breaking   bool
+	PRNumber   string
+	Parents    []string
+	CoAuthors  []Identity // people credited via Co-authored-by trailers
+	// Reverts is the hash of the commit this commit reverts, if any.
+	Reverts string
+	// RevertsRelease is the first release tag containing the reverted commit,
+	// set when the reverted commit lies outside the changelog range.
+	RevertsRelease string
+	// Issues lists the issue tracker references found in the message.
+	Issues []Issue
+	// Grouped holds the commits collapsed into this entry when grouping by PR.
+	Grouped []Commit
+}
+
+// IsMerge reports whether the commit has more than one parent.
+func (c *Commit) IsMerge() bool {
+	return len(c.Parents) > 1
 }
 
 // Changelog represents a collection of commits grouped by type.
@@ -50,6 +69,12 @@ type Changelog struct {
 	ToDate   time.Time
 	Commits  []Commit
 	ByType   map[CommitType][]Commit
+	// Contributors lists the people credited in this release (only collected on request).
+	Contributors []Contributor
+	// Excluded lists the commits dropped by the exclude rules.
+	Excluded []Exclusion
+	// Issues lists the issues referenced by the commits, sorted by ID.
+	Issues []Issue
 }
 
 //nolint:gochecknoglobals // compiled regexes and markers are immutable and reused across parses to avoid recompilation overhead
@@ -58,145 +83,379 @@ var (
 	conventionalRegex = regexp.MustCompile(`^(?P<type>\w+)(?:\((?P<scope>[^)]+)\))?(?P<breaking>!)?: (?P<subject>.+)$`)
 	// prRegex matches a PR number reference like (#123).
 	prRegex = regexp.MustCompile(`\(#(\d+)\)`)
+	// mergePRRegex matches GitHub merge commit subjects: Merge pull request #123 from owner/branch.
+	mergePRRegex = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+	// mergeRequestRegex matches the GitLab merge commit trailer: See merge request group/project!123.
+	mergeRequestRegex = regexp.MustCompile(`See merge request \S*!(\d+)`)
+	// coAuthorRegex matches a Co-authored-by trailer: Co-authored-by: Name <email>.
+	coAuthorRegex = regexp.MustCompile(`(?mi)^co-authored-by:\s*(.*?)\s*<([^>]*)>\s*$`)
 	// breakingMarkers lists keywords that indicate a breaking change in the commit body.
 	breakingMarkers = []string{"BREAKING CHANGE:", "BREAKING-CHANGE:", "BREAKING:"}
 )
 
-const commitFields = 6
+// Options controls which commits the parser collects and how they are grouped.
+type Options struct {
+	// FirstParent follows only the first parent of merge commits. Merge commits
+	// are kept and their PR titles become the changelog entries, so the commits
+	// on merged branches do not show up individually.
+	FirstParent bool
+
+	// GroupByPR collapses all commits that belong to the same pull request into
+	// a single entry. Commits without a PR reference inherit the PR number of
+	// the merge commit that brought them in.
+	GroupByPR bool
+
+	// Contributors collects the people who authored commits in the range and
+	// marks those without earlier commits as first-time contributors.
+	Contributors bool
+
+	// CoAuthors also credits people named in Co-authored-by trailers.
+	CoAuthors bool
+
+	// Bots lists author names or emails that are never credited as contributors.
+	// A "*" matches any sequence of characters.
+	Bots []string
+
+	// Exclude leaves matching commits out of the changelog.
+	Exclude ExcludeRules
+
+	// Issues recognizes issue tracker references such as Jira keys.
+	Issues []IssuePattern
+}
 
 // Parser parses git commits.
 type Parser struct {
 	repoDir   string
 	tagPrefix string
+	opts      Options
+
+	// repo is the git backend; nil opens repoDir for each call (see git.Open)
+	repo git.Repository
+
+	// history caches the contributors before a ref, ranges the start of each parsed
+	// range by its end (see previousContributors)
+	history map[string]map[string]bool
+	ranges  map[string]string
 }
 
 // NewParser creates a new parser.
 func NewParser(repoDir, tagPrefix string) *Parser {
+	return NewParserWithOptions(repoDir, tagPrefix, Options{})
+}
+
+// NewParserWithOptions creates a new parser with the given options. It uses the
+// git.Repository stored in the context of each call, if any.
+func NewParserWithOptions(repoDir, tagPrefix string, opts Options) *Parser {
 	return &Parser{
 		repoDir:   repoDir,
 		tagPrefix: tagPrefix,
+		opts:      opts,
 	}
 }
 
-// Parse parses git log between two commits/tags.
-func (p *Parser) Parse(ctx context.Context, from, to string) (*Changelog, error) {
-	return Parse(ctx, p.repoDir, from, to)
+// NewParserWithRepository creates a new parser on the given git backend.
+func NewParserWithRepository(repo git.Repository, tagPrefix string, opts Options) *Parser {
+	return &Parser{
+		tagPrefix: tagPrefix,
+		opts:      opts,
+		repo:      repo,
+	}
+}
+
+// git returns the git backend of the parser.
+func (p *Parser) git(ctx context.Context) git.Repository {
+	if p.repo != nil {
+		return p.repo
+	}
+	return git.Open(ctx, p.repoDir)
 }
 
 // Parse parses git log between two commits/tags.
 func Parse(ctx context.Context, repoDir, from, to string) (*Changelog, error) {
-	// Build git log command.
-	var logRange string
+	return NewParser(repoDir, "").Parse(ctx, from, to)
+}
+
+// Parse parses git log between two commits/tags and groups the commits by type.
+func (p *Parser) Parse(ctx context.Context, from, to string) (*Changelog, error) {
+	issuePatterns, err := compileIssuePatterns(p.opts.Issues)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []Commit{}
+	for commit, err := range p.Commits(ctx, from, to) {
+		if err != nil {
+			return nil, err
+		}
+		commit.Issues = extractIssues(issuePatterns, commit.Subject, commit.Body)
+		commits = append(commits, commit)
+	}
+
+	// Drop commits reverted within the range before anything else looks at them
+	commits = reconcileReverts(commits)
+	p.resolveRevertedReleases(ctx, commits)
+
+	commits, excluded, err := p.exclude(ctx, from, to, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.opts.GroupByPR {
+		commits = groupByPR(commits)
+	}
+
+	cl := newChangelog(from, to, commits)
+	if cl.ToDate.IsZero() {
+		cl.ToDate = p.refDate(ctx, to)
+	}
+	cl.Excluded = excluded
+	cl.Issues = collectIssues(commits)
+
+	if p.opts.Contributors {
+		contributors, err := p.contributors(ctx, from, to, commits)
+		if err != nil {
+			return nil, err
+		}
+		cl.Contributors = contributors
+	}
+
+	return cl, nil
+}
+
+// refDate returns the commit date of ref, or HEAD if ref is empty, dating changelogs
+// without commits. It falls back to the current time if the ref has no commit.
+func (p *Parser) refDate(ctx context.Context, ref string) time.Time {
+	for entry, err := range p.git(ctx).Log(ctx, git.LogOptions{Range: logRange("", ref)}) {
+		if err != nil {
+			break
+		}
+		return entry.CommitDate
+	}
+	return time.Now()
+}
+
+// Commits returns an iterator over the commits between from and to, newest first.
+// Commits are parsed while git is still producing output, so the full log is never
+// held in memory. Stopping the iteration early terminates the git process.
+//
+// Merge commits are only included in first-parent mode or when grouping by PR,
+// as they are needed to attribute branch commits to their pull request.
+func (p *Parser) Commits(ctx context.Context, from, to string) iter.Seq2[Commit, error] {
+	return func(yield func(Commit, error) bool) {
+		opts := git.LogOptions{
+			Range:       logRange(from, to),
+			FirstParent: p.opts.FirstParent,
+			NoMerges:    !p.opts.FirstParent && !p.opts.GroupByPR,
+		}
+		for entry, err := range p.git(ctx).Log(ctx, opts) {
+			if err != nil {
+				yield(Commit{}, err)
+				return
+			}
+			if !yield(newCommit(entry), nil) {
+				return
+			}
+		}
+	}
+}
+
+// logRange builds the git log revision range for the given endpoints.
+func logRange(from, to string) string {
 	switch {
 	case from != "" && to != "":
-		logRange = from + ".." + to
+		return from + ".." + to
 	case from != "":
-		logRange = from + "..HEAD"
+		return from + "..HEAD"
 	case to != "":
-		logRange = to
+		return to
 	default:
-		logRange = "HEAD"
+		return "HEAD"
+	}
+}
+
+// newChangelog assembles a Changelog from parsed commits (newest first).
+func newChangelog(from, to string, commits []Commit) *Changelog {
+	byType := make(map[CommitType][]Commit)
+	for _, commit := range commits {
+		byType[commit.Type] = append(byType[commit.Type], commit)
 	}
 
-	// Format: hash|short|author|date|subject|body
-	format := "%H|%h|%an|%aI|%s|%b"
-	result := run.CmdInDir(ctx, repoDir, "git", "log", logRange, "--no-merges", "--pretty=format:"+format, "--date=iso")
+	cl := &Changelog{
+		FromTag: from,
+		ToTag:   to,
+		Commits: commits,
+		ByType:  byType,
+	}
 
-	if !result.Success() {
-		return nil, fmt.Errorf("git log failed: %s", result.Stderr)
+	if len(commits) > 0 {
+		cl.ToDate = commits[0].CommitDate
+		cl.FromDate = commits[len(commits)-1].CommitDate
 	}
 
-	output := result.Stdout
-	if output == "" {
-		return &Changelog{
-			FromTag: from,
-			ToTag:   to,
-			Commits: []Commit{},
-			ByType:  make(map[CommitType][]Commit),
-		}, nil
+	return cl
+}
+
+// newCommit parses the conventional commit fields of a log entry.
+func newCommit(entry git.LogEntry) Commit {
+	commit := Commit{
+		Hash:       entry.Hash,
+		ShortHash:  entry.ShortHash,
+		Author:     entry.AuthorName,
+		Email:      entry.AuthorEmail,
+		Date:       entry.AuthorDate,
+		CommitDate: entry.CommitDate,
+		Parents:    entry.Parents,
+		Subject:    entry.Subject,
+		Body:       entry.Body,
 	}
 
-	// Parse commits
-	commits := []Commit{}
-	lines := strings.Split(output, "\n")
+	commit.CoAuthors = parseCoAuthors(commit.Body)
+
+	// Use the PR title as subject for merge commits
+	if commit.IsMerge() {
+		parseMergeCommit(&commit)
+	}
 
-	var currentCommit *Commit
-	var bodyLines []string
+	// Parse conventional commit format
+	parseConventionalCommit(&commit)
 
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
+	// Check for breaking changes
+	checkBreakingChange(&commit)
 
-		// Check if this is a new commit line (starts with hash)
-		parts := strings.SplitN(line, "|", commitFields)
-		if len(parts) == commitFields {
-			// Save previous commit if exists
-			if currentCommit != nil {
-				currentCommit.Body = strings.TrimSpace(strings.Join(bodyLines, "\n"))
-				commits = append(commits, *currentCommit)
-				bodyLines = []string{}
-			}
+	// Detect reverts
+	parseRevert(&commit)
 
-			// Parse new commit
-			hash := parts[0]
-			shortHash := parts[1]
-			author := parts[2]
-			dateStr := parts[3]
-			subject := parts[4]
-			body := parts[5]
-
-			date, _ := time.Parse(time.RFC3339, dateStr)
-
-			commit := &Commit{
-				Hash:      hash,
-				ShortHash: shortHash,
-				Author:    author,
-				Date:      date,
-				Subject:   subject,
-				Body:      body,
-			}
+	// Extract PR number
+	extractPRNumber(&commit)
+
+	return commit
+}
 
-			// Parse conventional commit format
-			parseConventionalCommit(commit)
+// parseMergeCommit extracts the PR number and title from GitHub and GitLab merge commits.
+// The PR title (the first body line) replaces the generic "Merge ..." subject.
+func parseMergeCommit(commit *Commit) {
+	var prNumber string
+	if matches := mergePRRegex.FindStringSubmatch(commit.Subject); matches != nil {
+		prNumber = matches[1]
+	} else if matches = mergeRequestRegex.FindStringSubmatch(commit.Body); matches != nil {
+		prNumber = matches[1]
+	}
 
-			// Check for breaking changes
-			checkBreakingChange(commit)
+	if prNumber == "" {
+		return
+	}
 
-			// Extract PR number
-			extractPRNumber(commit)
+	commit.PRNumber = prNumber
+	title, rest, _ := strings.Cut(commit.Body, "\n")
+	if title = strings.TrimSpace(title); title != "" && !mergeRequestRegex.MatchString(title) {
+		commit.Subject = title
+		commit.Body = strings.TrimSpace(rest)
+	}
+}
 
-			currentCommit = commit
+// groupByPR collapses commits (newest first) that belong to the same pull request.
+//
+// Branch commits are attributed to the merge commit that introduced them by walking
+// from each merge's second parent until the first-parent history is reached. Each
+// PR then becomes a single entry: the merge commit if it carries a PR title, the
+// newest commit of the PR otherwise. Merge commits without a PR reference are dropped.
+func groupByPR(commits []Commit) []Commit {
+	byHash := make(map[string]int, len(commits))
+	for i := range commits {
+		byHash[commits[i].Hash] = i
+	}
 
-			if body != "" {
-				bodyLines = append(bodyLines, body)
+	// Mark the first-parent chain starting at the newest commit.
+	mainline := make(map[string]bool)
+	if len(commits) > 0 {
+		i := 0
+		for {
+			mainline[commits[i].Hash] = true
+			if len(commits[i].Parents) == 0 {
+				break
 			}
-		} else {
-			// This is a body continuation line
-			if currentCommit != nil {
-				bodyLines = append(bodyLines, line)
+			next, ok := byHash[commits[i].Parents[0]]
+			if !ok {
+				break
 			}
+			i = next
 		}
 	}
 
-	// Save last commit
-	if currentCommit != nil {
-		currentCommit.Body = strings.TrimSpace(strings.Join(bodyLines, "\n"))
-		commits = append(commits, *currentCommit)
+	// Attribute branch commits to merges, oldest merge first so that each commit
+	// belongs to the merge that introduced it.
+	visited := make(map[string]bool)
+	for i := len(commits) - 1; i >= 0; i-- {
+		merge := commits[i]
+		if !mainline[merge.Hash] || !merge.IsMerge() || merge.PRNumber == "" {
+			continue
+		}
+		queue := slices.Clone(merge.Parents[1:])
+		for len(queue) > 0 {
+			hash := queue[0]
+			queue = queue[1:]
+			j, ok := byHash[hash]
+			if !ok || mainline[hash] || visited[hash] {
+				continue
+			}
+			visited[hash] = true
+			if commits[j].PRNumber == "" {
+				commits[j].PRNumber = merge.PRNumber
+			}
+			queue = append(queue, commits[j].Parents...)
+		}
 	}
 
-	// Group by type
-	byType := make(map[CommitType][]Commit)
+	entries := []Commit{}
+	index := make(map[string]int)
 	for _, commit := range commits {
-		byType[commit.Type] = append(byType[commit.Type], commit)
+		if commit.PRNumber == "" {
+			if !commit.IsMerge() {
+				entries = append(entries, commit)
+			}
+			continue
+		}
+
+		i, seen := index[commit.PRNumber]
+		if !seen {
+			index[commit.PRNumber] = len(entries)
+			entries = append(entries, commit)
+			continue
+		}
+
+		entry := &entries[i]
+		if commit.IsMerge() && !entry.IsMerge() {
+			// Prefer the merge commit (PR title) as the entry.
+			commit.Grouped = append([]Commit{*entry}, entry.Grouped...)
+			entry.Grouped = nil
+			*entry = commit
+			continue
+		}
+		entry.Grouped = append(entry.Grouped, commit)
 	}
 
-	return &Changelog{
-		FromTag: from,
-		ToTag:   to,
-		Commits: commits,
-		ByType:  byType,
-	}, nil
+	for i := range entries {
+		mergeGroupedInfo(&entries[i])
+	}
+
+	return entries
+}
+
+// mergeGroupedInfo folds the type, breaking flag and issues of grouped commits into the entry.
+// If the entry itself is not a Conventional Commit (e.g. a PR title without a type),
+// it takes the highest priority type among its grouped commits.
+func mergeGroupedInfo(entry *Commit) {
+	inferType := entry.Type == TypeOther
+	for _, c := range entry.Grouped {
+		if c.Breaking {
+			entry.Breaking = true
+		}
+		entry.Issues = addIssues(entry.Issues, c.Issues...)
+		if inferType && GetTypePriority(c.Type) < GetTypePriority(entry.Type) {
+			entry.Type = c.Type
+			entry.Scope = c.Scope
+		}
+	}
 }
 
 // parseConventionalCommit parses the subject line for Conventional Commits format.
@@ -222,7 +481,7 @@ func parseConventionalCommit(commit *Commit) {
 	// Validate type
 	validTypes := []CommitType{
 		TypeFeat, TypeFix, TypeDocs, TypeStyle, TypeRefactor,
-		TypePerf, TypeTest, TypeBuild, TypeCI, TypeChore,
+		TypePerf, TypeTest, TypeBuild, TypeCI, TypeChore, TypeRevert,
 	}
 
 	if !slices.Contains(validTypes, commit.Type) {
@@ -288,6 +547,8 @@ func GetTypeTitle(t CommitType) string {
 		return "Continuous Integration"
 	case TypeChore:
 		return "Chores"
+	case TypeRevert:
+		return "Reverts"
 	case TypeOther:
 		return "Other Changes"
 	}
@@ -319,6 +580,8 @@ func GetTypePriority(t CommitType) int {
 		return 9
 	case TypeChore:
 		return 10
+	case TypeRevert:
+		return 11
 	case TypeOther:
 		return 99
 	}
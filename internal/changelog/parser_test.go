@@ -0,0 +1,547 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/git/gittest"
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+func TestNewCommit(t *testing.T) {
+	entry := func(parents, subject, body string) git.LogEntry {
+		return git.LogEntry{
+			Hash:        "abc123",
+			ShortHash:   "abc",
+			AuthorName:  "Jane",
+			AuthorEmail: "jane@example.com",
+			AuthorDate:  time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+			CommitDate:  time.Date(2025, 1, 3, 11, 0, 0, 0, time.UTC),
+			Parents:     strings.Fields(parents),
+			Subject:     subject,
+			Body:        body,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		entry       git.LogEntry
+		wantSubject string
+		wantBody    string
+		wantType    CommitType
+		wantScope   string
+		wantPR      string
+	}{
+		{
+			name:        "conventional commit",
+			entry:       entry("p1", "feat(api): add pagination (#42)", "details"),
+			wantSubject: "feat(api): add pagination (#42)",
+			wantBody:    "details",
+			wantType:    TypeFeat,
+			wantScope:   "api",
+			wantPR:      "42",
+		},
+		{
+			name:        "pipe in subject",
+			entry:       entry("p1", "fix: handle a | b", ""),
+			wantSubject: "fix: handle a | b",
+			wantType:    TypeFix,
+		},
+		{
+			name:        "not conventional",
+			entry:       entry("p1", "update readme", ""),
+			wantSubject: "update readme",
+			wantType:    TypeOther,
+		},
+		{
+			name:        "github merge commit uses PR title",
+			entry:       entry("p1 p2", "Merge pull request #17 from jane/feature", "feat(ui): dark mode"),
+			wantSubject: "feat(ui): dark mode",
+			wantType:    TypeFeat,
+			wantScope:   "ui",
+			wantPR:      "17",
+		},
+		{
+			name: "gitlab merge commit uses MR title",
+			entry: entry("p1 p2", "Merge branch 'fix-login' into 'main'",
+				"fix: login loop\n\nSee merge request group/app!88"),
+			wantSubject: "fix: login loop",
+			wantBody:    "See merge request group/app!88",
+			wantType:    TypeFix,
+			wantPR:      "88",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newCommit(tt.entry)
+			if got.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.wantSubject)
+			}
+			if got.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", got.Body, tt.wantBody)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", got.Type, tt.wantType)
+			}
+			if got.Scope != tt.wantScope {
+				t.Errorf("Scope = %q, want %q", got.Scope, tt.wantScope)
+			}
+			if got.PRNumber != tt.wantPR {
+				t.Errorf("PRNumber = %q, want %q", got.PRNumber, tt.wantPR)
+			}
+			if got.Author != "Jane" || !got.CommitDate.Equal(tt.entry.CommitDate) || got.Date.IsZero() {
+				t.Errorf("identity and dates not copied: %+v", got)
+			}
+		})
+	}
+}
+
+// initTestRepo creates a temporary git repository with the given commit messages,
+// committed in order (so the last message ends up at HEAD).
+func initTestRepo(t *testing.T, messages ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	ctx := context.Background()
+	cmds := [][]string{
+		{"git", "init"},
+		{"git", "config", "user.email", "test@example.com"},
+		{"git", "config", "user.name", "Test User"},
+	}
+	for _, msg := range messages {
+		cmds = append(cmds, []string{"git", "commit", "--allow-empty", "-m", msg})
+	}
+	for _, args := range cmds {
+		r := run.CmdInDir(ctx, dir, args[0], args[1:]...)
+		if !r.Success() {
+			t.Fatalf("repo setup %v failed: %s", args, r.Stderr)
+		}
+	}
+	return dir
+}
+
+func TestParse(t *testing.T) {
+	dir := initTestRepo(t,
+		"feat(api): support a | b filters (#7)\n\nbody|with|five|pipes|in|it",
+		"fix: plain fix",
+		"docs: update readme\n\nBREAKING CHANGE: none really",
+	)
+
+	cl, err := Parse(t.Context(), dir, "", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(cl.Commits) != 3 {
+		t.Fatalf("got %d commits, want 3", len(cl.Commits))
+	}
+
+	if got := cl.Commits[2].Subject; got != "feat(api): support a | b filters (#7)" {
+		t.Errorf("oldest subject = %q", got)
+	}
+	if got := cl.Commits[2].Body; got != "body|with|five|pipes|in|it" {
+		t.Errorf("oldest body = %q", got)
+	}
+	if got := cl.Commits[2].PRNumber; got != "7" {
+		t.Errorf("PRNumber = %q, want 7", got)
+	}
+	if !cl.Commits[0].Breaking {
+		t.Errorf("expected newest commit to be breaking")
+	}
+	if len(cl.ByType[TypeFix]) != 1 {
+		t.Errorf("got %d fix commits, want 1", len(cl.ByType[TypeFix]))
+	}
+	for _, c := range cl.Commits {
+		if c.Date.IsZero() || c.CommitDate.IsZero() {
+			t.Errorf("commit %s has zero date", c.ShortHash)
+		}
+	}
+	if cl.ToDate.IsZero() || cl.FromDate.IsZero() {
+		t.Errorf("changelog date range not set")
+	}
+}
+
+func TestParseContributors(t *testing.T) {
+	dir := initTestRepo(t, "chore: initial commit")
+	ctx := t.Context()
+
+	if err := os.WriteFile(filepath.Join(dir, ".mailmap"),
+		[]byte("Jane Doe <jane@example.com> <jdoe@old.example>\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"tag", "v1.0.0"},
+		{"commit", "--allow-empty", "--author", "jdoe <jdoe@old.example>",
+			"-m", "feat: new thing\n\nCo-authored-by: Bob <bob@example.com>"},
+		{"commit", "--allow-empty", "--author", "dependabot[bot] <bot@users.noreply.github.com>",
+			"-m", "chore(deps): bump x"},
+		{"commit", "--allow-empty", "-m", "fix: returning contributor"},
+	} {
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+
+	parser := NewParserWithOptions(dir, "v", Options{
+		Contributors: true,
+		CoAuthors:    true,
+		Bots:         []string{"*[bot]"},
+	})
+	cl, err := parser.Parse(ctx, "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Contributor{
+		{Identity: Identity{Name: "Bob", Email: "bob@example.com"}, Commits: 1, FirstTime: true},
+		{Identity: Identity{Name: "Jane Doe", Email: "jane@example.com"}, Commits: 1, FirstTime: true},
+		{Identity: Identity{Name: "Test User", Email: "test@example.com"}, Commits: 1},
+	}
+	if !slices.Equal(cl.Contributors, want) {
+		t.Errorf("Contributors = %+v, want %+v", cl.Contributors, want)
+	}
+
+	md := FormatMarkdown(cl)
+	if !strings.Contains(md, "* Jane Doe 🎉 *first contribution*") || strings.Contains(md, "dependabot") {
+		t.Errorf("unexpected contributors section:\n%s", md)
+	}
+}
+
+func TestParseContributorsMailmappedCoAuthor(t *testing.T) {
+	dir := initTestRepo(t, "chore: initial commit")
+	ctx := t.Context()
+
+	if err := os.WriteFile(filepath.Join(dir, ".mailmap"),
+		[]byte("Carol <carol@example.com> <carol@old.example>\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Carol co-authored before v1.0.0 under an old email, and again under the new one
+	for _, args := range [][]string{
+		{"commit", "--allow-empty", "-m", "feat: first\n\nCo-authored-by: C. <carol@old.example>"},
+		{"tag", "v1.0.0"},
+		{"commit", "--allow-empty", "-m", "fix: second\n\nCo-authored-by: Carol <carol@example.com>"},
+	} {
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+
+	cl, err := NewParserWithOptions(dir, "v", Options{Contributors: true, CoAuthors: true}).
+		Parse(ctx, "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for _, c := range cl.Contributors {
+		if c.FirstTime {
+			t.Errorf("%s reported as a first-time contributor", c)
+		}
+	}
+}
+
+// mailmapRecorder records the identities passed to CheckMailmap.
+type mailmapRecorder struct {
+	git.Repository
+	calls [][]string
+}
+
+func (r *mailmapRecorder) CheckMailmap(ctx context.Context, identities []string) ([]string, error) {
+	r.calls = append(r.calls, identities)
+	return r.Repository.CheckMailmap(ctx, identities)
+}
+
+func TestParseContributorsMailmapOnce(t *testing.T) {
+	repo := gittest.New()
+	repo.AddCommit("feat: first\n\nCo-authored-by: Bob <bob@example.com>")
+	if err := repo.AddTag("v1.0.0", "HEAD", "Release v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		repo.AddCommit("fix: again\n\nCo-authored-by: Bob <bob@example.com>\nCo-authored-by: Eve <eve@example.com>")
+	}
+
+	recorder := &mailmapRecorder{Repository: repo}
+	cl, err := NewParserWithRepository(recorder, "v", Options{Contributors: true, CoAuthors: true}).
+		Parse(t.Context(), "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for _, identities := range recorder.calls {
+		if len(identities) != len(slices.Compact(slices.Sorted(slices.Values(identities)))) {
+			t.Errorf("CheckMailmap() called with duplicates: %v", identities)
+		}
+	}
+	for _, c := range cl.Contributors {
+		if c.Email == "bob@example.com" && (c.Commits != 3 || c.FirstTime) {
+			t.Errorf("Bob = %+v, want 3 commits and not first-time", c)
+		}
+		if c.Email == "eve@example.com" && (c.Commits != 3 || !c.FirstTime) {
+			t.Errorf("Eve = %+v, want 3 commits and first-time", c)
+		}
+	}
+}
+
+// TestParseReleasesSharedHistory parses consecutive releases oldest first with one
+// parser, the way release feeds do, and expects the same first-time contributors as a
+// fresh parser per release.
+func TestParseReleasesSharedHistory(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	for _, args := range [][]string{
+		{"commit", "--allow-empty", "--author", "Alice <alice@example.com>", "-m", "feat: one"},
+		{"tag", "v1.0.0"},
+		{"commit", "--allow-empty", "--author", "Bob <bob@example.com>", "-m", "fix: two"},
+		{"tag", "v1.1.0"},
+		{"commit", "--allow-empty", "--author", "Alice <alice@example.com>", "-m", "feat: three"},
+		{"commit", "--allow-empty", "--author", "Carol <carol@example.com>", "-m", "fix: four"},
+		{"tag", "v1.2.0"},
+	} {
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+
+	firstTimers := func(cl *Changelog) []string {
+		var names []string
+		for _, c := range cl.Contributors {
+			if c.FirstTime {
+				names = append(names, c.Name)
+			}
+		}
+		return names
+	}
+
+	opts := Options{Contributors: true}
+	shared := NewParserWithOptions(dir, "v", opts)
+	for _, tt := range []struct {
+		from, to string
+		want     []string
+	}{
+		{to: "v1.0.0", want: []string{"Alice"}},
+		{from: "v1.0.0", to: "v1.1.0", want: []string{"Bob"}},
+		{from: "v1.1.0", to: "v1.2.0", want: []string{"Carol"}},
+	} {
+		cl, err := shared.Parse(ctx, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("Parse(%q, %q) error = %v", tt.from, tt.to, err)
+		}
+		fresh, err := NewParserWithOptions(dir, "v", opts).Parse(ctx, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("Parse(%q, %q) error = %v", tt.from, tt.to, err)
+		}
+		if got := firstTimers(cl); !slices.Equal(got, tt.want) || !slices.Equal(got, firstTimers(fresh)) {
+			t.Errorf("%s first-time contributors = %v, fresh parser %v, want %v",
+				tt.to, got, firstTimers(fresh), tt.want)
+		}
+	}
+}
+
+func TestParserCommitsStopEarly(t *testing.T) {
+	dir := initTestRepo(t, "feat: one", "feat: two", "feat: three")
+
+	var seen []string
+	for commit, err := range NewParser(dir, "").Commits(t.Context(), "", "HEAD") {
+		if err != nil {
+			t.Fatalf("Commits() error = %v", err)
+		}
+		seen = append(seen, commit.Subject)
+		if len(seen) == 2 {
+			break
+		}
+	}
+
+	if len(seen) != 2 || seen[0] != "feat: three" || seen[1] != "feat: two" {
+		t.Errorf("unexpected commits: %v", seen)
+	}
+}
+
+func TestParseEmptyRangeDate(t *testing.T) {
+	dir := initTestRepo(t, "feat: one")
+	if r := run.CmdInDir(t.Context(), dir, "git", "tag", "v1.0.0"); !r.Success() {
+		t.Fatalf("git tag: %s", r.Stderr)
+	}
+
+	cl, err := Parse(t.Context(), dir, "v1.0.0", "v1.0.0")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(cl.Commits) != 0 {
+		t.Fatalf("got %d commits, want none", len(cl.Commits))
+	}
+	if cl.ToDate.IsZero() || time.Since(cl.ToDate) > time.Hour {
+		t.Errorf("ToDate = %v, want the date of v1.0.0", cl.ToDate)
+	}
+}
+
+func TestGroupByPR(t *testing.T) {
+	// History (newest first):
+	//   m2  merge of PR #2 (title "feat: search"), parents main1, b2
+	//   b2  wip on branch, parent b1
+	//   b1  wip on branch, parent main1
+	//   main1 fix: direct push (#9), parent m1
+	//   m1  merge of PR #1 (title "Add login"), parents root, a1
+	//   a1  feat(auth)!: login, parent root
+	//   s1  squash commit "feat: export (#3)", parent root
+	//   s2  follow-up "fix: export typo (#3)", parent s1
+	//   root
+	commits := []Commit{
+		{Hash: "m2", Parents: []string{"main1", "b2"}, Subject: "feat: search", Type: TypeFeat, PRNumber: "2"},
+		{Hash: "b2", Parents: []string{"b1"}, Subject: "wip", Type: TypeOther},
+		{Hash: "b1", Parents: []string{"main1"}, Subject: "fix: typo", Type: TypeFix},
+		{Hash: "main1", Parents: []string{"m1"}, Subject: "fix: direct push (#9)", Type: TypeFix, PRNumber: "9"},
+		{Hash: "m1", Parents: []string{"s2", "a1"}, Subject: "Add login", Type: TypeOther, PRNumber: "1"},
+		{Hash: "a1", Parents: []string{"s2"}, Subject: "feat(auth)!: login", Type: TypeFeat, Scope: "auth", Breaking: true},
+		{Hash: "s2", Parents: []string{"s1"}, Subject: "fix: export typo (#3)", Type: TypeFix, PRNumber: "3"},
+		{Hash: "s1", Parents: []string{"root"}, Subject: "feat: export (#3)", Type: TypeFeat, PRNumber: "3"},
+		{Hash: "root", Subject: "chore: init", Type: TypeChore},
+	}
+
+	got := groupByPR(commits)
+
+	want := []struct {
+		hash     string
+		grouped  int
+		typ      CommitType
+		breaking bool
+	}{
+		{hash: "m2", grouped: 2, typ: TypeFeat},
+		{hash: "main1", grouped: 0, typ: TypeFix},
+		{hash: "m1", grouped: 1, typ: TypeFeat, breaking: true},
+		{hash: "s2", grouped: 1, typ: TypeFix},
+		{hash: "root", grouped: 0, typ: TypeChore},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Hash != w.hash {
+			t.Errorf("entry %d: hash = %q, want %q", i, got[i].Hash, w.hash)
+		}
+		if len(got[i].Grouped) != w.grouped {
+			t.Errorf("entry %d: grouped = %d, want %d", i, len(got[i].Grouped), w.grouped)
+		}
+		if got[i].Type != w.typ {
+			t.Errorf("entry %d: type = %q, want %q", i, got[i].Type, w.typ)
+		}
+		if got[i].Breaking != w.breaking {
+			t.Errorf("entry %d: breaking = %v, want %v", i, got[i].Breaking, w.breaking)
+		}
+	}
+}
+
+func TestParseFirstParent(t *testing.T) {
+	dir := initTestRepo(t, "chore: init")
+	ctx := t.Context()
+	steps := [][]string{
+		{"git", "checkout", "-q", "-b", "feature"},
+		{"git", "commit", "--allow-empty", "-m", "wip one"},
+		{"git", "commit", "--allow-empty", "-m", "wip two"},
+		{"git", "checkout", "-q", "-"},
+		{"git", "merge", "--no-ff", "feature", "-m", "Merge pull request #5 from jane/feature", "-m", "feat: new widget"},
+		{"git", "commit", "--allow-empty", "-m", "fix: direct fix"},
+	}
+	for _, args := range steps {
+		if r := run.CmdInDir(ctx, dir, args[0], args[1:]...); !r.Success() {
+			t.Fatalf("%v failed: %s", args, r.Stderr)
+		}
+	}
+
+	cl, err := NewParserWithOptions(dir, "", Options{FirstParent: true}).Parse(ctx, "", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var subjects []string
+	for _, c := range cl.Commits {
+		subjects = append(subjects, c.Subject)
+	}
+	want := []string{"fix: direct fix", "feat: new widget", "chore: init"}
+	if !slices.Equal(subjects, want) {
+		t.Fatalf("subjects = %q, want %q", subjects, want)
+	}
+	if cl.Commits[1].PRNumber != "5" || cl.Commits[1].Type != TypeFeat {
+		t.Errorf("merge entry = %+v", cl.Commits[1])
+	}
+
+	grouped, err := NewParserWithOptions(dir, "", Options{GroupByPR: true}).Parse(ctx, "", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(grouped.Commits) != 3 || len(grouped.Commits[1].Grouped) != 2 {
+		t.Errorf("expected PR #5 to collapse two branch commits, got %+v", grouped.Commits)
+	}
+}
+
+func TestParseInvalidRange(t *testing.T) {
+	dir := initTestRepo(t, "feat: one")
+
+	if _, err := Parse(t.Context(), dir, "does-not-exist", "HEAD"); err == nil {
+		t.Fatal("expected error for unknown revision")
+	}
+}
+
+// benchmarkCommits is the size of the synthetic repository used by BenchmarkParse.
+const benchmarkCommits = 100_000
+
+// newSyntheticRepo builds a repository with n commits using git fast-import,
+// which is orders of magnitude faster than n invocations of git commit.
+func newSyntheticRepo(b *testing.B, n int) string {
+	b.Helper()
+	dir := b.TempDir()
+	ctx := context.Background()
+
+	if r := run.CmdInDir(ctx, dir, "git", "init"); !r.Success() {
+		b.Fatalf("git init failed: %s", r.Stderr)
+	}
+
+	types := []string{"feat", "fix", "docs", "refactor", "chore", "perf"}
+	var sb strings.Builder
+	for i := range n {
+		msg := fmt.Sprintf("%s(scope%d): change number %d | with pipe (#%d)\n\nBody line for %d.\n",
+			types[i%len(types)], i%10, i, i, i)
+		fmt.Fprintf(&sb, "commit refs/heads/main\n")
+		fmt.Fprintf(&sb, "committer Bench <bench@example.com> %d +0000\n", 1700000000+i)
+		fmt.Fprintf(&sb, "data %d\n%s\n", len(msg), msg)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "fast-import", "--quiet")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(sb.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		b.Fatalf("git fast-import failed: %v\n%s", err, out)
+	}
+	if r := run.CmdInDir(ctx, dir, "git", "symbolic-ref", "HEAD", "refs/heads/main"); !r.Success() {
+		b.Fatalf("git symbolic-ref failed: %s", r.Stderr)
+	}
+
+	return dir
+}
+
+func BenchmarkParse(b *testing.B) {
+	dir := newSyntheticRepo(b, benchmarkCommits)
+	parser := NewParser(dir, "")
+
+	for b.Loop() {
+		count := 0
+		for _, err := range parser.Commits(b.Context(), "", "HEAD") {
+			if err != nil {
+				b.Fatal(err)
+			}
+			count++
+		}
+		if count != benchmarkCommits {
+			b.Fatalf("parsed %d commits, want %d", count, benchmarkCommits)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package changelog
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+//nolint:gochecknoglobals // compiled regexes are immutable and reused across parses
+var (
+	// revertSubjectRegex matches the subject git revert generates: Revert "original subject".
+	revertSubjectRegex = regexp.MustCompile(`^Revert ".+"$`)
+	// revertBodyRegex matches the body line git revert generates: This reverts commit <hash>.
+	revertBodyRegex = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+)
+
+// parseRevert records which commit a commit reverts. Commits are only typed as
+// reverts when the subject says so (git's Revert "..." or a conventional revert:),
+// so a fix: that happens to undo an earlier commit stays a fix.
+func parseRevert(commit *Commit) {
+	if matches := revertBodyRegex.FindStringSubmatch(commit.Body); matches != nil {
+		commit.Reverts = matches[1]
+	}
+	if revertSubjectRegex.MatchString(commit.Subject) {
+		commit.Type = TypeRevert
+		commit.Scope = ""
+		commit.Breaking = false
+	}
+}
+
+// reconcileReverts removes commits (newest first) that were reverted within the
+// range together with their reverts. Walking newest first means a revert of a
+// revert cancels the first revert, leaving the original change in place.
+func reconcileReverts(commits []Commit) []Commit {
+	byHash := make(map[string]int, len(commits))
+	for i := range commits {
+		byHash[commits[i].Hash] = i
+	}
+
+	dropped := make(map[int]bool)
+	for i := range commits {
+		if dropped[i] || commits[i].Reverts == "" {
+			continue
+		}
+		j, ok := findCommit(commits, byHash, commits[i].Reverts)
+		if !ok || j <= i || dropped[j] {
+			continue
+		}
+		dropped[i] = true
+		dropped[j] = true
+	}
+
+	if len(dropped) == 0 {
+		return commits
+	}
+
+	kept := make([]Commit, 0, len(commits)-len(dropped))
+	for i := range commits {
+		if !dropped[i] {
+			kept = append(kept, commits[i])
+		}
+	}
+	return kept
+}
+
+// findCommit looks up a commit by full or abbreviated hash.
+func findCommit(commits []Commit, byHash map[string]int, hash string) (int, bool) {
+	if i, ok := byHash[hash]; ok {
+		return i, true
+	}
+	for i := range commits {
+		if strings.HasPrefix(commits[i].Hash, hash) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// resolveRevertedReleases sets RevertsRelease on reverts whose original commit
+// lies outside the range, so the changelog can point at the release that shipped it.
+// Lookup failures are not fatal; the revert is then listed without a release.
+func (p *Parser) resolveRevertedReleases(ctx context.Context, commits []Commit) {
+	for i := range commits {
+		if commits[i].Type != TypeRevert || commits[i].Reverts == "" {
+			continue
+		}
+		tags, err := p.git(ctx).ContainingTags(ctx, commits[i].Reverts)
+		if err != nil {
+			continue
+		}
+		if tag := p.firstRelease(tags); tag != "" {
+			commits[i].RevertsRelease = tag
+		}
+	}
+}
+
+// firstRelease returns the oldest of the tags with the parser's prefix by version
+// precedence. Tags that are not versions sort after the versions, by name.
+func (p *Parser) firstRelease(tags []string) string {
+	type release struct {
+		tag     string
+		version *version.Version
+	}
+
+	var releases []release
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, p.tagPrefix) {
+			continue
+		}
+		s := version.StripPrefix(tag, p.tagPrefix)
+		v, err := version.ParseSemVer(s)
+		if err != nil {
+			v, _ = version.ParseCalVer(s)
+		}
+		releases = append(releases, release{tag: tag, version: v})
+	}
+	if len(releases) == 0 {
+		return ""
+	}
+
+	return slices.MinFunc(releases, func(a, b release) int {
+		switch {
+		case a.version == nil && b.version == nil:
+			return strings.Compare(a.tag, b.tag)
+		case a.version == nil:
+			return 1
+		case b.version == nil:
+			return -1
+		}
+		return cmp.Or(version.Compare(a.version, b.version), strings.Compare(a.tag, b.tag))
+	}).tag
+}
@@ -0,0 +1,183 @@
+package changelog
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/run"
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+func TestParseRevert(t *testing.T) {
+	tests := []struct {
+		name        string
+		subject     string
+		body        string
+		wantType    CommitType
+		wantReverts string
+	}{
+		{
+			name:        "git revert",
+			subject:     `Revert "feat(api): add pagination"`,
+			body:        "This reverts commit 0123456789abcdef0123456789abcdef01234567.",
+			wantType:    TypeRevert,
+			wantReverts: "0123456789abcdef0123456789abcdef01234567",
+		},
+		{
+			name:        "conventional revert",
+			subject:     "revert: add pagination",
+			body:        "This reverts commit abc1234.",
+			wantType:    TypeRevert,
+			wantReverts: "abc1234",
+		},
+		{
+			name:        "fix that reverts keeps its type",
+			subject:     "fix: undo broken cache",
+			body:        "This reverts commit abc1234.",
+			wantType:    TypeFix,
+			wantReverts: "abc1234",
+		},
+		{
+			name:     "not a revert",
+			subject:  "feat: revert button",
+			wantType: TypeFeat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Commit{Subject: tt.subject, Body: tt.body}
+			parseConventionalCommit(&c)
+			parseRevert(&c)
+			if c.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", c.Type, tt.wantType)
+			}
+			if c.Reverts != tt.wantReverts {
+				t.Errorf("Reverts = %q, want %q", c.Reverts, tt.wantReverts)
+			}
+		})
+	}
+}
+
+func TestReconcileReverts(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []Commit // newest first
+		want    []string
+	}{
+		{
+			name: "pair in range is dropped",
+			commits: []Commit{
+				{Hash: "c3", Type: TypeRevert, Reverts: "c1"},
+				{Hash: "c2", Type: TypeFix},
+				{Hash: "c1", Type: TypeFeat},
+			},
+			want: []string{"c2"},
+		},
+		{
+			name: "abbreviated hash",
+			commits: []Commit{
+				{Hash: "bbbbbbbbbb", Type: TypeRevert, Reverts: "aaaaaaa"},
+				{Hash: "aaaaaaaaaa", Type: TypeFeat},
+			},
+			want: []string{},
+		},
+		{
+			name: "original outside range is kept",
+			commits: []Commit{
+				{Hash: "c2", Type: TypeRevert, Reverts: "c0"},
+				{Hash: "c1", Type: TypeFeat},
+			},
+			want: []string{"c2", "c1"},
+		},
+		{
+			name: "revert of revert restores original",
+			commits: []Commit{
+				{Hash: "c3", Type: TypeRevert, Reverts: "c2"},
+				{Hash: "c2", Type: TypeRevert, Reverts: "c1"},
+				{Hash: "c1", Type: TypeFeat},
+			},
+			want: []string{"c1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, c := range reconcileReverts(tt.commits) {
+				got = append(got, c.Hash)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("reconcileReverts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReverts(t *testing.T) {
+	dir := initTestRepo(t, "feat: shipped feature")
+	ctx := t.Context()
+
+	git := func(args ...string) string {
+		t.Helper()
+		r := run.CmdInDir(ctx, dir, "git", args...)
+		if !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+		return r.Stdout
+	}
+
+	// git revert refuses empty commits, so write the messages it would generate.
+	revert := func(subject, hash string) {
+		git("commit", "--allow-empty", "-m", `Revert "`+subject+`"`, "-m", "This reverts commit "+hash+".")
+	}
+
+	shipped := strings.TrimSpace(git("rev-parse", "HEAD"))
+	git("tag", "v1.0.0")
+	git("commit", "--allow-empty", "-m", "feat: short-lived feature")
+	revert("feat: short-lived feature", strings.TrimSpace(git("rev-parse", "HEAD")))
+	git("commit", "--allow-empty", "-m", "fix: keep me")
+	revert("feat: shipped feature", shipped)
+
+	cl, err := NewParser(dir, "v").Parse(ctx, "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(cl.Commits) != 2 {
+		t.Fatalf("got %d commits, want 2: %+v", len(cl.Commits), cl.Commits)
+	}
+	if c := cl.Commits[0]; c.Type != TypeRevert || c.RevertsRelease != "v1.0.0" {
+		t.Errorf("revert = %+v, want revert of v1.0.0", c)
+	}
+	if got := cl.SuggestedBump(); got != version.BumpPatch {
+		t.Errorf("SuggestedBump() = %q, want patch", got)
+	}
+}
+
+func TestSuggestedBump(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []Commit
+		want    version.BumpType
+	}{
+		{name: "fixes only", commits: []Commit{{Type: TypeFix}}, want: version.BumpPatch},
+		{name: "feature", commits: []Commit{{Type: TypeFix}, {Type: TypeFeat}}, want: version.BumpMinor},
+		{name: "breaking", commits: []Commit{{Type: TypeFeat}, {Type: TypeFix, Breaking: true}}, want: version.BumpMajor},
+		{
+			name:    "grouped feature",
+			commits: []Commit{{Type: TypeOther, Grouped: []Commit{{Type: TypeFeat}}}},
+			want:    version.BumpMinor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := newChangelog("", "", tt.commits)
+			if got := cl.SuggestedBump(); got != tt.want {
+				t.Errorf("SuggestedBump() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,10 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/alexjoedt/forge/internal/changelog"
 	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/git"
 	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/run"
+	"github.com/alexjoedt/forge/internal/version"
+	"github.com/alexjoedt/forge/pkg/forge"
 	"github.com/urfave/cli/v3"
 )
 
@@ -18,6 +25,11 @@ func Changelog() *cli.Command {
 		Usage: "Generate changelog from git commit history",
 		Description: `Generate a formatted changelog from git commits between two tags.
 
+Without --from, the range starts at the previous stable release, so notes for
+v1.6.0 cover everything since v1.5.0, including v1.6.0-rc.1..rc.3. Use
+--since-previous to start at the immediately preceding tag instead, e.g. to show
+only the delta between two release candidates.
+
 Supports Conventional Commits format (https://www.conventionalcommits.org/):
   - feat: New feature
   - fix: Bug fix
@@ -31,9 +43,12 @@ Supports Conventional Commits format (https://www.conventionalcommits.org/):
   - chore: Maintenance tasks
 
 Examples:
-  # Generate changelog from last tag to HEAD
+  # Generate changelog from the last stable tag to HEAD
   forge changelog
 
+  # Only the changes since the previous release candidate
+  forge changelog --to v1.6.0-rc.3 --since-previous
+
   # Generate changelog between two tags
   forge changelog --from v1.0.0 --to v1.1.0
 
@@ -44,12 +59,39 @@ Examples:
   forge changelog --output CHANGELOG.md
 
   # Multi-app changelog
-  forge changelog --app api --from api/v1.0.0`,
+  forge changelog --app api --from api/v1.0.0
+
+  # Use merge commit PR titles as entries
+  forge changelog --first-parent
+
+  # Collapse commits of the same pull request into one entry
+  forge changelog --group-by-pr
+
+  # Credit contributors (including Co-authored-by trailers)
+  forge changelog --contributors --co-authors
+
+  # debian/changelog or RPM %changelog entry for a release
+  forge changelog --from v1.1.0 --to v1.2.0 --format debian --package myservice
+  forge changelog --from v1.1.0 --to v1.2.0 --format rpm
+
+  # Issues referenced in a release (changelog.issues patterns)
+  forge changelog --from v1.1.0 --to v1.2.0 --issues-only
+
+  # Atom or RSS feed of all releases
+  forge changelog --format atom --base-url https://github.com/org/repo -o releases.atom`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "from",
 				Aliases: []string{"f"},
-				Usage:   "Starting tag (defaults to latest tag)",
+				Usage:   "Starting tag (defaults to the previous stable tag)",
+			},
+			&cli.BoolFlag{
+				Name:  "since-stable",
+				Usage: "Start at the previous stable tag, spanning all prereleases in between (default)",
+			},
+			&cli.BoolFlag{
+				Name:  "since-previous",
+				Usage: "Start at the immediately preceding tag, including prereleases",
 			},
 			&cli.StringFlag{
 				Name:    "to",
@@ -60,7 +102,7 @@ Examples:
 			&cli.StringFlag{
 				Name:    "format",
 				Aliases: []string{"fmt"},
-				Usage:   "Output format (markdown, json, plain)",
+				Usage:   "Output format (markdown, json, plain, debian, rpm, atom, rss)",
 				Value:   "markdown",
 			},
 			&cli.StringFlag{
@@ -73,6 +115,43 @@ Examples:
 				Aliases: []string{"a"},
 				Usage:   "Application name (for multi-app repos)",
 			},
+			&cli.BoolFlag{
+				Name:  "first-parent",
+				Usage: "Follow only the first parent and use merge commit PR titles as entries (overrides changelog.mode)",
+			},
+			&cli.BoolFlag{
+				Name:  "group-by-pr",
+				Usage: "Collapse commits of the same pull request into one entry (overrides changelog.group_by_pr)",
+			},
+			&cli.BoolFlag{
+				Name:  "contributors",
+				Usage: "Add a contributors section highlighting first-time contributors (overrides changelog.contributors)",
+			},
+			&cli.BoolFlag{
+				Name:  "co-authors",
+				Usage: "Credit Co-authored-by trailers as contributors (overrides changelog.co_authors)",
+			},
+			&cli.StringFlag{
+				Name:  "package",
+				Usage: "Package name for debian/rpm formats (overrides changelog.package.name)",
+			},
+			&cli.StringFlag{
+				Name:  "distribution",
+				Usage: "Debian distribution (overrides changelog.package.distribution)",
+			},
+			&cli.StringFlag{
+				Name: "maintainer",
+				Usage: "Maintainer \"Name <email>\" for debian/rpm formats and the atom feed author " +
+					"(overrides changelog.package.maintainer)",
+			},
+			&cli.BoolFlag{
+				Name:  "issues-only",
+				Usage: "Only list the issues referenced in the range (needs changelog.issues patterns)",
+			},
+			&cli.StringFlag{
+				Name:  "base-url",
+				Usage: "Repository web URL for links in atom/rss feeds (e.g. https://github.com/org/repo)",
+			},
 		},
 		Action: changelogAction,
 	}
@@ -81,24 +160,27 @@ Examples:
 //nolint:funlen
 func changelogAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
-	repoDir := "."
-	app := cmd.String("app")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate environment
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.LoadFromDir(repoDir)
+	repo, err := openRepo(ctx, cmd, repoDir, false)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
+	cfg := repo.Config()
+	app := selectApp(ctx, cmd, cfg, repoDir)
 
 	// Get app config
-	appConfig, err := cfg.GetAppConfig(app)
+	appConfig, err := repo.App(app)
 	if err != nil {
-		return fmt.Errorf("get app config: %w", err)
+		return err
 	}
 
 	// Get tags
@@ -107,12 +189,6 @@ func changelogAction(ctx context.Context, cmd *cli.Command) error {
 	format := cmd.String("format")
 	output := cmd.String("output")
 
-	// If no from tag specified, use latest tag.
-	if from == "" {
-		//nolint:godox // TODO: Get latest tag from git
-		logger.Warnf("No --from tag specified, using all commits up to HEAD")
-	}
-
 	// Validate format
 	var changelogFormat changelog.Format
 	switch format {
@@ -122,17 +198,91 @@ func changelogAction(ctx context.Context, cmd *cli.Command) error {
 		changelogFormat = changelog.JSONFormat
 	case "plain", "text":
 		changelogFormat = changelog.PlainFormat
+	case "debian", "deb":
+		changelogFormat = changelog.DebianFormat
+	case "rpm":
+		changelogFormat = changelog.RPMFormat
+	case "atom":
+		changelogFormat = changelog.AtomFormat
+	case "rss":
+		changelogFormat = changelog.RSSFormat
 	default:
-		return fmt.Errorf("unsupported format: %s (use markdown, json, or plain)", format)
+		return fmt.Errorf("unsupported format: %s (use markdown, json, plain, debian, rpm, atom, or rss)", format)
+	}
+
+	if cmd.Bool("since-stable") && cmd.Bool("since-previous") {
+		return fmt.Errorf("--since-stable and --since-previous are mutually exclusive")
+	}
+	if cmd.String("from") != "" && (cmd.Bool("since-stable") || cmd.Bool("since-previous")) {
+		return fmt.Errorf("--from cannot be combined with --since-stable or --since-previous")
+	}
+
+	// Resolve changelog mode: flags override config
+	changelogCfg := appConfig.GetChangelogConfig()
+	opts := changelogOptions(cmd, changelogCfg)
+
+	issuesOnly := cmd.Bool("issues-only")
+	if issuesOnly {
+		if len(opts.Issues) == 0 {
+			return &ForgeError{
+				Title:       "No issue patterns configured",
+				Description: "--issues-only lists issue tracker references, but forge doesn't know what they look like.",
+				Suggestions: []string{
+					"Add changelog.issues to forge.yaml, e.g. pattern 'PAY-\\d+' with url 'https://example.atlassian.net/browse/{id}'",
+				},
+			}
+		}
+		switch changelogFormat {
+		case changelog.MarkdownFormat, changelog.JSONFormat, changelog.PlainFormat:
+		default:
+			return fmt.Errorf("--issues-only supports markdown, json and plain formats, not %s", format)
+		}
 	}
 
 	// Parse commits
 	logger.Infof("Parsing git commits...")
-	parser := changelog.NewParser(repoDir, appConfig.Prefix)
+	parser := changelog.NewParserWithOptions(repoDir, appConfig.Prefix, opts)
 
-	cl, err := parser.Parse(ctx, from, to)
+	// Feeds cover the full release history instead of a single range
+	if changelogFormat == changelog.AtomFormat || changelogFormat == changelog.RSSFormat {
+		appName := app
+		if appName == "" {
+			appName = cfg.DefaultApp
+		}
+		name, nameErr := projectName(ctx, repoDir, appName)
+		if nameErr != nil {
+			return nameErr
+		}
+		feed := changelog.FeedInfo{
+			Name:    name,
+			BaseURL: cmd.String("base-url"),
+			Author:  feedAuthor(ctx, cmd, repoDir, changelogCfg.Package),
+		}
+		formatted, feedErr := releaseFeed(ctx, parser,
+			git.NewTagger(repoDir, appConfig.Prefix, false).
+				WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat),
+			changelogFormat, feed)
+		if feedErr != nil {
+			return feedErr
+		}
+		return writeChangelog(ctx, output, formatted)
+	}
+
+	// Without --from, start at the previous (stable) release
+	cl, err := repo.Changelog(ctx, forge.ChangelogRequest{
+		App:           app,
+		From:          from,
+		To:            to,
+		SincePrevious: cmd.Bool("since-previous"),
+		Modes:         changelogModes(cmd),
+	})
 	if err != nil {
-		return fmt.Errorf("parse changelog: %w", err)
+		return err
+	}
+	to = cl.ToTag
+
+	if len(cl.Excluded) > 0 {
+		logger.Infof("Excluded %d commits (%s)", len(cl.Excluded), excludedSummary(cl.Excluded))
 	}
 
 	if len(cl.Commits) == 0 {
@@ -140,31 +290,258 @@ func changelogAction(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
+	if issuesOnly {
+		formatted, err := changelog.FormatIssues(cl, changelogFormat)
+		if err != nil {
+			return fmt.Errorf("format issues: %w", err)
+		}
+		if len(cl.Issues) == 0 {
+			logger.Warnf("No issues referenced in range")
+		}
+		return writeChangelog(ctx, output, formatted)
+	}
+
 	logger.Infof("Found %d commits", len(cl.Commits))
 
 	// Format changelog
 	var formatted string
 	switch changelogFormat {
-	case changelog.MarkdownFormat:
-		formatted = changelog.FormatMarkdown(cl)
-	case changelog.JSONFormat:
-		formatted, err = changelog.FormatJSON(cl)
-		if err != nil {
-			return fmt.Errorf("format JSON: %w", err)
+	case changelog.MarkdownFormat, changelog.JSONFormat, changelog.PlainFormat:
+		if formatted, err = forge.FormatChangelog(cl, changelogFormat); err != nil {
+			return err
+		}
+	case changelog.DebianFormat, changelog.RPMFormat:
+		appName := app
+		if appName == "" {
+			appName = cfg.DefaultApp
+		}
+		pkg, pkgErr := resolvePackageInfo(ctx, cmd, repoDir, appName, appConfig, changelogCfg.Package, to)
+		if pkgErr != nil {
+			return pkgErr
+		}
+		if changelogFormat == changelog.DebianFormat {
+			formatted = changelog.FormatDebian(cl, pkg)
+		} else {
+			formatted = changelog.FormatRPM(cl, pkg)
 		}
-	case changelog.PlainFormat:
-		formatted = changelog.FormatPlain(cl)
 	}
 
-	// Output
-	if output != "" {
-		if err := os.WriteFile(output, []byte(formatted), 0o600); err != nil {
-			return fmt.Errorf("write file: %w", err)
+	return writeChangelog(ctx, output, formatted)
+}
+
+// changelogOptions builds the parser options from the changelog config, letting the
+// command's mode flags override it.
+func changelogOptions(cmd *cli.Command, changelogCfg config.ChangelogConfig) changelog.Options {
+	return forge.NewChangelogOptions(changelogCfg, changelogModes(cmd))
+}
+
+// changelogModes returns the changelog mode flags set on the command.
+func changelogModes(cmd *cli.Command) forge.ChangelogModes {
+	flag := func(name string) *bool {
+		if !cmd.IsSet(name) {
+			return nil
 		}
-		logger.Success("Changelog written to %s", output)
-	} else {
+		value := cmd.Bool(name)
+		return &value
+	}
+	return forge.ChangelogModes{
+		FirstParent:  flag("first-parent"),
+		GroupByPR:    flag("group-by-pr"),
+		Contributors: flag("contributors"),
+		CoAuthors:    flag("co-authors"),
+	}
+}
+
+// writeChangelog writes the formatted changelog to the output file, or to stdout if empty.
+func writeChangelog(ctx context.Context, output, formatted string) error {
+	if output == "" {
 		fmt.Fprintln(os.Stdout, formatted)
+		return nil
 	}
 
+	if err := os.WriteFile(output, []byte(formatted), 0o600); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	log.FromContext(ctx).Success("Changelog written to %s", output)
 	return nil
 }
+
+// releaseFeed builds an Atom or RSS feed with one entry per release tag. Each entry
+// covers the commits between the previous tag and its own; the oldest tag covers
+// the history up to it. All releases are parsed by the same parser.
+func releaseFeed(
+	ctx context.Context,
+	parser *changelog.Parser,
+	tagger *git.Tagger,
+	format changelog.Format,
+	feed changelog.FeedInfo,
+) (string, error) {
+	logger := log.FromContext(ctx)
+
+	tags, err := tagger.ListAllTags(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list tags: %w", err)
+	}
+	if len(tags) == 0 {
+		logger.Warnf("No release tags found, the feed will be empty")
+	}
+
+	// Parse the oldest release first, so the parser extends the contributors known
+	// before each release instead of scanning the history again for every tag
+	releases := make([]changelog.Release, len(tags))
+	for i := len(tags) - 1; i >= 0; i-- {
+		tag := tags[i]
+		from := ""
+		if i+1 < len(tags) {
+			from = tags[i+1].Tag
+		}
+
+		cl, err := parser.Parse(ctx, from, tag.Tag)
+		if err != nil {
+			return "", fmt.Errorf("parse changelog for %s: %w", tag.Tag, err)
+		}
+
+		date, err := time.Parse(git.DateFormat, tag.Date)
+		if err != nil {
+			return "", fmt.Errorf("parse date of %s: %w", tag.Tag, err)
+		}
+
+		releases[i] = changelog.Release{
+			Tag:       tag.Tag,
+			Commit:    tag.Commit,
+			Date:      date,
+			Changelog: cl,
+		}
+	}
+
+	logger.Infof("Found %d releases", len(releases))
+
+	if format == changelog.RSSFormat {
+		return changelog.FormatRSS(releases, feed)
+	}
+	return changelog.FormatAtom(releases, feed)
+}
+
+// resolvePackageInfo collects the package metadata for the debian and rpm formats.
+// Flags override config; the maintainer falls back to the git identity and the
+// package name to the app name or repository directory.
+func resolvePackageInfo(
+	ctx context.Context,
+	cmd *cli.Command,
+	repoDir, app string,
+	appConfig *config.AppConfig,
+	pkgCfg config.PackageConfig,
+	to string,
+) (changelog.PackageInfo, error) {
+	upstream := version.StripPrefix(to, appConfig.Prefix)
+	_, err := forge.ParseVersion(forge.Scheme(appConfig.Scheme), upstream)
+	if err != nil || !strings.HasPrefix(to, appConfig.Prefix) {
+		return changelog.PackageInfo{}, &ForgeError{
+			Title:       "Package changelogs need a release tag",
+			Description: fmt.Sprintf("'%s' is not a %s version tag with prefix '%s'.", to, appConfig.Scheme, appConfig.Prefix),
+			Suggestions: []string{
+				fmt.Sprintf("Pass the release tag: forge changelog --to %s1.2.0 --format %s",
+					appConfig.Prefix, cmd.String("format")),
+				"Create the tag first with 'forge bump'",
+			},
+		}
+	}
+
+	pkg := changelog.PackageInfo{
+		Name:         pkgCfg.Name,
+		Version:      upstream,
+		Revision:     pkgCfg.Revision,
+		Maintainer:   pkgCfg.Maintainer,
+		Distribution: pkgCfg.Distribution,
+		Urgency:      pkgCfg.Urgency,
+		HotfixSuffix: appConfig.GetHotfixConfig().Suffix,
+	}
+	if cmd.IsSet("package") {
+		pkg.Name = cmd.String("package")
+	}
+	if cmd.IsSet("distribution") {
+		pkg.Distribution = cmd.String("distribution")
+	}
+	if cmd.IsSet("maintainer") {
+		pkg.Maintainer = cmd.String("maintainer")
+	}
+
+	if pkg.Name == "" {
+		name, err := projectName(ctx, repoDir, app)
+		if err != nil {
+			return changelog.PackageInfo{}, err
+		}
+		pkg.Name = name
+	}
+
+	if pkg.Maintainer == "" {
+		pkg.Maintainer = gitIdentity(ctx, repoDir)
+		if pkg.Maintainer == "" {
+			return changelog.PackageInfo{}, &ForgeError{
+				Title:       "No package maintainer configured",
+				Description: "Debian and RPM changelogs must be signed by a maintainer.",
+				Suggestions: []string{
+					"Pass --maintainer \"Jane Doe <jane@example.com>\"",
+					"Set changelog.package.maintainer in forge.yaml",
+					"Configure your git identity: git config user.name / user.email",
+				},
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+// feedAuthor returns the "Name <email>" credited as author of a release feed: the
+// package maintainer if one is set, else the git identity. It is empty if neither is
+// configured.
+func feedAuthor(ctx context.Context, cmd *cli.Command, repoDir string, pkgCfg config.PackageConfig) string {
+	if cmd.IsSet("maintainer") {
+		return cmd.String("maintainer")
+	}
+	if pkgCfg.Maintainer != "" {
+		return pkgCfg.Maintainer
+	}
+	return gitIdentity(ctx, repoDir)
+}
+
+// gitIdentity returns the configured git user as "Name <email>", or an empty string
+// if the name or email is not set.
+func gitIdentity(ctx context.Context, repoDir string) string {
+	name := strings.TrimSpace(run.CmdInDir(ctx, repoDir, "git", "config", "user.name").Stdout)
+	email := strings.TrimSpace(run.CmdInDir(ctx, repoDir, "git", "config", "user.email").Stdout)
+	if name == "" || email == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
+
+// excludedSummary counts excluded commits per reason, e.g. "2 by author, 1 by paths".
+func excludedSummary(excluded []changelog.Exclusion) string {
+	counts := map[changelog.ExcludeReason]int{}
+	for _, e := range excluded {
+		counts[e.Reason]++
+	}
+
+	parts := []string{}
+	for _, reason := range []changelog.ExcludeReason{
+		changelog.ExcludeSubject, changelog.ExcludeAuthor, changelog.ExcludeScope, changelog.ExcludePaths,
+	} {
+		if n := counts[reason]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d by %s", n, reason))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// projectName returns the app name, or the repository directory name for single-app repos.
+func projectName(ctx context.Context, repoDir, app string) (string, error) {
+	if app != "" {
+		return app, nil
+	}
+	result := run.CmdInDir(ctx, repoDir, "git", "rev-parse", "--show-toplevel")
+	if !result.Success() {
+		return "", fmt.Errorf("determine project name: %s", strings.TrimSpace(result.Stderr))
+	}
+	return filepath.Base(strings.TrimSpace(result.Stdout)), nil
+}
@@ -5,33 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
+	"github.com/alexjoedt/forge/internal/config"
 	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/output"
 	"github.com/alexjoedt/forge/internal/run"
+	"github.com/alexjoedt/forge/pkg/forge"
+	"github.com/urfave/cli/v3"
 )
 
 // ForgeError represents a user friendly error with actionable suggestions.
-type ForgeError struct {
-	Title       string
-	Description string
-	Suggestions []string
-}
-
-func (e *ForgeError) Error() string {
-	var sb strings.Builder
-	fmt.Fprintf(&sb, "Error: %s\n", e.Title)
-	if e.Description != "" {
-		fmt.Fprintf(&sb, "\n  %s\n", e.Description)
-	}
-	if len(e.Suggestions) > 0 {
-		sb.WriteString("\n  Suggestions:\n")
-		for _, suggestion := range e.Suggestions {
-			fmt.Fprintf(&sb, "    \u2022 %s\n", suggestion)
-		}
-	}
-	return sb.String()
-}
+type ForgeError = forge.Error
 
 // ValidateRequirements checks for forge.yaml and git repository.
 // This should be called for commands that require these dependencies.
@@ -56,7 +40,7 @@ func ValidateRequirements(ctx context.Context, repoDir string) error {
 			Description: fmt.Sprintf("No forge.yaml or .forge.yaml found in %s", absPath),
 			Suggestions: []string{
 				"Run 'forge init' to create a new configuration",
-				"If you're in the wrong directory, use --repo-dir to specify the correct path",
+				"If you're in the wrong directory, use -C/--repo-dir to specify the correct path",
 				"Check that forge.yaml exists in your project root",
 			},
 		}
@@ -81,57 +65,52 @@ func ValidateRequirements(ctx context.Context, repoDir string) error {
 	return nil
 }
 
-// CheckGitClean checks if the git working directory has uncommitted changes
-func CheckGitClean(ctx context.Context, repoDir string, allowDirty bool) error {
-	if allowDirty {
-		return nil
+// openRepo opens repoDir through the public API, logging and reporting results like
+// the rest of the CLI according to the global --verbose and --json flags.
+func openRepo(ctx context.Context, cmd *cli.Command, repoDir string, dryRun bool) (*forge.Repo, error) {
+	format := forge.OutputText
+	if output.FromContext(ctx).IsJSON() {
+		format = forge.OutputJSON
 	}
+	verbose := cmd.Root().Bool("verbose") && format != forge.OutputJSON
 
-	result := run.CmdInDir(ctx, repoDir, "git", "status", "--porcelain")
-	if !result.Success() {
-		return fmt.Errorf("failed to check git status: %s", result.Stderr)
-	}
+	return forge.Open(repoDir,
+		forge.WithDryRun(dryRun),
+		forge.WithLogger(os.Stdout, verbose),
+		forge.WithOutput(os.Stdout, format),
+	)
+}
 
-	if result.Stdout != "" {
-		return &ForgeError{
-			Title:       "Working directory has uncommitted changes",
-			Description: "Git working directory is not clean. Forge requires a clean state before creating version tags.",
-			Suggestions: []string{
-				"Commit your changes: git add . && git commit -m 'Your message'",
-				"Stash your changes: git stash",
-				"Use --force to create tag anyway (not recommended)",
-			},
-		}
+// findRepoDir returns the directory forge works in: the closest directory with a
+// forge.yaml, from the global --repo-dir (the working directory by default) up to the
+// git top-level.
+func findRepoDir(cmd *cli.Command) (string, error) {
+	repoDir, err := config.FindRoot(cmd.String("repo-dir"))
+	if err != nil {
+		return "", fmt.Errorf("find repository: %w", err)
 	}
-
-	return nil
+	return repoDir, nil
 }
 
-// CheckForExistingTags checks if any version tags exist
-func CheckForExistingTags(ctx context.Context, repoDir, tagPrefix string) (bool, error) {
-	pattern := tagPrefix + "*"
-	result := run.CmdInDir(ctx, repoDir, "git", "tag", "-l", pattern)
-
-	if !result.Success() {
-		return false, fmt.Errorf("failed to list tags: %s", result.Stderr)
+// selectApp returns the --app flag, or if it isn't given the app whose dir contains
+// the directory forge was started in. Empty selects the default app.
+func selectApp(ctx context.Context, cmd *cli.Command, cfg *config.Config, repoDir string) string {
+	if cmd.IsSet("app") {
+		return cmd.String("app")
 	}
 
-	return result.Stdout != "", nil
-}
-
-// NoTagsError returns an error for when no version tags are found
-func NoTagsError(tagPrefix, initialVersion string) error {
-	if initialVersion == "" {
-		initialVersion = "1.0.0"
+	workDir, err := filepath.Abs(cmd.String("repo-dir"))
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(repoDir, workDir)
+	if err != nil {
+		return ""
 	}
 
-	return &ForgeError{
-		Title:       "No version tags found",
-		Description: "This appears to be the first version tag for this project.",
-		Suggestions: []string{
-			fmt.Sprintf("Create your first tag: forge bump --initial %s", initialVersion),
-			"Or use: forge bump --initial to use default (1.0.0)",
-			fmt.Sprintf("Or manually: git tag %s%s && git push --tags", tagPrefix, initialVersion),
-		},
+	app := cfg.DetectAppFromDir(filepath.ToSlash(rel))
+	if app != "" {
+		log.FromContext(ctx).Debugf("selected app %q from the working directory", app)
 	}
+	return app
 }
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/output"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Config returns the config command group.
+func Config() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Inspect the forge.yaml configuration",
+		Commands: []*cli.Command{
+			configShow(),
+			configSchema(),
+		},
+	}
+}
+
+// configShow returns the config show command.
+func configShow() *cli.Command {
+	return &cli.Command{
+		Name:  "show",
+		Usage: "Print forge.yaml, or with --resolved the effective config of each app",
+		Description: `Print the configuration file as written.
+
+With --resolved, print the configuration forge uses: every app with the
+defaults block and the app it extends merged in. --app selects a single app.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "resolved",
+				Usage: "print the effective config of each app after applying defaults and extends",
+			},
+			&cli.StringFlag{
+				Name:  "app",
+				Usage: "only print the resolved config of this app (implies --resolved)",
+			},
+		},
+		Action: configShowAction,
+	}
+}
+
+func configShowAction(ctx context.Context, cmd *cli.Command) error {
+	out := output.FromContext(ctx)
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if cmd.Bool("resolved") || cmd.IsSet("app") {
+		cfg, err := config.LoadFromDir(repoDir)
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		// Single app configs are shown the way they are written, without an app name
+		var doc any = cfg
+		if cmd.IsSet("app") || !cfg.IsMultiApp() {
+			if doc, err = cfg.GetAppConfig(cmd.String("app")); err != nil {
+				return fmt.Errorf("get app config: %w", err)
+			}
+		}
+		if data, err = yaml.Marshal(doc); err != nil {
+			return fmt.Errorf("marshal config: %w", err)
+		}
+	} else {
+		path, err := config.FindFile(repoDir)
+		if err != nil {
+			return err
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return fmt.Errorf("read config: %w", err)
+		}
+	}
+
+	if out.IsJSON() {
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parse config: %w", err)
+		}
+		return out.Print(doc)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// configSchema returns the config schema command.
+func configSchema() *cli.Command {
+	return &cli.Command{
+		Name:  "schema",
+		Usage: "Print the JSON Schema of forge.yaml",
+		Description: `Print the JSON Schema of forge.yaml, for editors and CI checks.
+
+The schema covers single and multi-app configurations, rejects unknown keys and
+calver_format on apps with another scheme than calver. Single app configurations
+are also checked for required settings. Editors using the YAML
+language server pick it up from a modeline at the top of forge.yaml, which
+forge init writes:
+
+  # yaml-language-server: $schema=` + config.SchemaURL,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "write the schema to this file instead of stdout",
+			},
+		},
+		Action: configSchemaAction,
+	}
+}
+
+func configSchemaAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+
+	data, err := config.SchemaJSON()
+	if err != nil {
+		return err
+	}
+
+	outputPath := cmd.String("output")
+	if outputPath == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, data, 0o600); err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+	logger.Success("Wrote JSON Schema to %s", outputPath)
+	return nil
+}
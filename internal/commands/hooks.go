@@ -0,0 +1,320 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/hooks"
+	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/output"
+	"github.com/alexjoedt/forge/internal/run"
+	"github.com/urfave/cli/v3"
+)
+
+// Hooks returns the hooks command group.
+func Hooks() *cli.Command {
+	return &cli.Command{
+		Name:  "hooks",
+		Usage: "Manage forge's git hooks (commit-msg linting, pre-push tag validation)",
+		Commands: []*cli.Command{
+			hooksInstall(),
+			hooksUninstall(),
+			hooksStatus(),
+			hooksRun(),
+		},
+	}
+}
+
+// HooksOutput represents the output of the hooks install, uninstall and status commands.
+type HooksOutput struct {
+	Dir    string         `json:"dir"`
+	Hooks  []hooks.Status `json:"hooks"`
+	DryRun bool           `json:"dry_run,omitempty"`
+}
+
+// hooksInstall returns the hooks install command.
+func hooksInstall() *cli.Command {
+	return &cli.Command{
+		Name:  "install",
+		Usage: "Install the commit-msg and pre-push hooks",
+		Description: `Install forge-managed commit-msg and pre-push hooks into the repository's
+hooks directory (core.hooksPath if set, .git/hooks otherwise).
+
+Existing hooks are kept: they are renamed with a ".forge-chained" suffix and
+run before forge's checks. Running install again updates the managed hooks.
+
+The commit-msg hook validates the message against the Conventional Commits
+rules in forge.yaml. The pre-push hook rejects tags that match an app's prefix
+but don't parse as a version of that app's scheme.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hooksInstallAction,
+	}
+}
+
+func hooksInstallAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun := cmd.Bool("dry-run")
+
+	if err := ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	dir, err := hooks.Dir(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := hooks.Install(ctx, repoDir, dryRun)
+	if err != nil {
+		return fmt.Errorf("install hooks: %w", err)
+	}
+
+	if out.IsJSON() {
+		return out.Print(HooksOutput{Dir: dir, Hooks: statuses, DryRun: dryRun})
+	}
+
+	verb := "Installed"
+	if dryRun {
+		verb = "[DRY RUN] Would install"
+	}
+	for _, s := range statuses {
+		if s.Chained {
+			logger.Success("%s %s hook (chaining existing %s)", verb, s.Name, filepath.Base(s.Path)+".forge-chained")
+		} else {
+			logger.Success("%s %s hook", verb, s.Name)
+		}
+	}
+	logger.Printf("Hooks directory: %s", dir)
+	return nil
+}
+
+// hooksUninstall returns the hooks uninstall command.
+func hooksUninstall() *cli.Command {
+	return &cli.Command{
+		Name:  "uninstall",
+		Usage: "Remove forge's hooks and restore chained hooks",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hooksUninstallAction,
+	}
+}
+
+func hooksUninstallAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun := cmd.Bool("dry-run")
+
+	dir, err := hooks.Dir(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	before, err := hooks.Inspect(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := hooks.Uninstall(ctx, repoDir, dryRun)
+	if err != nil {
+		return fmt.Errorf("uninstall hooks: %w", err)
+	}
+
+	if out.IsJSON() {
+		return out.Print(HooksOutput{Dir: dir, Hooks: statuses, DryRun: dryRun})
+	}
+
+	prefix := ""
+	if dryRun {
+		prefix = "[DRY RUN] "
+	}
+	for _, s := range before {
+		switch {
+		case !s.Installed:
+			logger.Printf("%s%s hook not managed by forge, left untouched", prefix, s.Name)
+		case s.Chained:
+			logger.Success("%sRemoved %s hook and restored the chained hook", prefix, s.Name)
+		default:
+			logger.Success("%sRemoved %s hook", prefix, s.Name)
+		}
+	}
+	return nil
+}
+
+// hooksStatus returns the hooks status command.
+func hooksStatus() *cli.Command {
+	return &cli.Command{
+		Name:   "status",
+		Usage:  "Show which hooks are installed",
+		Action: hooksStatusAction,
+	}
+}
+
+func hooksStatusAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	dir, err := hooks.Dir(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := hooks.Inspect(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	if out.IsJSON() {
+		return out.Print(HooksOutput{Dir: dir, Hooks: statuses})
+	}
+
+	logger.Printf("Hooks directory: %s", dir)
+	for _, s := range statuses {
+		state := "not installed"
+		switch {
+		case s.Installed && s.Chained:
+			state = "installed (chains existing hook)"
+		case s.Installed:
+			state = "installed"
+		case s.Foreign:
+			state = "not installed (existing hook will be chained on install)"
+		}
+		logger.Printf("  %-12s %s", s.Name, state)
+	}
+	return nil
+}
+
+// hooksRun returns the hidden command the installed hook scripts call.
+func hooksRun() *cli.Command {
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Run a hook's checks (called by the installed hooks)",
+		ArgsUsage: "<hook> [hook arguments]",
+		Hidden:    true,
+		Action:    hooksRunAction,
+	}
+}
+
+func hooksRunAction(ctx context.Context, cmd *cli.Command) error {
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	switch hook := cmd.Args().First(); hook {
+	case hooks.CommitMsg:
+		return runCommitMsgHook(ctx, repoDir, cfg, cmd.Args().Get(1))
+	case hooks.PrePush:
+		return runPrePushHook(ctx, cfg)
+	default:
+		return fmt.Errorf("unknown hook: %q (expected %s)", hook, strings.Join(hooks.Names(), " or "))
+	}
+}
+
+// runCommitMsgHook lints the commit message file against the rules of all apps, as a
+// commit may touch any of them. Merge commits are skipped because git generates their
+// messages.
+func runCommitMsgHook(ctx context.Context, repoDir string, cfg *config.Config, msgFile string) error {
+	logger := log.FromContext(ctx)
+
+	if msgFile == "" {
+		return fmt.Errorf("commit message file argument required")
+	}
+
+	if run.CmdInDir(ctx, repoDir, "git", "rev-parse", "-q", "--verify", "MERGE_HEAD").Success() {
+		return nil
+	}
+
+	lintCfg := cfg.GetLintConfig()
+
+	violations, err := hooks.CheckCommitMsg(msgFile, changelog.LintRules{
+		Types:            lintCfg.Types,
+		Scopes:           lintCfg.Scopes,
+		MaxSubjectLength: lintCfg.MaxSubjectLength,
+	})
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	logger.Errorf("✗ commit message rejected by forge:")
+	for _, v := range violations {
+		logger.Errorf("    %s: %s", v.Rule, v.Message)
+		if v.Suggestion != "" {
+			logger.Errorf("      → %s", v.Suggestion)
+		}
+	}
+	return &ForgeError{
+		Title:       "Commit message does not follow the Conventional Commits rules",
+		Description: "Your message was kept in .git/COMMIT_EDITMSG.",
+		Suggestions: []string{
+			"Fix the message and commit again: git commit -e -F .git/COMMIT_EDITMSG",
+			"Skip the hook once (not recommended): git commit --no-verify",
+		},
+	}
+}
+
+// runPrePushHook rejects pushes of tags that match an app prefix but don't parse
+// under that app's version scheme.
+func runPrePushHook(ctx context.Context, cfg *config.Config) error {
+	logger := log.FromContext(ctx)
+
+	tags, err := hooks.PushedTags(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, tag := range tags {
+		if err := hooks.CheckTag(cfg, tag); err != nil {
+			logger.Errorf("✗ %v", err)
+			invalid++
+		}
+	}
+	if invalid == 0 {
+		return nil
+	}
+
+	return &ForgeError{
+		Title:       fmt.Sprintf("Push rejected: %d invalid version tag(s)", invalid),
+		Description: "Tags that use an app's prefix must be valid versions of that app's scheme.",
+		Suggestions: []string{
+			"Delete the tag locally: git tag -d <tag>",
+			"Create version tags with 'forge bump' instead of 'git tag'",
+			"Skip the hook once (not recommended): git push --no-verify",
+		},
+	}
+}
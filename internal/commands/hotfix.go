@@ -2,14 +2,23 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/alexjoedt/forge/internal/changelog"
 	"github.com/alexjoedt/forge/internal/config"
 	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/interactive"
 	"github.com/alexjoedt/forge/internal/log"
 	"github.com/alexjoedt/forge/internal/output"
+	"github.com/alexjoedt/forge/internal/table"
+	"github.com/alexjoedt/forge/internal/version"
 	"github.com/urfave/cli/v3"
 )
 
@@ -24,6 +33,12 @@ func Hotfix() *cli.Command {
 			hotfixBump(),
 			hotfixStatus(),
 			hotfixList(),
+			hotfixChangelog(),
+			hotfixPick(),
+			hotfixSync(),
+			hotfixFinish(),
+			hotfixReport(),
+			hotfixPrune(),
 		},
 	}
 }
@@ -76,9 +91,9 @@ func hotfixCreateAction(ctx context.Context, cmd *cli.Command) error {
 	dryRun := cmd.Bool("dry-run")
 
 	// 2. Validate requirements
-	repoDir, err := os.Getwd()
+	repoDir, err := findRepoDir(cmd)
 	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
+		return err
 	}
 
 	if err = ValidateRequirements(ctx, repoDir); err != nil {
@@ -114,6 +129,9 @@ func hotfixCreateAction(ctx context.Context, cmd *cli.Command) error {
 	if err = git.ValidateHotfixBaseTag(ctx, repoDir, baseTag); err != nil {
 		return err
 	}
+	if err = validateHotfixBase(appConfig, baseTag); err != nil {
+		return err
+	}
 
 	// 7. Get hotfix config with defaults
 	hotfixCfg := appConfig.GetHotfixConfig()
@@ -169,6 +187,10 @@ func hotfixBump() *cli.Command {
 				Aliases: []string{"m"},
 				Usage:   "Custom tag message (default: 'Hotfix <tag>')",
 			},
+			&cli.BoolFlag{
+				Name:  "changelog",
+				Usage: "Append the changes since the previous hotfix to the tag message",
+			},
 			&cli.BoolFlag{
 				Name:  "push",
 				Usage: "Push tag to remote after creation",
@@ -200,9 +222,9 @@ func hotfixBumpAction(ctx context.Context, cmd *cli.Command) error {
 	out := output.FromContext(ctx)
 	dryRun := cmd.Bool("dry-run")
 
-	repoDir, err := os.Getwd()
+	repoDir, err := findRepoDir(cmd)
 	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
+		return err
 	}
 
 	// Check if --base flag provided (create + bump in one step)
@@ -216,29 +238,11 @@ func hotfixBumpAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	currentBranch, err := git.GetCurrentBranch(repoDir)
+	// Detect hotfix context from current branch
+	appConfig, baseTag, err := currentHotfixLine(ctx, repoDir, cfg)
 	if err != nil {
 		return err
 	}
-
-	// Detect hotfix context from current branch
-	var appConfig *config.AppConfig
-	var baseTag string
-	var hotfixCfg config.HotfixConfig
-
-	for _, app := range cfg.GetAllAppConfigs() {
-		hotfixCfg = app.GetHotfixConfig()
-
-		if git.IsHotfixBranch(currentBranch, hotfixCfg.BranchPrefix) {
-			baseTag, err = git.ExtractTagFromBranch(currentBranch, hotfixCfg.BranchPrefix)
-			if err != nil {
-				return fmt.Errorf("invalid hotfix branch format: %w", err)
-			}
-			appConfig = app
-			break
-		}
-	}
-
 	if appConfig == nil {
 		return fmt.Errorf(
 			"not on a hotfix branch\n\nUse one of these commands:\n  forge hotfix create <tag>   - Create hotfix branch first\n  forge hotfix bump --base <tag>  - Create and bump in one step",
@@ -254,18 +258,18 @@ func hotfixBumpAction(ctx context.Context, cmd *cli.Command) error {
 	tagger := git.NewTagger(repoDir, appConfig.Prefix, dryRun)
 
 	// Get next hotfix tag
-	nextTag, seq, err := tagger.GetNextHotfixTag(ctx, baseTag, hotfixCfg.Suffix)
+	nextTag, seq, err := tagger.NextHotfix(ctx, baseTag, hotfixNaming(appConfig))
 	if err != nil {
 		return fmt.Errorf("get next hotfix tag: %w", err)
 	}
 
 	// Create tag
-	message := cmd.String("message")
-	if message == "" {
-		message = fmt.Sprintf("Hotfix %s", nextTag)
+	message, err := hotfixTagMessage(ctx, cmd, repoDir, appConfig, baseTag, nextTag)
+	if err != nil {
+		return err
 	}
 
-	if err = tagger.CreateHotfixTag(ctx, nextTag, message); err != nil {
+	if err = tagger.CreateHotfixTag(ctx, nextTag, baseTag, "HEAD", message); err != nil {
 		return fmt.Errorf("create hotfix tag: %w", err)
 	}
 
@@ -289,7 +293,7 @@ func hotfixBumpAction(ctx context.Context, cmd *cli.Command) error {
 		Version:  strings.TrimPrefix(nextTag, appConfig.Prefix),
 		BaseTag:  baseTag,
 		Sequence: seq,
-		Branch:   currentBranch,
+		Branch:   appConfig.GetHotfixConfig().BranchPrefix + baseTag,
 		Created:  !dryRun,
 		Pushed:   pushed,
 		Message:  fmt.Sprintf("Created hotfix tag %s", nextTag),
@@ -305,9 +309,9 @@ func hotfixBumpAction(ctx context.Context, cmd *cli.Command) error {
 func quickHotfixBump(ctx context.Context, cmd *cli.Command, baseTag string, out *output.Manager, dryRun bool) error {
 	logger := log.FromContext(ctx)
 
-	repoDir, err := os.Getwd()
+	repoDir, err := findRepoDir(cmd)
 	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
+		return err
 	}
 
 	if err = ValidateRequirements(ctx, repoDir); err != nil {
@@ -334,6 +338,9 @@ func quickHotfixBump(ctx context.Context, cmd *cli.Command, baseTag string, out
 	if err = git.ValidateHotfixBaseTag(ctx, repoDir, baseTag); err != nil {
 		return err
 	}
+	if err = validateHotfixBase(appConfig, baseTag); err != nil {
+		return err
+	}
 
 	hotfixCfg := appConfig.GetHotfixConfig()
 
@@ -354,18 +361,18 @@ func quickHotfixBump(ctx context.Context, cmd *cli.Command, baseTag string, out
 	}
 
 	// Get next hotfix tag
-	nextTag, seq, err := tagger.GetNextHotfixTag(ctx, baseTag, hotfixCfg.Suffix)
+	nextTag, seq, err := tagger.NextHotfix(ctx, baseTag, hotfixNaming(appConfig))
 	if err != nil {
 		return fmt.Errorf("get next hotfix tag: %w", err)
 	}
 
 	// Create tag
-	message := cmd.String("message")
-	if message == "" {
-		message = fmt.Sprintf("Hotfix %s", nextTag)
+	message, err := hotfixTagMessage(ctx, cmd, repoDir, appConfig, baseTag, nextTag)
+	if err != nil {
+		return err
 	}
 
-	if err = tagger.CreateHotfixTag(ctx, nextTag, message); err != nil {
+	if err = tagger.CreateHotfixTag(ctx, nextTag, baseTag, "HEAD", message); err != nil {
 		return fmt.Errorf("create hotfix tag: %w", err)
 	}
 
@@ -433,9 +440,9 @@ type ActiveHotfix struct {
 func hotfixStatusAction(ctx context.Context, cmd *cli.Command) error {
 	out := output.FromContext(ctx)
 
-	repoDir, err := os.Getwd()
+	repoDir, err := findRepoDir(cmd)
 	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
+		return err
 	}
 
 	cfg, err := config.LoadFromDir(repoDir)
@@ -443,7 +450,7 @@ func hotfixStatusAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	currentBranch, err := git.GetCurrentBranch(repoDir)
+	currentBranch, err := git.GetCurrentBranch(ctx, repoDir)
 	if err != nil {
 		return err
 	}
@@ -453,53 +460,39 @@ func hotfixStatusAction(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Check current branch
-	for _, app := range cfg.GetAllAppConfigs() {
-		hotfixCfg := app.GetHotfixConfig()
-
-		if git.IsHotfixBranch(currentBranch, hotfixCfg.BranchPrefix) {
-			result.OnHotfixBranch = true
-			result.BaseTag, _ = git.ExtractTagFromBranch(currentBranch, hotfixCfg.BranchPrefix)
-
-			// Get hotfix info
-			tagger := git.NewTagger(repoDir, app.Prefix, false)
-			nextTag, seq, _ := tagger.GetNextHotfixTag(ctx, result.BaseTag, hotfixCfg.Suffix)
-			result.NextHotfix = nextTag
-			result.HotfixCount = seq - 1
-
-			if result.HotfixCount > 0 {
-				result.LastHotfix = fmt.Sprintf("%s-%s.%d", result.BaseTag, hotfixCfg.Suffix, result.HotfixCount)
-			}
-
-			break
+	if current := hotfixBranchOf(cfg, currentBranch); current != nil {
+		result.OnHotfixBranch = true
+		result.BaseTag = current.baseTag
+
+		// Get hotfix info
+		tagger := git.NewTagger(repoDir, current.app.Prefix, false)
+		result.NextHotfix, _, _ = tagger.NextHotfix(ctx, result.BaseTag, hotfixNaming(current.app))
+		hotfixes, _ := tagger.HotfixLine(ctx, result.BaseTag, current.app.GetHotfixConfig().Suffix)
+		result.HotfixCount = len(hotfixes)
+
+		if result.HotfixCount > 0 {
+			result.LastHotfix = hotfixes[len(hotfixes)-1]
 		}
 	}
 
 	// List all active hotfix branches
-	branches, _ := git.ListBranches(repoDir)
-	for _, app := range cfg.GetAllAppConfigs() {
-		hotfixCfg := app.GetHotfixConfig()
-
-		for _, branch := range branches {
-			if git.IsHotfixBranch(branch, hotfixCfg.BranchPrefix) {
-				baseTag, _ := git.ExtractTagFromBranch(branch, hotfixCfg.BranchPrefix)
-
-				tagger := git.NewTagger(repoDir, app.Prefix, false)
-				_, seq, _ := tagger.GetNextHotfixTag(ctx, baseTag, hotfixCfg.Suffix)
-				count := seq - 1
-
-				var lastTag string
-				if count > 0 {
-					lastTag = fmt.Sprintf("%s-%s.%d", baseTag, hotfixCfg.Suffix, count)
-				}
-
-				result.ActiveHotfixes = append(result.ActiveHotfixes, ActiveHotfix{
-					Branch:  branch,
-					BaseTag: baseTag,
-					LastTag: lastTag,
-					Count:   count,
-				})
-			}
+	branches, _ := listHotfixBranches(ctx, repoDir, cfg)
+	for _, b := range branches {
+		tagger := git.NewTagger(repoDir, b.app.Prefix, false)
+		hotfixes, _ := tagger.HotfixLine(ctx, b.baseTag, b.app.GetHotfixConfig().Suffix)
+		count := len(hotfixes)
+
+		var lastTag string
+		if count > 0 {
+			lastTag = hotfixes[count-1]
 		}
+
+		result.ActiveHotfixes = append(result.ActiveHotfixes, ActiveHotfix{
+			Branch:  b.name,
+			BaseTag: b.baseTag,
+			LastTag: lastTag,
+			Count:   count,
+		})
 	}
 
 	return out.Print(result)
@@ -533,9 +526,9 @@ type HotfixListOutput struct {
 func hotfixListAction(ctx context.Context, cmd *cli.Command) error {
 	out := output.FromContext(ctx)
 
-	repoDir, err := os.Getwd()
+	repoDir, err := findRepoDir(cmd)
 	if err != nil {
-		return fmt.Errorf("get working directory: %w", err)
+		return err
 	}
 
 	baseTag := cmd.Args().First()
@@ -548,23 +541,11 @@ func hotfixListAction(ctx context.Context, cmd *cli.Command) error {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		var currentBranch string
-		currentBranch, err = git.GetCurrentBranch(repoDir)
+		_, baseTag, err = currentHotfixLine(ctx, repoDir, detectionCfg)
 		if err != nil {
 			return err
 		}
 
-		for _, app := range detectionCfg.GetAllAppConfigs() {
-			hotfixCfg := app.GetHotfixConfig()
-			if git.IsHotfixBranch(currentBranch, hotfixCfg.BranchPrefix) {
-				baseTag, err = git.ExtractTagFromBranch(currentBranch, hotfixCfg.BranchPrefix)
-				if err != nil {
-					return err
-				}
-				break
-			}
-		}
-
 		if baseTag == "" {
 			return fmt.Errorf(
 				"base tag required (or run from hotfix branch)\n\nUsage: forge hotfix list <base-tag>\n\nExample:\n  forge hotfix list v1.0.0",
@@ -589,24 +570,13 @@ func hotfixListAction(ctx context.Context, cmd *cli.Command) error {
 
 	hotfixCfg := appConfig.GetHotfixConfig()
 
-	// List all hotfix tags - we'll call the GetNextHotfixTag to get them
+	// List the hotfix tags of both naming styles
 	tagger := git.NewTagger(repoDir, appConfig.Prefix, false)
-
-	// Get all tags and filter for hotfix pattern
-	allTagsInfo, err := tagger.ListAllTags(ctx)
+	hotfixTags, err := tagger.HotfixLine(ctx, baseTag, hotfixCfg.Suffix)
 	if err != nil {
 		return fmt.Errorf("failed to list tags: %w", err)
 	}
 
-	// Filter for hotfix tags matching the base tag
-	pattern := fmt.Sprintf("%s-%s.", baseTag, hotfixCfg.Suffix)
-	hotfixTags := []string{}
-	for _, tagInfo := range allTagsInfo {
-		if strings.HasPrefix(tagInfo.Tag, pattern) {
-			hotfixTags = append(hotfixTags, tagInfo.Tag)
-		}
-	}
-
 	result := HotfixListOutput{
 		BaseTag:  baseTag,
 		Hotfixes: hotfixTags,
@@ -615,3 +585,1664 @@ func hotfixListAction(ctx context.Context, cmd *cli.Command) error {
 
 	return out.Print(result)
 }
+
+// hotfixChangelog returns the hotfix changelog command.
+func hotfixChangelog() *cli.Command {
+	return &cli.Command{
+		Name:      "changelog",
+		Usage:     "Generate the changelog of a hotfix or a whole hotfix line",
+		ArgsUsage: "[hotfix-tag]",
+		Description: `Generate the changelog of a hotfix release. The range starts at the previous
+hotfix in the same line, or at the base tag for the first hotfix.
+
+Without an argument, shows the unreleased changes on the current hotfix branch.
+Passing a base tag instead of a hotfix tag covers the whole line.
+
+Examples:
+  # Changes since v1.5.0-hotfix.1
+  forge hotfix changelog v1.5.0-hotfix.2
+
+  # Everything fixed on top of v1.5.0 so far
+  forge hotfix changelog v1.5.0-hotfix.2 --line
+  forge hotfix changelog v1.5.0
+
+  # Pending changes on the current hotfix branch
+  forge hotfix changelog`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "app",
+				Aliases: []string{"a"},
+				Usage:   "Specify app name (optional, auto-detected from tag)",
+			},
+			&cli.BoolFlag{
+				Name:  "line",
+				Usage: "Cover the whole hotfix line since the base tag",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"fmt"},
+				Usage:   "Output format (markdown, json, plain)",
+				Value:   "markdown",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output file (defaults to stdout)",
+			},
+			&cli.BoolFlag{
+				Name:  "first-parent",
+				Usage: "Follow only the first parent and use merge commit PR titles as entries (overrides changelog.mode)",
+			},
+			&cli.BoolFlag{
+				Name:  "group-by-pr",
+				Usage: "Collapse commits of the same pull request into one entry (overrides changelog.group_by_pr)",
+			},
+			&cli.BoolFlag{
+				Name:  "contributors",
+				Usage: "Add a contributors section (overrides changelog.contributors)",
+			},
+			&cli.BoolFlag{
+				Name:  "co-authors",
+				Usage: "Credit Co-authored-by trailers as contributors (overrides changelog.co_authors)",
+			},
+		},
+		Action: hotfixChangelogAction,
+	}
+}
+
+//nolint:gocognit // resolving the range from a hotfix tag, a base tag or the current branch is inherently branchy
+func hotfixChangelogAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	var changelogFormat changelog.Format
+	switch format := cmd.String("format"); format {
+	case "markdown", "md":
+		changelogFormat = changelog.MarkdownFormat
+	case "json":
+		changelogFormat = changelog.JSONFormat
+	case "plain", "text":
+		changelogFormat = changelog.PlainFormat
+	default:
+		return fmt.Errorf("unsupported format: %s (use markdown, json, or plain)", format)
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	tag := cmd.Args().First()
+	line := cmd.Bool("line")
+
+	var appConfig *config.AppConfig
+	var from, to string
+
+	if tag == "" {
+		// Unreleased changes on the current hotfix branch
+		var baseTag string
+		appConfig, baseTag, err = currentHotfixLine(ctx, repoDir, cfg)
+		if err != nil {
+			return err
+		}
+		if appConfig == nil {
+			return fmt.Errorf(
+				"hotfix tag required (or run from hotfix branch)\n\nUsage: forge hotfix changelog [hotfix-tag]" +
+					"\n\nExample:\n  forge hotfix changelog v1.0.0-hotfix.2",
+			)
+		}
+
+		tagger := git.NewTagger(repoDir, appConfig.Prefix, false)
+		hotfixes, hotfixErr := tagger.HotfixLine(ctx, baseTag, appConfig.GetHotfixConfig().Suffix)
+		if hotfixErr != nil {
+			return hotfixErr
+		}
+
+		from, to = baseTag, "HEAD"
+		if !line && len(hotfixes) > 0 {
+			from = hotfixes[len(hotfixes)-1]
+		}
+	} else {
+		appName := cmd.String("app")
+		if appName == "" {
+			appName, err = cfg.DetectAppFromTag(tag)
+			if err != nil {
+				return err
+			}
+		}
+
+		appConfig, err = cfg.GetAppConfig(appName)
+		if err != nil {
+			return fmt.Errorf("get app config: %w", err)
+		}
+
+		tagger := git.NewTagger(repoDir, appConfig.Prefix, false)
+		exists, existsErr := tagger.TagExists(ctx, tag)
+		if existsErr != nil {
+			return fmt.Errorf("failed to check tag: %w", existsErr)
+		}
+		if !exists {
+			return fmt.Errorf("tag %q does not exist", tag)
+		}
+
+		baseTag, baseErr := tagger.HotfixBaseOf(ctx, tag)
+		if baseErr != nil {
+			return fmt.Errorf("parse hotfix tag: %w", baseErr)
+		}
+
+		if baseTag != "" {
+			from, to = baseTag, tag
+			if !line {
+				from, err = tagger.PreviousHotfixTag(ctx, baseTag, appConfig.GetHotfixConfig().Suffix, tag)
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			// A base tag covers its whole hotfix line
+			hotfixes, hotfixErr := tagger.HotfixLine(ctx, tag, appConfig.GetHotfixConfig().Suffix)
+			if hotfixErr != nil {
+				return hotfixErr
+			}
+			if len(hotfixes) == 0 {
+				return &ForgeError{
+					Title:       "No hotfixes released",
+					Description: fmt.Sprintf("'%s' has no hotfix tags yet.", tag),
+					Suggestions: []string{
+						"Show the pending changes from the hotfix branch: forge hotfix changelog",
+						fmt.Sprintf("Start a hotfix line: forge hotfix create %s", tag),
+					},
+				}
+			}
+			from, to = tag, hotfixes[len(hotfixes)-1]
+		}
+	}
+
+	logger.Infof("Changelog range: %s..%s", from, to)
+
+	opts := changelogOptions(cmd, appConfig.GetChangelogConfig())
+	parser := changelog.NewParserWithOptions(repoDir, appConfig.Prefix, opts)
+	cl, err := parser.Parse(ctx, from, to)
+	if err != nil {
+		return fmt.Errorf("parse changelog: %w", err)
+	}
+
+	if len(cl.Commits) == 0 {
+		logger.Warnf("No commits found in range")
+		return nil
+	}
+
+	var formatted string
+	switch changelogFormat {
+	case changelog.JSONFormat:
+		formatted, err = changelog.FormatJSON(cl)
+		if err != nil {
+			return fmt.Errorf("format JSON: %w", err)
+		}
+	case changelog.PlainFormat:
+		formatted = changelog.FormatPlain(cl)
+	default:
+		formatted = changelog.FormatMarkdown(cl)
+	}
+
+	return writeChangelog(ctx, cmd.String("output"), formatted)
+}
+
+// hotfixNaming translates the hotfix config of an app into the tag naming rules.
+func hotfixNaming(appConfig *config.AppConfig) git.HotfixNaming {
+	hotfixCfg := appConfig.GetHotfixConfig()
+	naming := git.HotfixNaming{
+		Suffix:   hotfixCfg.Suffix,
+		Patch:    hotfixCfg.Style == config.HotfixStylePatch,
+		FourPart: hotfixCfg.Fallback == config.HotfixFallbackFourPart,
+	}
+	if appConfig.Scheme == string(version.SchemeCalVer) {
+		naming.CalVerFormat = appConfig.CalVerFormat
+	}
+	return naming
+}
+
+// validateHotfixBase checks that a CalVer base tag is a release in the app's
+// calver_format, so its hotfixes can be numbered.
+func validateHotfixBase(appConfig *config.AppConfig, baseTag string) error {
+	if appConfig.Scheme != string(version.SchemeCalVer) {
+		return nil
+	}
+	v, err := version.ParseCalVerFormat(version.StripPrefix(baseTag, appConfig.Prefix), appConfig.CalVerFormat)
+	if err != nil {
+		return fmt.Errorf("cannot create hotfix from %q: %w", baseTag, err)
+	}
+	if v.IsPrerelease() {
+		return fmt.Errorf("cannot create hotfix from prerelease %q", baseTag)
+	}
+	return nil
+}
+
+// hotfixBranch is a local hotfix branch and the app it belongs to.
+type hotfixBranch struct {
+	name    string
+	appName string
+	app     *config.AppConfig
+	baseTag string
+}
+
+// hotfixBranchOf returns the hotfix line of branch, or nil if it is not a hotfix branch
+// of any app. The default branch prefix is shared by every app, so if several apps
+// match, the one whose tag prefix fits the base tag wins; apps are tried by name to
+// keep the choice stable.
+func hotfixBranchOf(cfg *config.Config, branch string) *hotfixBranch {
+	apps := cfg.GetAllApps()
+
+	var match *hotfixBranch
+	for _, name := range slices.Sorted(maps.Keys(apps)) {
+		app := apps[name]
+		prefix := app.GetHotfixConfig().BranchPrefix
+		if !git.IsHotfixBranch(branch, prefix) {
+			continue
+		}
+		baseTag, _ := git.ExtractTagFromBranch(branch, prefix)
+		candidate := &hotfixBranch{name: branch, app: &app, baseTag: baseTag}
+		if cfg.IsMultiApp() {
+			candidate.appName = name
+		}
+		if strings.HasPrefix(baseTag, app.Prefix) {
+			return candidate
+		}
+		if match == nil {
+			match = candidate
+		}
+	}
+	return match
+}
+
+// currentHotfixLine returns the app and base tag of the hotfix branch that is checked out,
+// or a nil app if the current branch is not a hotfix branch.
+func currentHotfixLine(ctx context.Context, repoDir string, cfg *config.Config) (*config.AppConfig, string, error) {
+	currentBranch, err := git.GetCurrentBranch(ctx, repoDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	b := hotfixBranchOf(cfg, currentBranch)
+	if b == nil {
+		return nil, "", nil
+	}
+	return b.app, b.baseTag, nil
+}
+
+// hotfixTagMessage returns the message for the hotfix tag. With --changelog, the plain
+// text changelog since the previous hotfix (or the base tag) is appended.
+func hotfixTagMessage(
+	ctx context.Context,
+	cmd *cli.Command,
+	repoDir string,
+	appConfig *config.AppConfig,
+	baseTag, nextTag string,
+) (string, error) {
+	message := cmd.String("message")
+	if message == "" {
+		message = fmt.Sprintf("Hotfix %s", nextTag)
+	}
+	if !cmd.Bool("changelog") {
+		return message, nil
+	}
+
+	tagger := git.NewTagger(repoDir, appConfig.Prefix, false)
+	from, err := tagger.PreviousHotfixTag(ctx, baseTag, appConfig.GetHotfixConfig().Suffix, nextTag)
+	if err != nil {
+		return "", fmt.Errorf("find previous hotfix: %w", err)
+	}
+
+	opts := changelogOptions(cmd, appConfig.GetChangelogConfig())
+	cl, err := changelog.NewParserWithOptions(repoDir, appConfig.Prefix, opts).Parse(ctx, from, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("parse changelog: %w", err)
+	}
+
+	if len(cl.Commits) == 0 {
+		log.FromContext(ctx).Warnf("No commits since %s, tag message has no changelog", from)
+		return message, nil
+	}
+
+	cl.ToTag = nextTag
+	return message + "\n\n" + changelog.FormatPlain(cl), nil
+}
+
+// hotfixPick returns the hotfix pick command.
+func hotfixPick() *cli.Command {
+	return &cli.Command{
+		Name:      "pick",
+		Usage:     "Cherry-pick commits onto the current hotfix branch",
+		ArgsUsage: "[commit|range...]",
+		Description: `Cherry-pick commits onto the current hotfix branch with -x provenance.
+
+Commits whose changes are already on the branch (same patch-id) are skipped.
+If a cherry-pick stops on conflicts, resolve and stage them, then run
+'forge hotfix pick resume', or roll back with 'forge hotfix pick abort'.
+
+Without arguments, lists the fix: commits on the default branch since the base
+tag for selection.
+
+Examples:
+  forge hotfix pick 3f2a1bc
+  forge hotfix pick 3f2a1bc..9d8e7f6 c0ffee1
+  forge hotfix pick --interactive`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "interactive",
+				Aliases: []string{"i"},
+				Usage:   "Select fix: commits from the default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hotfixPickAction,
+		Commands: []*cli.Command{
+			{
+				Name:    "resume",
+				Aliases: []string{"continue"},
+				Usage:   "Continue a pick that stopped on conflicts",
+				Action:  hotfixPickResumeAction,
+			},
+			{
+				Name:   "abort",
+				Usage:  "Abort a pick and reset the branch to where it started",
+				Action: hotfixPickAbortAction,
+			},
+		},
+	}
+}
+
+// HotfixPickOutput represents the output of hotfix pick command.
+type HotfixPickOutput struct {
+	Branch  string   `json:"branch"`
+	Picked  []string `json:"picked"`
+	Skipped []string `json:"skipped"`
+	Message string   `json:"message"`
+}
+
+//nolint:gocognit // selecting, validating and applying commits are sequential steps of one workflow
+func hotfixPickAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	dryRun := cmd.Bool("dry-run")
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	if state, stateErr := git.LoadPickState(ctx, repoDir); stateErr != nil {
+		return stateErr
+	} else if state != nil {
+		return &ForgeError{
+			Title: "A hotfix pick is already in progress",
+			Description: fmt.Sprintf("Cherry-picking %s onto %s stopped on conflicts.",
+				shortCommit(state.Current), state.Branch),
+			Suggestions: []string{
+				"Resolve and stage the conflicts, then run 'forge hotfix pick resume'",
+				"Roll back with 'forge hotfix pick abort'",
+			},
+		}
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	appConfig, baseTag, err := currentHotfixLine(ctx, repoDir, cfg)
+	if err != nil {
+		return err
+	}
+	if appConfig == nil {
+		return fmt.Errorf("not on a hotfix branch\n\nCreate one first:\n  forge hotfix create <tag>")
+	}
+	branch := appConfig.GetHotfixConfig().BranchPrefix + baseTag
+
+	if err = git.ValidateWorkingTreeClean(ctx, repoDir); err != nil {
+		return err
+	}
+
+	specs := cmd.Args().Slice()
+	if len(specs) == 0 || cmd.Bool("interactive") {
+		if len(specs) > 0 {
+			return fmt.Errorf("--interactive cannot be combined with commit arguments")
+		}
+		if !interactive.IsInteractive() || out.IsJSON() {
+			return fmt.Errorf(
+				"commit argument required\n\nUsage: forge hotfix pick <commit|range>...\n\nExample:\n  forge hotfix pick 3f2a1bc",
+			)
+		}
+		specs, err = selectFixCommits(ctx, repoDir, appConfig, baseTag)
+		if err != nil {
+			return err
+		}
+		if len(specs) == 0 {
+			logger.Warnf("No commits selected")
+			return nil
+		}
+	}
+
+	commits, err := git.ResolveCommits(ctx, repoDir, specs)
+	if err != nil {
+		return err
+	}
+
+	result := HotfixPickOutput{Branch: branch, Picked: []string{}, Skipped: []string{}}
+
+	if dryRun {
+		for _, commit := range commits {
+			applied, appliedErr := git.IsPatchApplied(ctx, repoDir, commit)
+			if appliedErr != nil {
+				return appliedErr
+			}
+			if applied {
+				result.Skipped = append(result.Skipped, commit)
+			} else {
+				result.Picked = append(result.Picked, commit)
+			}
+			if !out.IsJSON() {
+				note := ""
+				if applied {
+					note = " (already on branch, skipped)"
+				}
+				logger.Printf("Would pick %s%s", commitSummary(ctx, repoDir, commit), note)
+			}
+		}
+		result.Message = fmt.Sprintf("Would pick %d commits onto %s (%d already present)",
+			len(result.Picked), branch, len(result.Skipped))
+		return out.Print(result)
+	}
+
+	head, err := git.NewTagger(repoDir, appConfig.Prefix, false).CurrentCommit(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := &git.PickState{Branch: branch, OrigHead: head, Pending: commits}
+	return finishPick(ctx, repoDir, state, git.Pick(ctx, repoDir, state))
+}
+
+func hotfixPickResumeAction(ctx context.Context, cmd *cli.Command) error {
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := git.ResumePick(ctx, repoDir)
+	if state == nil {
+		return err
+	}
+	return finishPick(ctx, repoDir, state, err)
+}
+
+func hotfixPickAbortAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := git.AbortPick(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	logger.Success("✓ Reset %s to %s", state.Branch, shortCommit(state.OrigHead))
+	return out.Print(HotfixPickOutput{
+		Branch:  state.Branch,
+		Picked:  []string{},
+		Skipped: []string{},
+		Message: fmt.Sprintf("Aborted hotfix pick on %s", state.Branch),
+	})
+}
+
+// finishPick reports the outcome of a pick, turning a conflict into instructions.
+func finishPick(ctx context.Context, repoDir string, state *git.PickState, err error) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+
+	if !out.IsJSON() {
+		for _, commit := range state.Picked {
+			logger.Success("✓ Picked %s", commitSummary(ctx, repoDir, commit))
+		}
+	}
+
+	if errors.Is(err, git.ErrPickConflict) {
+		return &ForgeError{
+			Title: fmt.Sprintf("Cherry-pick of %s stopped on conflicts", commitSummary(ctx, repoDir, state.Current)),
+			Description: fmt.Sprintf("%d commits picked, %d skipped, %d still pending.",
+				len(state.Picked), len(state.Skipped), len(state.Pending)),
+			Suggestions: []string{
+				"Resolve the conflicts, stage them with 'git add', then run 'forge hotfix pick resume'",
+				"Roll back the whole pick with 'forge hotfix pick abort'",
+			},
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	result := HotfixPickOutput{
+		Branch:  state.Branch,
+		Picked:  state.Picked,
+		Skipped: state.Skipped,
+		Message: fmt.Sprintf("Picked %d commits onto %s (%d already present)",
+			len(state.Picked), state.Branch, len(state.Skipped)),
+	}
+	if result.Picked == nil {
+		result.Picked = []string{}
+	}
+	if result.Skipped == nil {
+		result.Skipped = []string{}
+	}
+	return out.Print(result)
+}
+
+// selectFixCommits lets the user choose among the fix: commits on the default branch
+// since the base tag that are not on the hotfix branch yet. Returns the selected
+// hashes, oldest first.
+func selectFixCommits(
+	ctx context.Context,
+	repoDir string,
+	appConfig *config.AppConfig,
+	baseTag string,
+) ([]string, error) {
+	parser := changelog.NewParser(repoDir, appConfig.Prefix)
+
+	var candidates []changelog.Commit
+	for commit, err := range parser.Commits(ctx, baseTag, appConfig.DefaultBranch) {
+		if err != nil {
+			return nil, fmt.Errorf("list commits on %s: %w", appConfig.DefaultBranch, err)
+		}
+		if commit.Type != changelog.TypeFix {
+			continue
+		}
+		applied, err := git.IsPatchApplied(ctx, repoDir, commit.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if !applied {
+			candidates = append(candidates, commit)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, &ForgeError{
+			Title: "Nothing to pick",
+			Description: fmt.Sprintf("All fix: commits on %s since %s are already on this branch.",
+				appConfig.DefaultBranch, baseTag),
+			Suggestions: []string{"Pass commits explicitly: forge hotfix pick <commit|range>..."},
+		}
+	}
+
+	// git log lists newest first; offer and apply them in commit order
+	slices.Reverse(candidates)
+
+	choices := make([]interactive.MultiSelectChoice, 0, len(candidates))
+	for _, c := range candidates {
+		choices = append(choices, interactive.MultiSelectChoice{
+			Label:       c.ShortHash + " " + c.Subject,
+			Description: c.Author,
+		})
+	}
+
+	title := fmt.Sprintf("Select fixes from %s to pick onto %s", appConfig.DefaultBranch, baseTag)
+	selected, err := interactive.PromptMultiSelect(title, choices)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(selected))
+	for _, i := range selected {
+		hashes = append(hashes, candidates[i].Hash)
+	}
+	return hashes, nil
+}
+
+// commitSummary returns the short hash and subject of a commit, e.g. "3f2a1bc fix: crash".
+func commitSummary(ctx context.Context, repoDir, commit string) string {
+	for entry, err := range git.Open(ctx, repoDir).Log(ctx, git.LogOptions{Range: commit}) {
+		if err != nil {
+			break
+		}
+		return entry.ShortHash + " " + entry.Subject
+	}
+	return shortCommit(commit)
+}
+
+// shortCommit abbreviates a commit hash for messages.
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// hotfixSync returns the hotfix sync command.
+func hotfixSync() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "Find hotfix commits missing from the default branch",
+		Description: `Compare each active hotfix branch with the default branch and list the
+commits whose changes never made it back (by patch-id, or -x provenance).
+
+Exits with status 1 if anything is missing, so it can guard CI pipelines.
+With --port, the missing commits are cherry-picked onto a new branch from the
+default branch, ready for a pull request.
+
+Examples:
+  forge hotfix sync
+  forge hotfix sync --branch release/v1.5.0 --port`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "branch",
+				Aliases: []string{"b"},
+				Usage:   "Only check this hotfix branch",
+			},
+			&cli.BoolFlag{
+				Name:  "port",
+				Usage: "Cherry-pick the missing commits onto forward-port/<hotfix-branch> from the default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hotfixSyncAction,
+	}
+}
+
+// HotfixSyncOutput represents the output of hotfix sync command.
+type HotfixSyncOutput struct {
+	Branches []HotfixSyncBranch `json:"branches"`
+	Missing  int                `json:"missing"`
+	Ported   string             `json:"ported_branch,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// HotfixSyncBranch lists the commits of a hotfix branch missing from the default branch.
+type HotfixSyncBranch struct {
+	Branch        string          `json:"branch"`
+	BaseTag       string          `json:"base_tag"`
+	DefaultBranch string          `json:"default_branch"`
+	Missing       []MissingCommit `json:"missing"`
+}
+
+// MissingCommit is a hotfix commit that has not been forward-ported.
+type MissingCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+}
+
+//nolint:gocognit,funlen // reporting and porting belong to one workflow
+func hotfixSyncAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	dryRun := cmd.Bool("dry-run")
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	result, err := hotfixSyncStatus(ctx, repoDir, cfg, cmd.String("branch"))
+	if err != nil {
+		return err
+	}
+
+	if !out.IsJSON() {
+		for _, b := range result.Branches {
+			if len(b.Missing) == 0 {
+				logger.Success("✓ %s: all fixes are on %s", b.Branch, b.DefaultBranch)
+				continue
+			}
+			logger.Warnf("%s: %d commits missing from %s", b.Branch, len(b.Missing), b.DefaultBranch)
+			for _, c := range b.Missing {
+				logger.Printf("  %s %s", shortCommit(c.Hash), c.Subject)
+			}
+		}
+	}
+
+	if result.Missing == 0 {
+		result.Message = "All hotfix commits are on the default branch"
+		return out.Print(result)
+	}
+	result.Message = fmt.Sprintf("%d hotfix commits missing from the default branch", result.Missing)
+
+	if !cmd.Bool("port") {
+		if err = out.Print(result); err != nil {
+			return err
+		}
+		return cli.Exit("", 1)
+	}
+
+	// Forward-port the missing commits of a single branch
+	var port *HotfixSyncBranch
+	for i := range result.Branches {
+		if len(result.Branches[i].Missing) == 0 {
+			continue
+		}
+		if port != nil {
+			return &ForgeError{
+				Title:       "Several hotfix branches need porting",
+				Description: "--port forward-ports one hotfix branch at a time.",
+				Suggestions: []string{"Pick one with --branch, e.g. forge hotfix sync --port --branch " + port.Branch},
+			}
+		}
+		port = &result.Branches[i]
+	}
+
+	portBranch := "forward-port/" + port.Branch
+	result.Ported = portBranch
+
+	if dryRun {
+		result.Message = fmt.Sprintf("Would cherry-pick %d commits onto %s from %s",
+			len(port.Missing), portBranch, port.DefaultBranch)
+		if !out.IsJSON() {
+			logger.Println(result.Message)
+		}
+		return out.Print(result)
+	}
+
+	if err = git.ValidateWorkingTreeClean(ctx, repoDir); err != nil {
+		return err
+	}
+
+	repo := git.Open(ctx, repoDir)
+	if err = repo.CreateBranch(ctx, portBranch, port.DefaultBranch); err != nil {
+		return fmt.Errorf("create forward-port branch: %w", err)
+	}
+	if err = repo.Checkout(ctx, portBranch); err != nil {
+		return fmt.Errorf("create forward-port branch: %w", err)
+	}
+	logger.Success("✓ Created and checked out %s from %s", portBranch, port.DefaultBranch)
+
+	head, err := git.NewTagger(repoDir, "", false).CurrentCommit(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := &git.PickState{Branch: portBranch, OrigHead: head}
+	for _, c := range port.Missing {
+		state.Pending = append(state.Pending, c.Hash)
+	}
+	return finishPick(ctx, repoDir, state, git.Pick(ctx, repoDir, state))
+}
+
+// hotfixSyncStatus compares every local hotfix branch, or only the named one, with the
+// default branch of its app.
+func hotfixSyncStatus(ctx context.Context, repoDir string, cfg *config.Config, only string) (HotfixSyncOutput, error) {
+	result := HotfixSyncOutput{Branches: []HotfixSyncBranch{}}
+
+	branches, err := listHotfixBranches(ctx, repoDir, cfg)
+	if err != nil {
+		return result, err
+	}
+
+	for _, b := range branches {
+		if only != "" && b.name != only {
+			continue
+		}
+
+		missing, err := git.MissingCommits(ctx, repoDir, b.app.DefaultBranch, b.name, b.baseTag)
+		if err != nil {
+			return result, err
+		}
+
+		status := HotfixSyncBranch{
+			Branch:        b.name,
+			BaseTag:       b.baseTag,
+			DefaultBranch: b.app.DefaultBranch,
+			Missing:       []MissingCommit{},
+		}
+		for _, hash := range missing {
+			summary := commitSummary(ctx, repoDir, hash)
+			_, subject, _ := strings.Cut(summary, " ")
+			status.Missing = append(status.Missing, MissingCommit{Hash: hash, Subject: subject})
+		}
+		result.Branches = append(result.Branches, status)
+		result.Missing += len(missing)
+	}
+
+	if only != "" && len(result.Branches) == 0 {
+		return result, fmt.Errorf("%q is not a hotfix branch", only)
+	}
+	return result, nil
+}
+
+// hotfixFinish returns the hotfix finish command.
+func hotfixFinish() *cli.Command {
+	return &cli.Command{
+		Name:  "finish",
+		Usage: "Close out a hotfix line and delete its branch",
+		Description: `Close out a hotfix branch: optionally bring its fixes to the default branch
+and promote the last hotfix to a stable patch version, then delete the branch
+locally and on origin.
+
+The branch is only deleted once all of its fixes are on the default branch
+(see 'forge hotfix sync'), unless --force is given. With hotfix.archive
+enabled, the branch is kept as a tag such as archive/release/v1.5.0.
+
+Examples:
+  forge hotfix finish --merge --tag --push
+  forge hotfix finish --branch release/v1.5.0 --cherry-pick
+  forge hotfix finish --force --archive`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "branch",
+				Aliases: []string{"b"},
+				Usage:   "Hotfix branch to finish (default: current branch)",
+			},
+			&cli.BoolFlag{
+				Name:  "merge",
+				Usage: "Merge the hotfix branch into the default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "cherry-pick",
+				Usage: "Cherry-pick the fixes missing from the default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "tag",
+				Usage: "Tag the last -hotfix.N as the next stable patch version",
+			},
+			&cli.BoolFlag{
+				Name:  "archive",
+				Usage: "Keep the branch as an archive tag (default from hotfix.archive)",
+			},
+			&cli.BoolFlag{
+				Name:  "push",
+				Usage: "Push the default branch and new tags to origin",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Delete the branch even if fixes are missing from the default branch",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hotfixFinishAction,
+	}
+}
+
+// HotfixFinishOutput represents the output of hotfix finish command.
+type HotfixFinishOutput struct {
+	Branch        string   `json:"branch"`
+	BaseTag       string   `json:"base_tag"`
+	DefaultBranch string   `json:"default_branch"`
+	Integration   string   `json:"integration,omitempty"`
+	Picked        []string `json:"picked,omitempty"`
+	StableTag     string   `json:"stable_tag,omitempty"`
+	ArchiveTag    string   `json:"archive_tag,omitempty"`
+	DeletedLocal  bool     `json:"deleted_local"`
+	DeletedRemote bool     `json:"deleted_remote"`
+	Pushed        bool     `json:"pushed"`
+	DryRun        bool     `json:"dry_run,omitempty"`
+	Message       string   `json:"message"`
+}
+
+//nolint:gocognit,gocyclo,funlen // tagging, integrating, archiving and deleting are ordered steps of one workflow
+func hotfixFinishAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	dryRun := cmd.Bool("dry-run")
+
+	// say prints a progress line in text mode: "Would ..." in dry-run, a success otherwise
+	say := func(done, planned string, args ...any) {
+		if out.IsJSON() {
+			return
+		}
+		if dryRun {
+			logger.Printf(planned, args...)
+		} else {
+			logger.Success("✓ "+done, args...)
+		}
+	}
+
+	if cmd.Bool("merge") && cmd.Bool("cherry-pick") {
+		return fmt.Errorf("--merge and --cherry-pick cannot be combined")
+	}
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	if state, stateErr := git.LoadPickState(ctx, repoDir); stateErr != nil {
+		return stateErr
+	} else if state != nil {
+		return &ForgeError{
+			Title:       "A hotfix pick is still in progress",
+			Description: fmt.Sprintf("Cherry-picking onto %s stopped on conflicts.", state.Branch),
+			Suggestions: []string{
+				"Resolve and stage the conflicts, then run 'forge hotfix pick resume'",
+				"Roll back with 'forge hotfix pick abort'",
+			},
+		}
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	currentBranch, err := git.GetCurrentBranch(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	branch := cmd.String("branch")
+	if branch == "" {
+		branch = currentBranch
+	}
+	appConfig, baseTag, err := hotfixLineOf(ctx, repoDir, cfg, branch)
+	if err != nil {
+		return err
+	}
+	hotfixCfg := appConfig.GetHotfixConfig()
+
+	if err = git.ValidateWorkingTreeClean(ctx, repoDir); err != nil {
+		return err
+	}
+
+	archive := hotfixCfg.Archive
+	if cmd.IsSet("archive") {
+		archive = cmd.Bool("archive")
+	}
+	push := cmd.Bool("push")
+	tagger := git.NewTagger(repoDir, appConfig.Prefix, dryRun)
+
+	result := HotfixFinishOutput{
+		Branch:        branch,
+		BaseTag:       baseTag,
+		DefaultBranch: appConfig.DefaultBranch,
+		DryRun:        dryRun,
+	}
+	var newTags []string
+
+	// Check everything that can stop the finish before creating tags or switching branches
+	var stable, last string
+	if cmd.Bool("tag") {
+		if stable, last, err = stableHotfixTag(ctx, tagger, appConfig, baseTag); err != nil {
+			return err
+		}
+	}
+
+	if archive {
+		result.ArchiveTag = hotfixCfg.ArchivePrefix + branch
+		exists, existsErr := tagger.TagExists(ctx, result.ArchiveTag)
+		if existsErr != nil {
+			return existsErr
+		}
+		if exists {
+			return &ForgeError{
+				Title:       fmt.Sprintf("Archive tag %s already exists", result.ArchiveTag),
+				Description: "The hotfix branch has not been deleted.",
+				Suggestions: []string{
+					"Delete the old archive with 'git tag -d " + result.ArchiveTag + "'",
+					"Finish without archiving with --archive=false",
+				},
+			}
+		}
+	}
+
+	missing, err := git.MissingCommits(ctx, repoDir, appConfig.DefaultBranch, branch, baseTag)
+	if err != nil {
+		return err
+	}
+	integrate := cmd.Bool("merge") || cmd.Bool("cherry-pick")
+	if len(missing) > 0 && !integrate && !cmd.Bool("force") {
+		return &ForgeError{
+			Title: fmt.Sprintf("%d commits of %s are missing from %s", len(missing), branch, appConfig.DefaultBranch),
+			Description: "Deleting the branch would lose them; " +
+				"'forge hotfix sync' lists them.",
+			Suggestions: []string{
+				"Bring them over with --merge or --cherry-pick",
+				"Delete the branch anyway with --force",
+			},
+		}
+	}
+
+	// Promote the last -hotfix.N to a stable patch version
+	if stable != "" {
+		message := fmt.Sprintf("Release %s\n\nPromoted from %s", stable, last)
+		if err = tagger.CreateHotfixTag(ctx, stable, baseTag, last+"^{commit}", message); err != nil {
+			return fmt.Errorf("create stable tag: %w", err)
+		}
+		say("Tagged %s as %s", "Would tag %s as %s", last, stable)
+		result.StableTag = stable
+		newTags = append(newTags, stable)
+	}
+
+	// Bring the fixes to the default branch
+	switch {
+	case cmd.Bool("merge"):
+		result.Integration = "merge"
+		if !dryRun {
+			if err = git.Open(ctx, repoDir).Checkout(ctx, appConfig.DefaultBranch); err != nil {
+				return err
+			}
+			currentBranch = appConfig.DefaultBranch
+			mergeErr := git.MergeBranch(ctx, repoDir, branch, fmt.Sprintf("Merge hotfix branch '%s'", branch))
+			if errors.Is(mergeErr, git.ErrMergeConflict) {
+				return &ForgeError{
+					Title:       fmt.Sprintf("Merge of %s into %s stopped on conflicts", branch, appConfig.DefaultBranch),
+					Description: "The hotfix branch has not been deleted.",
+					Suggestions: []string{
+						"Resolve the conflicts and commit the merge, then run 'forge hotfix finish --branch " + branch + "'",
+						"Give up on the merge with 'git merge --abort'",
+					},
+				}
+			}
+			if mergeErr != nil {
+				return mergeErr
+			}
+		}
+		say("Merged %s into %s", "Would merge %s into %s", branch, appConfig.DefaultBranch)
+
+	case cmd.Bool("cherry-pick"):
+		result.Integration = "cherry-pick"
+		result.Picked = missing
+		if !dryRun && len(missing) > 0 {
+			if err = git.Open(ctx, repoDir).Checkout(ctx, appConfig.DefaultBranch); err != nil {
+				return err
+			}
+			currentBranch = appConfig.DefaultBranch
+
+			head, headErr := tagger.CurrentCommit(ctx)
+			if headErr != nil {
+				return headErr
+			}
+			state := &git.PickState{Branch: appConfig.DefaultBranch, OrigHead: head, Pending: missing}
+			if pickErr := git.Pick(ctx, repoDir, state); errors.Is(pickErr, git.ErrPickConflict) {
+				return &ForgeError{
+					Title:       fmt.Sprintf("Cherry-pick of %s stopped on conflicts", commitSummary(ctx, repoDir, state.Current)),
+					Description: "The hotfix branch has not been deleted.",
+					Suggestions: []string{
+						"Resolve and stage the conflicts, run 'forge hotfix pick resume', " +
+							"then 'forge hotfix finish --branch " + branch + "'",
+						"Roll back with 'forge hotfix pick abort'",
+					},
+				}
+			} else if pickErr != nil {
+				return pickErr
+			}
+			result.Picked = state.Picked
+		}
+		for _, commit := range missing {
+			say("Picked %s", "Would pick %s", commitSummary(ctx, repoDir, commit))
+		}
+	}
+
+	// Keep the branch as a tag before deleting it
+	if archive {
+		message := fmt.Sprintf("Archive of hotfix branch %s", branch)
+		if err = tagger.CreateTagAt(ctx, result.ArchiveTag, branch, message); err != nil {
+			return fmt.Errorf("create archive tag: %w", err)
+		}
+		say("Archived %s as %s", "Would archive %s as %s", branch, result.ArchiveTag)
+		newTags = append(newTags, result.ArchiveTag)
+	}
+
+	remoteBranch, err := git.RemoteBranchExists(ctx, repoDir, "origin", branch)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		if currentBranch == branch {
+			if err = git.Open(ctx, repoDir).Checkout(ctx, appConfig.DefaultBranch); err != nil {
+				return err
+			}
+		}
+		if err = git.DeleteBranch(ctx, repoDir, branch); err != nil {
+			return err
+		}
+	}
+	result.DeletedLocal = true
+	say("Deleted branch %s", "Would delete branch %s", branch)
+
+	if remoteBranch {
+		// The archive tag must reach origin before the branch is gone there
+		if archive && !push && !dryRun {
+			if err = tagger.PushTag(ctx, result.ArchiveTag); err != nil {
+				return fmt.Errorf("push archive tag: %w", err)
+			}
+		}
+		if !dryRun {
+			if err = git.DeleteRemoteBranch(ctx, repoDir, "origin", branch); err != nil {
+				return err
+			}
+		}
+		result.DeletedRemote = true
+		say("Deleted branch %s on origin", "Would delete branch %s on origin", branch)
+	}
+
+	if push {
+		refs := newTags
+		if result.Integration != "" {
+			refs = append([]string{appConfig.DefaultBranch}, refs...)
+			if !dryRun {
+				if err = git.PushBranch(ctx, repoDir, "origin", appConfig.DefaultBranch); err != nil {
+					return err
+				}
+			}
+		}
+		for _, tag := range newTags {
+			if err = tagger.PushTag(ctx, tag); err != nil {
+				return fmt.Errorf("failed to push tag: %w", err)
+			}
+		}
+		result.Pushed = !dryRun && len(refs) > 0
+		if len(refs) > 0 {
+			say("Pushed %s to origin", "Would push %s to origin", strings.Join(refs, ", "))
+		}
+	}
+
+	result.Message = fmt.Sprintf("Finished hotfix branch %s", branch)
+	if dryRun {
+		result.Message = fmt.Sprintf("Would finish hotfix branch %s", branch)
+	}
+	return out.Print(result)
+}
+
+// hotfixLineOf returns the app and base tag of an existing hotfix branch.
+func hotfixLineOf(
+	ctx context.Context, repoDir string, cfg *config.Config, branch string,
+) (*config.AppConfig, string, error) {
+	branches, err := git.ListBranches(ctx, repoDir)
+	if err != nil {
+		return nil, "", err
+	}
+	if !slices.Contains(branches, branch) {
+		return nil, "", fmt.Errorf("branch %q does not exist", branch)
+	}
+
+	if b := hotfixBranchOf(cfg, branch); b != nil {
+		return b.app, b.baseTag, nil
+	}
+	return nil, "", fmt.Errorf("%q is not a hotfix branch\n\nFinish a branch like release/v1.5.0 or pass --branch", branch)
+}
+
+// stableHotfixTag returns the stable patch version for the last hotfix of the line and
+// that hotfix. The tag is empty if the last hotfix already has a release version.
+func stableHotfixTag(
+	ctx context.Context,
+	tagger *git.Tagger,
+	appConfig *config.AppConfig,
+	baseTag string,
+) (string, string, error) {
+	hotfixCfg := appConfig.GetHotfixConfig()
+
+	line, err := tagger.HotfixLine(ctx, baseTag, hotfixCfg.Suffix)
+	if err != nil {
+		return "", "", err
+	}
+	if len(line) == 0 {
+		return "", "", &ForgeError{
+			Title:       fmt.Sprintf("No hotfixes released for %s", baseTag),
+			Description: "--tag promotes the last hotfix tag of the line to a stable version.",
+			Suggestions: []string{"Create one with 'forge hotfix bump' first, or finish without --tag"},
+		}
+	}
+
+	last := line[len(line)-1]
+	if _, suffix, _, splitErr := git.SplitHotfixTag(last); splitErr != nil || suffix != hotfixCfg.Suffix {
+		log.FromContext(ctx).Warnf("%s is already a release version, not creating a stable tag", last)
+		return "", last, nil
+	}
+
+	naming := hotfixNaming(appConfig)
+	naming.Patch = true
+	stable, _, err := tagger.NextHotfix(ctx, baseTag, naming)
+	if err != nil {
+		return "", "", fmt.Errorf("get stable tag: %w", err)
+	}
+	return stable, last, nil
+}
+
+// hotfixReport returns the hotfix report command.
+func hotfixReport() *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Show an overview of all hotfix lines",
+		Description: `For each hotfix branch, show the base tag, the number of hotfixes and the
+date of the last one, the commits not released in a hotfix yet, how the branch
+compares with origin (as of the last fetch) and, with hotfix.support_window
+configured, whether the base version is still supported.
+
+Examples:
+  forge hotfix report
+  forge hotfix report --json`,
+		Action: hotfixReportAction,
+	}
+}
+
+// HotfixReportOutput represents the output of hotfix report command.
+type HotfixReportOutput struct {
+	Lines []HotfixLineReport `json:"lines"`
+}
+
+// HotfixLineReport describes the state of a hotfix line.
+type HotfixLineReport struct {
+	App            string `json:"app,omitempty"`
+	Branch         string `json:"branch"`
+	BaseTag        string `json:"base_tag"`
+	Hotfixes       int    `json:"hotfixes"`
+	LastHotfix     string `json:"last_hotfix,omitempty"`
+	LastHotfixDate string `json:"last_hotfix_date,omitempty"`
+	LastCommitDate string `json:"last_commit_date"`
+	Unreleased     int    `json:"unreleased"`
+	Remote         string `json:"remote"`
+	Supported      *bool  `json:"supported,omitempty"`
+
+	// lastActivity is the later of the last commit and the last hotfix
+	lastActivity time.Time
+}
+
+func hotfixReportAction(ctx context.Context, cmd *cli.Command) error {
+	out := output.FromContext(ctx)
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lines, err := hotfixLineReports(ctx, repoDir, cfg)
+	if err != nil {
+		return err
+	}
+
+	if out.IsJSON() {
+		return out.Print(HotfixReportOutput{Lines: lines})
+	}
+
+	if len(lines) == 0 {
+		log.FromContext(ctx).Println("No hotfix branches")
+		return nil
+	}
+
+	tbl := table.New([]table.Column{
+		{Header: "Branch", Align: table.AlignLeft},
+		{Header: "Base", Align: table.AlignLeft},
+		{Header: "Hotfixes", Align: table.AlignRight},
+		{Header: "Last Hotfix", Align: table.AlignLeft},
+		{Header: "Date", Width: 10, Align: table.AlignLeft},
+		{Header: "Unreleased", Align: table.AlignRight},
+		{Header: "Remote", Align: table.AlignLeft},
+		{Header: "Supported", Align: table.AlignLeft},
+	})
+	for _, l := range lines {
+		last, date := l.LastHotfix, l.LastHotfixDate
+		if last == "" {
+			last, date = "-", "-"
+		}
+		supported := "-"
+		if l.Supported != nil {
+			supported = map[bool]string{true: "yes", false: "no"}[*l.Supported]
+		}
+		tbl.AddRow(
+			l.Branch,
+			l.BaseTag,
+			strconv.Itoa(l.Hotfixes),
+			table.CurrentVersion(last),
+			table.Date(date),
+			strconv.Itoa(l.Unreleased),
+			l.Remote,
+			supported,
+		)
+	}
+	fmt.Fprintln(os.Stdout, tbl.Render())
+	return nil
+}
+
+// listHotfixBranches returns the local hotfix branches, sorted by name.
+func listHotfixBranches(ctx context.Context, repoDir string, cfg *config.Config) ([]hotfixBranch, error) {
+	branches, err := git.ListBranches(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []hotfixBranch
+	for _, branch := range branches {
+		if b := hotfixBranchOf(cfg, branch); b != nil {
+			result = append(result, *b)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b hotfixBranch) int { return strings.Compare(a.name, b.name) })
+	return result, nil
+}
+
+// hotfixLineReports builds the report of every local hotfix branch.
+func hotfixLineReports(ctx context.Context, repoDir string, cfg *config.Config) ([]HotfixLineReport, error) {
+	branches, err := listHotfixBranches(ctx, repoDir, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lines := []HotfixLineReport{}
+	for _, b := range branches {
+		report, err := hotfixLineReport(ctx, repoDir, b, now)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.name, err)
+		}
+		lines = append(lines, report)
+	}
+	return lines, nil
+}
+
+// hotfixLineReport describes a single hotfix branch.
+func hotfixLineReport(ctx context.Context, repoDir string, b hotfixBranch, now time.Time) (HotfixLineReport, error) {
+	hotfixCfg := b.app.GetHotfixConfig()
+	tagger := git.NewTagger(repoDir, b.app.Prefix, false).
+		WithScheme(version.Scheme(b.app.Scheme), b.app.CalVerFormat)
+
+	report := HotfixLineReport{App: b.appName, Branch: b.name, BaseTag: b.baseTag}
+
+	line, err := tagger.HotfixLine(ctx, b.baseTag, hotfixCfg.Suffix)
+	if err != nil {
+		return report, err
+	}
+	report.Hotfixes = len(line)
+
+	released := b.baseTag
+	if len(line) > 0 {
+		released = line[len(line)-1]
+		report.LastHotfix = released
+
+		date, dateErr := git.RefDate(ctx, repoDir, released)
+		if dateErr != nil {
+			return report, dateErr
+		}
+		report.LastHotfixDate = date.Format(time.DateOnly)
+		report.lastActivity = date
+	}
+
+	if report.Unreleased, err = git.CountCommits(ctx, repoDir, released+".."+b.name); err != nil {
+		return report, err
+	}
+
+	tip, err := git.RefDate(ctx, repoDir, b.name)
+	if err != nil {
+		return report, err
+	}
+	report.LastCommitDate = tip.Format(time.DateOnly)
+	if tip.After(report.lastActivity) {
+		report.lastActivity = tip
+	}
+
+	ahead, behind, tracked, err := git.RemoteTracking(ctx, repoDir, "origin", b.name)
+	if err != nil {
+		return report, err
+	}
+	switch {
+	case !tracked:
+		report.Remote = "local only"
+	case ahead == 0 && behind == 0:
+		report.Remote = "in sync"
+	case behind == 0:
+		report.Remote = fmt.Sprintf("ahead %d", ahead)
+	case ahead == 0:
+		report.Remote = fmt.Sprintf("behind %d", behind)
+	default:
+		report.Remote = fmt.Sprintf("ahead %d, behind %d", ahead, behind)
+	}
+
+	if hotfixCfg.SupportWindow != "" {
+		supported, supportErr := isSupportedBase(ctx, repoDir, tagger, b, hotfixCfg.SupportWindow, now)
+		if supportErr != nil {
+			return report, supportErr
+		}
+		report.Supported = &supported
+	}
+	return report, nil
+}
+
+// isSupportedBase reports whether the base of a hotfix line is still supported: it is
+// the latest release, or it was released within the support window.
+func isSupportedBase(
+	ctx context.Context,
+	repoDir string,
+	tagger *git.Tagger,
+	b hotfixBranch,
+	supportWindow string,
+	now time.Time,
+) (bool, error) {
+	window, err := config.ParseDuration(supportWindow)
+	if err != nil {
+		return false, err
+	}
+
+	var latest string
+	if b.app.Scheme == string(version.SchemeCalVer) {
+		latest, _, err = tagger.LatestCalVerTag(ctx, b.app.CalVerFormat)
+	} else {
+		latest, err = tagger.LatestStableTag(ctx)
+	}
+	if err != nil {
+		return false, err
+	}
+	if latest == b.baseTag {
+		return true, nil
+	}
+
+	released, err := git.RefDate(ctx, repoDir, b.baseTag)
+	if err != nil {
+		return false, err
+	}
+	return now.Sub(released) <= window, nil
+}
+
+// hotfixPrune returns the hotfix prune command.
+func hotfixPrune() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "Delete fully released, inactive hotfix branches",
+		Description: `Delete the hotfix branches whose commits are all released in a hotfix tag and
+that saw no commit or hotfix within --older-than, locally and on origin.
+The commits stay reachable from the hotfix tags.
+
+Examples:
+  forge hotfix prune --older-than 90d
+  forge hotfix prune --older-than 12w --yes`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Minimum time since the last commit or hotfix, e.g. 90d, 12w",
+				Value: "90d",
+			},
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Delete without asking for confirmation",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show what would happen without making changes",
+			},
+		},
+		Action: hotfixPruneAction,
+	}
+}
+
+// HotfixPruneOutput represents the output of hotfix prune command.
+type HotfixPruneOutput struct {
+	Pruned        []string `json:"pruned"`
+	DeletedRemote []string `json:"deleted_remote"`
+	Kept          []string `json:"kept"`
+	DryRun        bool     `json:"dry_run,omitempty"`
+	Message       string   `json:"message"`
+}
+
+//nolint:gocognit,funlen // selecting, confirming and deleting are sequential steps of one workflow
+func hotfixPruneAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	dryRun := cmd.Bool("dry-run")
+
+	olderThan, err := config.ParseDuration(cmd.String("older-than"))
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	lines, err := hotfixLineReports(ctx, repoDir, cfg)
+	if err != nil {
+		return err
+	}
+
+	result := HotfixPruneOutput{Pruned: []string{}, DeletedRemote: []string{}, Kept: []string{}, DryRun: dryRun}
+	cutoff := time.Now().Add(-olderThan)
+	var prune []HotfixLineReport
+	for _, l := range lines {
+		if l.Unreleased == 0 && l.lastActivity.Before(cutoff) {
+			prune = append(prune, l)
+		} else {
+			result.Kept = append(result.Kept, l.Branch)
+		}
+	}
+
+	if len(prune) == 0 {
+		result.Message = fmt.Sprintf("No fully released hotfix branches inactive for %s", cmd.String("older-than"))
+		if !out.IsJSON() {
+			logger.Println(result.Message)
+		}
+		return out.Print(result)
+	}
+
+	summary := make([]string, 0, len(prune))
+	for _, l := range prune {
+		last := l.LastHotfix
+		if last == "" {
+			last = "no hotfixes"
+		}
+		summary = append(summary, fmt.Sprintf("%s (%s, last activity %s)",
+			l.Branch, last, l.lastActivity.Format(time.DateOnly)))
+	}
+
+	if !dryRun && !cmd.Bool("yes") {
+		if out.IsJSON() || !interactive.IsInteractive() {
+			return &ForgeError{
+				Title:       fmt.Sprintf("Pruning %d hotfix branches needs confirmation", len(prune)),
+				Description: strings.Join(summary, "\n"),
+				Suggestions: []string{"Confirm with --yes", "Preview with --dry-run"},
+			}
+		}
+		confirmed, promptErr := interactive.PromptConfirmation(
+			fmt.Sprintf("Delete %d hotfix branches locally and on origin?", len(prune)),
+			strings.Join(summary, "\n"),
+		)
+		if promptErr != nil {
+			return promptErr
+		}
+		if !confirmed {
+			logger.Warnf("Nothing deleted")
+			return nil
+		}
+	}
+
+	currentBranch, err := git.GetCurrentBranch(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	for i, l := range prune {
+		remote, remoteErr := git.RemoteBranchExists(ctx, repoDir, "origin", l.Branch)
+		if remoteErr != nil {
+			return remoteErr
+		}
+
+		if dryRun {
+			if !out.IsJSON() {
+				logger.Printf("Would delete %s", summary[i])
+			}
+		} else {
+			if l.Branch == currentBranch {
+				if err = git.ValidateWorkingTreeClean(ctx, repoDir); err != nil {
+					return err
+				}
+				appConfig, _, lineErr := hotfixLineOf(ctx, repoDir, cfg, l.Branch)
+				if lineErr != nil {
+					return lineErr
+				}
+				if err = git.Open(ctx, repoDir).Checkout(ctx, appConfig.DefaultBranch); err != nil {
+					return err
+				}
+			}
+			if err = git.DeleteBranch(ctx, repoDir, l.Branch); err != nil {
+				return err
+			}
+			if remote {
+				if err = git.DeleteRemoteBranch(ctx, repoDir, "origin", l.Branch); err != nil {
+					return err
+				}
+			}
+			if !out.IsJSON() {
+				logger.Success("✓ Deleted %s", summary[i])
+			}
+		}
+
+		result.Pruned = append(result.Pruned, l.Branch)
+		if remote {
+			result.DeletedRemote = append(result.DeletedRemote, l.Branch)
+		}
+	}
+
+	result.Message = fmt.Sprintf("Pruned %d hotfix branches", len(result.Pruned))
+	if dryRun {
+		result.Message = fmt.Sprintf("Would prune %d hotfix branches", len(result.Pruned))
+	}
+	return out.Print(result)
+}
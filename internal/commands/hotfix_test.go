@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/git/gittest"
+	"github.com/alexjoedt/forge/internal/run"
+	"github.com/urfave/cli/v3"
+)
+
+// initHotfixRepo creates a repository with api/v1.0.0 and web/v1.0.0 tagged on main and
+// a hotfix branch for each, sharing the default release/ branch prefix. Only the api
+// branch has a fix that is missing from main.
+func initHotfixRepo(t *testing.T) (string, *config.Config) {
+	t.Helper()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "feat: initial"},
+		{"tag", "api/v1.0.0"},
+		{"tag", "web/v1.0.0"},
+		{"branch", "release/web/v1.0.0"},
+		{"checkout", "-b", "release/api/v1.0.0"},
+		{"commit", "--allow-empty", "-m", "fix: api hotfix"},
+		{"checkout", "main"},
+	} {
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+
+	cfg := &config.Config{Apps: map[string]config.AppConfig{
+		"api": {Scheme: "semver", Prefix: "api/v", DefaultBranch: "main"},
+		"web": {Scheme: "semver", Prefix: "web/v", DefaultBranch: "main"},
+	}}
+	return dir, cfg
+}
+
+func TestHotfixSyncStatusMultiApp(t *testing.T) {
+	dir, cfg := initHotfixRepo(t)
+
+	tests := []struct {
+		name        string
+		only        string
+		wantBranch  []string
+		wantMissing int
+	}{
+		{name: "all branches", wantBranch: []string{"release/api/v1.0.0", "release/web/v1.0.0"}, wantMissing: 1},
+		{name: "one branch", only: "release/api/v1.0.0", wantBranch: []string{"release/api/v1.0.0"}, wantMissing: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := hotfixSyncStatus(t.Context(), dir, cfg, tt.only)
+			if err != nil {
+				t.Fatalf("hotfixSyncStatus() error = %v", err)
+			}
+
+			var branches []string
+			for _, b := range result.Branches {
+				branches = append(branches, b.Branch)
+			}
+			if !slices.Equal(branches, tt.wantBranch) {
+				t.Errorf("branches = %v, want %v", branches, tt.wantBranch)
+			}
+			if result.Missing != tt.wantMissing {
+				t.Errorf("missing = %d, want %d", result.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestHotfixSyncStatusPicked(t *testing.T) {
+	repo := gittest.New()
+	base := repo.AddCommit("feat: initial", "main.go")
+	if err := repo.AddTag("v1.0.0", base, "forge: release v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := git.WithRepository(t.Context(), repo)
+	if err := repo.CreateBranch(ctx, "release/v1.0.0", base); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Checkout(ctx, "release/v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	crash := repo.AddCommit("fix: crash", "crash.go")
+	leak := repo.AddCommit("fix: leak", "leak.go")
+
+	// Only the crash fix is brought over to main
+	if err := repo.Checkout(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CherryPick(ctx, crash); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Apps: map[string]config.AppConfig{
+		"app": {Scheme: "semver", Prefix: "v", DefaultBranch: "main"},
+	}}
+	result, err := hotfixSyncStatus(ctx, "", cfg, "")
+	if err != nil {
+		t.Fatalf("hotfixSyncStatus() error = %v", err)
+	}
+	if len(result.Branches) != 1 || result.Missing != 1 {
+		t.Fatalf("hotfixSyncStatus() = %+v, want one branch missing one fix", result)
+	}
+	if got := result.Branches[0].Missing; got[0].Hash != leak || got[0].Subject != "fix: leak" {
+		t.Errorf("missing = %+v, want %s fix: leak", got, leak)
+	}
+}
+
+func TestHotfixLineOfMultiApp(t *testing.T) {
+	dir, cfg := initHotfixRepo(t)
+
+	// Both apps share the release/ branch prefix; the tag prefix decides
+	for _, tt := range []struct {
+		branch     string
+		wantPrefix string
+		wantBase   string
+	}{
+		{branch: "release/api/v1.0.0", wantPrefix: "api/v", wantBase: "api/v1.0.0"},
+		{branch: "release/web/v1.0.0", wantPrefix: "web/v", wantBase: "web/v1.0.0"},
+	} {
+		t.Run(tt.branch, func(t *testing.T) {
+			app, baseTag, err := hotfixLineOf(t.Context(), dir, cfg, tt.branch)
+			if err != nil {
+				t.Fatalf("hotfixLineOf() error = %v", err)
+			}
+			if app.Prefix != tt.wantPrefix || baseTag != tt.wantBase {
+				t.Errorf("hotfixLineOf() = %s, %s, want %s, %s", app.Prefix, baseTag, tt.wantPrefix, tt.wantBase)
+			}
+		})
+	}
+
+	if _, _, err := hotfixLineOf(t.Context(), dir, cfg, "main"); err == nil {
+		t.Error("hotfixLineOf(main) succeeded, want an error")
+	}
+}
+
+func TestHotfixFinishLeavesNoTagOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		if r := run.CmdInDir(ctx, dir, "git", args...); !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+	}
+
+	forgeYAML := "scheme: semver\nprefix: v\ndefault_branch: main\n"
+	if err := os.WriteFile(filepath.Join(dir, "forge.yaml"), []byte(forgeYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	gitCmd("init", "-b", "main")
+	gitCmd("config", "user.email", "test@example.com")
+	gitCmd("config", "user.name", "Test User")
+	gitCmd("add", "forge.yaml")
+	gitCmd("commit", "-m", "feat: initial")
+	gitCmd("tag", "-a", "v1.0.0", "-m", "Release v1.0.0")
+	gitCmd("checkout", "-b", "release/v1.0.0")
+	gitCmd("commit", "--allow-empty", "-m", "fix: crash")
+	gitCmd("tag", "-a", "v1.0.0-hotfix.1", "-m", "Hotfix v1.0.0-hotfix.1\n\nHotfix-Base: v1.0.0")
+	gitCmd("checkout", "main")
+
+	finish := func(args ...string) error {
+		root := &cli.Command{
+			Name:     "forge",
+			Flags:    []cli.Flag{&cli.StringFlag{Name: "repo-dir", Value: "."}},
+			Commands: []*cli.Command{hotfixFinish()},
+		}
+		return root.Run(ctx, append([]string{"forge", "--repo-dir", dir, "finish"}, args...))
+	}
+	tagExists := func(tag string) bool {
+		return run.CmdInDir(ctx, dir, "git", "rev-parse", "--verify", "--quiet", "refs/tags/"+tag).Success()
+	}
+
+	// The fix is not on main, so finishing without --force fails before tagging
+	if err := finish("--branch", "release/v1.0.0", "--tag"); err == nil {
+		t.Fatal("finish succeeded with a fix missing from main")
+	}
+	if tagExists("v1.0.1") {
+		t.Error("failed finish left the stable tag v1.0.1 behind")
+	}
+	if r := run.CmdInDir(ctx, dir, "git", "branch", "--show-current"); strings.TrimSpace(r.Stdout) != "main" {
+		t.Errorf("failed finish checked out %q", strings.TrimSpace(r.Stdout))
+	}
+
+	if err := finish("--branch", "release/v1.0.0", "--tag", "--force"); err != nil {
+		t.Fatalf("finish --force error = %v", err)
+	}
+	if !tagExists("v1.0.1") {
+		t.Error("finish --force did not create the stable tag v1.0.1")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/alexjoedt/forge/internal/config"
 	"github.com/alexjoedt/forge/internal/initialize"
 	"github.com/alexjoedt/forge/internal/log"
 	"github.com/alexjoedt/forge/internal/output"
@@ -34,6 +35,15 @@ func Init() *cli.Command {
 				Name:  "multi",
 				Usage: "initialzises a configuration for multiple apps",
 			},
+			&cli.StringFlag{
+				Name:  "schema",
+				Usage: "JSON Schema URL or path for the yaml-language-server modeline",
+				Value: config.SchemaURL,
+			},
+			&cli.BoolFlag{
+				Name:  "no-schema",
+				Usage: "don't write the yaml-language-server modeline",
+			},
 		},
 		Action: initAction,
 	}
@@ -47,6 +57,10 @@ func initAction(ctx context.Context, cmd *cli.Command) error {
 	force := cmd.Bool("force")
 	dryRun := cmd.Bool("dry-run")
 	multi := cmd.Bool("multi")
+	schema := cmd.String("schema")
+	if cmd.Bool("no-schema") {
+		schema = ""
+	}
 
 	logger.Debugf("initializing forge configuration: %s", outputPath)
 
@@ -55,6 +69,7 @@ func initAction(ctx context.Context, cmd *cli.Command) error {
 		Force:      force,
 		DryRun:     dryRun,
 		Multi:      multi,
+		Schema:     schema,
 	}
 
 	if err := initialize.Init(ctx, opts); err != nil {
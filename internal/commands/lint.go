@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/output"
+	"github.com/alexjoedt/forge/internal/run"
+	"github.com/urfave/cli/v3"
+)
+
+// defaultLintRange is the revision range linted when none is given.
+const defaultLintRange = "@{upstream}..HEAD"
+
+// Lint returns the lint command group.
+func Lint() *cli.Command {
+	return &cli.Command{
+		Name:  "lint",
+		Usage: "Check commits against forge's conventions",
+		Commands: []*cli.Command{
+			lintCommits(),
+		},
+	}
+}
+
+// lintCommits returns the lint commits command.
+func lintCommits() *cli.Command {
+	return &cli.Command{
+		Name:      "commits",
+		Usage:     "Validate commit messages against the Conventional Commits rules",
+		ArgsUsage: "[<range>]",
+		Description: `Validate every non-merge commit in a revision range against the
+Conventional Commits format and the lint rules in forge.yaml (allowed types,
+allowed scopes, subject length and breaking change footers).
+
+The range defaults to the commits not yet pushed upstream (@{upstream}..HEAD).
+The command exits with a non-zero status if any commit violates a rule.
+
+Examples:
+  # Lint unpushed commits
+  forge lint commits
+
+  # Lint the commits of a pull request
+  forge lint commits origin/main..HEAD
+
+  # JSON report for PR bots
+  forge --json lint commits origin/main..HEAD`,
+		Flags: []cli.Flag{
+			appFlag,
+		},
+		Action: lintCommitsAction,
+	}
+}
+
+//nolint:funlen // linear flow: resolve rules, collect violations, report
+func lintCommitsAction(ctx context.Context, cmd *cli.Command) error {
+	logger := log.FromContext(ctx)
+	out := output.FromContext(ctx)
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateRequirements(ctx, repoDir); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadFromDir(repoDir)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	appConfig, err := cfg.GetAppConfig(selectApp(ctx, cmd, cfg, repoDir))
+	if err != nil {
+		return fmt.Errorf("get app config: %w", err)
+	}
+
+	lintCfg := appConfig.GetLintConfig()
+	rules := changelog.LintRules{
+		Types:            lintCfg.Types,
+		Scopes:           lintCfg.Scopes,
+		MaxSubjectLength: lintCfg.MaxSubjectLength,
+	}
+
+	revRange := cmd.Args().First()
+	if revRange == "" {
+		revRange = defaultLintRange
+		if result := run.CmdInDir(ctx, repoDir, "git", "rev-parse", "--abbrev-ref", "@{upstream}"); !result.Success() {
+			return &ForgeError{
+				Title:       "No upstream branch configured",
+				Description: "Without a range, forge lints the commits not yet pushed upstream (@{upstream}..HEAD).",
+				Suggestions: []string{
+					"Pass a range explicitly: forge lint commits origin/main..HEAD",
+					"Set an upstream branch: git branch --set-upstream-to origin/main",
+				},
+			}
+		}
+	}
+
+	from, to, found := strings.Cut(revRange, "..")
+	if !found {
+		from, to = "", revRange
+	}
+
+	result := output.LintResult{
+		Range:   revRange,
+		Commits: []output.LintCommitResult{},
+	}
+
+	parser := changelog.NewParser(repoDir, appConfig.Prefix)
+	for commit, err := range parser.Commits(ctx, from, to) {
+		if err != nil {
+			return fmt.Errorf("read commits: %w", err)
+		}
+		result.Checked++
+
+		violations := changelog.Lint(commit.Subject, commit.Body, rules)
+		if len(violations) == 0 {
+			continue
+		}
+
+		entry := output.LintCommitResult{
+			Hash:      commit.Hash,
+			ShortHash: commit.ShortHash,
+			Subject:   commit.Subject,
+		}
+		for _, v := range violations {
+			entry.Violations = append(entry.Violations, output.LintViolation{
+				Rule:       v.Rule,
+				Message:    v.Message,
+				Suggestion: v.Suggestion,
+			})
+		}
+		result.Commits = append(result.Commits, entry)
+	}
+
+	result.Failed = len(result.Commits)
+	result.Valid = result.Failed == 0
+
+	if out.IsJSON() {
+		if err := out.Print(result); err != nil {
+			return err
+		}
+		if !result.Valid {
+			// The report is the output; exit non-zero without an extra error message.
+			return cli.Exit("", 1)
+		}
+		return nil
+	}
+
+	if result.Valid {
+		logger.Success("✓ %d commit(s) in %s follow the Conventional Commits rules", result.Checked, revRange)
+		return nil
+	}
+
+	for _, c := range result.Commits {
+		logger.Errorf("✗ %s %s", c.ShortHash, c.Subject)
+		for _, v := range c.Violations {
+			logger.Errorf("    %s: %s", v.Rule, v.Message)
+			if v.Suggestion != "" {
+				logger.Errorf("      → %s", v.Suggestion)
+			}
+		}
+	}
+
+	return fmt.Errorf("%d of %d commit(s) violate the Conventional Commits rules", result.Failed, result.Checked)
+}
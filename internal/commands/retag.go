@@ -10,6 +10,7 @@ import (
 	"github.com/alexjoedt/forge/internal/interactive"
 	"github.com/alexjoedt/forge/internal/log"
 	"github.com/alexjoedt/forge/internal/output"
+	"github.com/alexjoedt/forge/internal/version"
 	"github.com/urfave/cli/v3"
 )
 
@@ -44,11 +45,6 @@ func Retag() *cli.Command {
 				Usage: "tag prefix override (e.g., v)",
 				Value: "",
 			},
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			appFlag,
 		},
 		Action: retagAction,
@@ -81,9 +77,11 @@ func retagAction(ctx context.Context, cmd *cli.Command) error {
 	yes := cmd.Bool("yes")
 	push := cmd.Bool("push")
 	dryRun := cmd.Bool("dry-run")
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 	message := cmd.String("message")
-	appName := cmd.String("app")
 	prefix := cmd.String("prefix")
 
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
@@ -95,7 +93,7 @@ func retagAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	appConfig, err := cfg.GetAppConfig(appName)
+	appConfig, err := cfg.GetAppConfig(selectApp(ctx, cmd, cfg, repoDir))
 	if err != nil {
 		return fmt.Errorf("get app config: %w", err)
 	}
@@ -104,7 +102,8 @@ func retagAction(ctx context.Context, cmd *cli.Command) error {
 		prefix = appConfig.Prefix
 	}
 
-	tagger := git.NewTagger(repoDir, prefix, dryRun)
+	tagger := git.NewTagger(repoDir, prefix, dryRun).
+		WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat)
 
 	exists, err := tagger.TagExists(ctx, tag)
 	if err != nil {
@@ -5,13 +5,11 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/alexjoedt/forge/internal/config"
-	"github.com/alexjoedt/forge/internal/git"
 	"github.com/alexjoedt/forge/internal/interactive"
 	"github.com/alexjoedt/forge/internal/log"
-	"github.com/alexjoedt/forge/internal/nodejs"
 	"github.com/alexjoedt/forge/internal/output"
 	"github.com/alexjoedt/forge/internal/version"
+	"github.com/alexjoedt/forge/pkg/forge"
 	"github.com/urfave/cli/v3"
 )
 
@@ -66,11 +64,6 @@ func Bump() *cli.Command {
 				Usage: "tag prefix (e.g., v)",
 				Value: "",
 			},
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			&cli.BoolFlag{
 				Name:  "push",
 				Usage: "push the tag to remote",
@@ -92,301 +85,173 @@ func Bump() *cli.Command {
 	}
 }
 
-//nolint:gocognit
 func tagAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
 	out := output.FromContext(ctx)
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 	dryRun := cmd.Bool("dry-run")
-	initialVersion := cmd.String("initial")
-	force := cmd.Bool("force")
 
 	// Validate requirements
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
 		return err
 	}
 
-	// Check for clean git state (unless --force or --dry-run)
-	if !dryRun && !force {
-		if err := CheckGitClean(ctx, repoDir, force); err != nil {
-			return err
-		}
-	}
-
-	// Load config
-	cfg, err := config.LoadFromDir(repoDir)
+	repo, err := openRepo(ctx, cmd, repoDir, dryRun)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-
-	appName := cmd.String("app")
-	appConfig, err := cfg.GetAppConfig(appName)
-	if err != nil {
-		return fmt.Errorf("get app config: %w", err)
-	}
-
-	// Override config with flags
-	scheme := cmd.String("scheme")
-	if scheme == "" {
-		scheme = appConfig.Scheme
+		return err
 	}
 
-	// --prefix CLI flag overrides config consistently for both tag discovery and creation.
-	prefix := cmd.String("prefix")
-	if prefix == "" {
-		prefix = appConfig.Prefix
+	req := forge.ReleaseRequest{
+		NextVersionRequest: forge.NextVersionRequest{
+			App:          selectApp(ctx, cmd, repo.Config(), repoDir),
+			Scheme:       forge.Scheme(cmd.String("scheme")),
+			CalVerFormat: cmd.String("calver-format"),
+			Pre:          cmd.String("pre"),
+			Meta:         cmd.String("meta"),
+			// --prefix CLI flag overrides config consistently for both tag discovery and creation.
+			Prefix: cmd.String("prefix"),
+		},
+		Initial: cmd.String("initial"),
+		Push:    cmd.Bool("push"),
+		Force:   cmd.Bool("force"),
 	}
 
 	// Handle initial version creation
-	if initialVersion != "" {
-		return createInitialTag(ctx, repoDir, prefix, initialVersion, dryRun, cmd.Bool("push"))
-	}
-
-	calverFormat := cmd.String("calver-format")
-	if calverFormat == "" {
-		calverFormat = appConfig.CalVerFormat
-	}
-
-	pre := cmd.String("pre")
-	if pre == "" {
-		pre = appConfig.Pre
-	}
-
-	meta := cmd.String("meta")
-	if meta == "" {
-		meta = appConfig.Meta
+	if req.Initial != "" {
+		_, err = repo.CreateRelease(ctx, req)
+		return err
 	}
 
-	// Create tagger for getting current version
-	tagger := git.NewTagger(repoDir, prefix, dryRun)
-
-	// Check if any tags exist
-	hasTags, err := CheckForExistingTags(ctx, repoDir, prefix)
+	appConfig, err := repo.App(req.App)
 	if err != nil {
-		return fmt.Errorf("failed to check for existing tags: %w", err)
-	}
-
-	if !hasTags {
-		// No tags found - guide user to create first tag
-		return NoTagsError(prefix, "1.0.0")
-	}
-
-	// Guard: block numeric bumps while the latest tag is a prerelease (SemVer only).
-	// This prevents accidentally creating e.g. v1.3.0 when you're on v1.3.0-rc.1.
-	if scheme == "semver" && !force {
-		if latestTag, ltErr := tagger.LatestTag(ctx); ltErr == nil && latestTag != "" {
-			vStr := version.StripPrefix(latestTag, prefix)
-			if lv, parseErr := version.ParseSemVer(vStr); parseErr == nil && lv.IsPrerelease() {
-				return preReleaseGuardError(latestTag, lv)
-			}
-		}
+		return err
 	}
-
-	// Get current version for interactive display
-	currentVersion, err := tagger.GetVersionWithDirtyCheck(ctx)
-	if err != nil {
-		logger.Debugf("failed to detect current version: %v", err)
-		currentVersion = "none"
+	scheme := forge.Scheme(appConfig.Scheme)
+	if req.Scheme != "" {
+		scheme = req.Scheme
 	}
 
 	// Interactive mode: if --bump flag is not explicitly set and we're in a TTY
-	var bump version.BumpType
 	isInteractive := interactive.IsInteractive() && !cmd.IsSet("bump") && !out.IsJSON()
-
-	if isInteractive && scheme == "semver" {
-		// Show interactive prompt for bump type selection
-		logger.Debugf("entering interactive mode for bump selection")
-
-		// Calculate preview versions for each bump type
-		choices := []interactive.BumpChoice{}
-
-		for _, bumpType := range []version.BumpType{version.BumpPatch, version.BumpMinor, version.BumpMajor} {
-			previewVer, previewErr := tagger.CalculateNextVersion(
-				ctx,
-				version.SchemeSemVer,
-				bumpType,
-				calverFormat,
-				pre,
-				meta,
-			)
-			if previewErr != nil {
-				logger.Debugf("failed to calculate preview for %s: %v", bumpType, previewErr)
-				continue
-			}
-
-			var desc string
-			switch bumpType {
-			case version.BumpPatch:
-				desc = "bug fixes and patches"
-			case version.BumpMinor:
-				desc = "new features, backwards compatible"
-			case version.BumpMajor:
-				desc = "breaking changes"
-			}
-
-			choices = append(choices, interactive.BumpChoice{
-				Type:        interactive.BumpType(strings.ToLower(string(bumpType))),
-				Description: desc,
-				Preview:     version.WithPrefix(previewVer.String(), prefix),
-			})
-		}
-
-		// Show selection prompt
-		selected, selErr := interactive.PromptBumpType(currentVersion, choices)
-		if selErr != nil {
-			return fmt.Errorf("interactive selection: %w", selErr)
+	if isInteractive {
+		// Fail before asking anything if the release would be refused
+		if err = repo.CheckRelease(ctx, req); err != nil {
+			return err
 		}
+	}
 
-		// Convert selected choice to bump type
-		switch selected.Type {
-		case interactive.BumpPatch:
-			bump = version.BumpPatch
-		case interactive.BumpMinor:
-			bump = version.BumpMinor
-		case interactive.BumpMajor:
-			bump = version.BumpMajor
-		default:
-			return fmt.Errorf("invalid bump type selected: %s", selected.Type)
+	if isInteractive && scheme == forge.SchemeSemVer {
+		logger.Debugf("entering interactive mode for bump selection")
+		if req.Bump, err = promptBump(ctx, repo, req.NextVersionRequest); err != nil {
+			return err
 		}
-
-		logger.Debugf("selected bump type: %s", bump)
+		logger.Debugf("selected bump type: %s", req.Bump)
 	} else {
 		// Non-interactive mode: use flag or default
-		bumpStr := cmd.String("bump")
-		switch bumpStr {
-		case "major":
-			bump = version.BumpMajor
-		case "minor":
-			bump = version.BumpMinor
-		case "patch":
-			bump = version.BumpPatch
-		default:
-			return fmt.Errorf("invalid bump type: %s", bumpStr)
+		if req.Bump, err = forge.ParseBump(cmd.String("bump")); err != nil {
+			return err
 		}
-	}
-
-	// Validate scheme
-	var versionScheme version.Scheme
-	switch scheme {
-	case "semver":
-		versionScheme = version.SchemeSemVer
-	case "calver":
-		versionScheme = version.SchemeCalVer
 		// Warn if --bump flag is provided with calver
-		if cmd.IsSet("bump") {
+		if scheme == forge.SchemeCalVer && cmd.IsSet("bump") {
 			logger.Warnf(
 				"--bump flag is ignored for calver scheme (versions are automatically determined by date/week)",
 			)
 		}
-	default:
-		return fmt.Errorf("invalid scheme: %s (must be semver or calver)", scheme)
 	}
 
-	// Calculate next version (but don't create tag yet)
-	nextVersion, err := tagger.CalculateNextVersion(ctx, versionScheme, bump, calverFormat, pre, meta)
-	if err != nil {
-		return fmt.Errorf("calculate next version: %w", err)
-	}
-
-	tag := version.WithPrefix(nextVersion.String(), prefix)
-	cleanVersion := nextVersion.String()
-
 	// Interactive confirmation before creating tag
-	var confirmed bool
 	if isInteractive && !dryRun {
-		preview := fmt.Sprintf("Current: %s \u2192 Next: %s", currentVersion, tag)
-		confirmed, err = interactive.PromptConfirmation("Create this tag?", preview)
-		if err != nil {
-			return fmt.Errorf("confirmation: %w", err)
-		}
-		if !confirmed {
-			logger.Infof("Tag creation canceled")
-			return nil
+		confirmed, confirmErr := confirmRelease(ctx, repo, req.NextVersionRequest)
+		if confirmErr != nil || !confirmed {
+			return confirmErr
 		}
 	}
 
-	// Update package.json BEFORE creating the tag if Node.js integration is enabled
-	var updated bool
-	if appConfig.NodeJS.Enabled {
-		logger.Debugf("Node.js integration enabled, updating package.json")
-
-		// Create Node.js updater
-		nodeUpdater := nodejs.NewUpdater(repoDir, dryRun)
-
-		// Update package.json
-		updated, err = nodeUpdater.Update(ctx, appConfig.NodeJS.PackagePath, cleanVersion)
-		if err != nil {
-			return fmt.Errorf("update package.json: %w", err)
-		}
+	_, err = repo.CreateRelease(ctx, req)
+	return err
+}
 
-		if updated && !dryRun {
-			// Stage and commit the package.json change
-			logger.Debugf("committing package.json version update")
+// promptBump asks for the bump type, previewing the version each would create and
+// marking the one suggested by the commits since the latest tag.
+func promptBump(ctx context.Context, repo *forge.Repo, req forge.NextVersionRequest) (version.BumpType, error) {
+	logger := log.FromContext(ctx)
+	quiet := repo.With(forge.WithOutput(nil, ""))
 
-			// Get package.json path for staging
-			pkgPath := appConfig.NodeJS.PackagePath
-			if pkgPath == "" {
-				pkgPath = "package.json"
-			}
+	// Suggest a bump from the commits since the latest tag (reverted changes don't count)
+	suggested, err := quiet.NextVersion(ctx, req)
+	if err != nil {
+		return "", err
+	}
 
-			if err = tagger.CommitVersionUpdate(ctx, pkgPath, tag); err != nil {
-				return fmt.Errorf("commit package.json: %w", err)
-			}
+	// Calculate preview versions for each bump type
+	choices := []interactive.BumpChoice{}
+	for _, bumpType := range []version.BumpType{version.BumpPatch, version.BumpMinor, version.BumpMajor} {
+		req.Bump = bumpType
+		preview, previewErr := quiet.NextVersion(ctx, req)
+		if previewErr != nil {
+			logger.Debugf("failed to calculate preview for %s: %v", bumpType, previewErr)
+			continue
+		}
 
-			logger.Infof("committed package.json version update")
+		var desc string
+		switch bumpType {
+		case version.BumpPatch:
+			desc = "bug fixes and patches"
+		case version.BumpMinor:
+			desc = "new features, backwards compatible"
+		case version.BumpMajor:
+			desc = "breaking changes"
+		}
+		if string(bumpType) == suggested.Bump {
+			desc += " (suggested)"
 		}
-	}
 
-	// Now create the tag on the current commit (which includes package.json update if any)
-	if err = tagger.CreateTag(ctx, tag, fmt.Sprintf("forge: release %s", tag)); err != nil {
-		return fmt.Errorf("create tag: %w", err)
+		choices = append(choices, interactive.BumpChoice{
+			Type:        interactive.BumpType(strings.ToLower(string(bumpType))),
+			Description: desc,
+			Preview:     preview.Tag,
+		})
 	}
 
-	pushed := cmd.Bool("push")
-
-	// Push if requested
-	if pushed {
-		if err = tagger.PushTag(ctx, tag); err != nil {
-			return fmt.Errorf("push tag: %w", err)
-		}
+	// Show selection prompt
+	selected, err := interactive.PromptBumpType(suggested.Current, choices)
+	if err != nil {
+		return "", fmt.Errorf("interactive selection: %w", err)
 	}
 
-	// Output based on format
-	if out.IsJSON() {
-		result := output.TagResult{
-			Tag:     tag,
-			Pushed:  pushed,
-			Version: tag,
-			Message: fmt.Sprintf("Tag created%s", map[bool]string{true: " and pushed", false: ""}[pushed]),
-		}
-		return out.Print(result)
+	// Convert selected choice to bump type
+	switch selected.Type {
+	case interactive.BumpPatch:
+		return version.BumpPatch, nil
+	case interactive.BumpMinor:
+		return version.BumpMinor, nil
+	case interactive.BumpMajor:
+		return version.BumpMajor, nil
+	default:
+		return "", fmt.Errorf("invalid bump type selected: %s", selected.Type)
 	}
+}
 
-	if pushed {
-		logger.Success("Tag created and pushed: %s", tag)
-	} else {
-		logger.Success("Tag created: %s", tag)
+// confirmRelease previews the tag req would create and asks whether to create it.
+func confirmRelease(ctx context.Context, repo *forge.Repo, req forge.NextVersionRequest) (bool, error) {
+	next, err := repo.With(forge.WithOutput(nil, "")).NextVersion(ctx, req)
+	if err != nil {
+		return false, err
 	}
 
-	return nil
-}
-
-// preReleaseGuardError returns a friendly error when the user tries to do a numeric
-// bump while the current latest tag is a prerelease.
-func preReleaseGuardError(tag string, v *version.Version) error {
-	base := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
-	channel := strings.SplitN(v.Pre, ".", 2)[0]
-	return &ForgeError{
-		Title:       fmt.Sprintf("Current version %s is a prerelease", tag),
-		Description: "Numeric bumps (major/minor/patch) cannot be applied while on a prerelease tag.",
-		Suggestions: []string{
-			fmt.Sprintf("forge bump pre %-12s advance prerelease (%s → %s.next)", channel, v.Pre, channel),
-			"forge bump pre rc              promote to a different channel",
-			fmt.Sprintf("forge bump pre release         graduate to stable %s", base),
-			"forge bump ... --force         bypass this guard (not recommended)",
-		},
+	preview := fmt.Sprintf("Current: %s \u2192 Next: %s", next.Current, next.Tag)
+	confirmed, err := interactive.PromptConfirmation("Create this tag?", preview)
+	if err != nil {
+		return false, fmt.Errorf("confirmation: %w", err)
+	}
+	if !confirmed {
+		log.FromContext(ctx).Infof("Tag creation canceled")
 	}
+	return confirmed, nil
 }
 
 // BumpPre returns the 'forge bump pre' subcommand for managing prerelease versions.
@@ -415,11 +280,6 @@ func BumpPre() *cli.Command {
 				Usage: "tag prefix (e.g., v)",
 				Value: "",
 			},
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			&cli.BoolFlag{
 				Name:  "push",
 				Usage: "push the tag to remote",
@@ -438,9 +298,7 @@ func BumpPre() *cli.Command {
 	}
 }
 
-//nolint:gocognit
 func preAction(ctx context.Context, cmd *cli.Command) error {
-	logger := log.FromContext(ctx)
 	out := output.FromContext(ctx)
 
 	channel := cmd.Args().First()
@@ -455,175 +313,44 @@ func preAction(ctx context.Context, cmd *cli.Command) error {
 		)
 	}
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 	dryRun := cmd.Bool("dry-run")
-	force := cmd.Bool("force")
 
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
 		return err
 	}
 
-	if !dryRun && !force {
-		if err := CheckGitClean(ctx, repoDir, force); err != nil {
-			return err
-		}
-	}
-
-	cfg, err := config.LoadFromDir(repoDir)
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-
-	appName := cmd.String("app")
-	appConfig, err := cfg.GetAppConfig(appName)
+	repo, err := openRepo(ctx, cmd, repoDir, dryRun)
 	if err != nil {
-		return fmt.Errorf("get app config: %w", err)
-	}
-
-	if appConfig.Scheme != "semver" {
-		return fmt.Errorf(
-			"forge bump pre only supports semver scheme (configured scheme: %s)",
-			appConfig.Scheme,
-		)
-	}
-
-	// --prefix CLI flag overrides config consistently for both tag discovery and creation.
-	prefix := cmd.String("prefix")
-	if prefix == "" {
-		prefix = appConfig.Prefix
-	}
-
-	tagger := git.NewTagger(repoDir, prefix, dryRun)
-
-	nextVer, err := tagger.CalculatePreRelease(ctx, channel, cmd.String("bump"))
-	if err != nil {
-		return fmt.Errorf("calculate prerelease version: %w", err)
-	}
-
-	tag := version.WithPrefix(nextVer.String(), prefix)
-	cleanVersion := nextVer.String()
-
-	// Get current version for display.
-	currentVersion, err := tagger.GetVersionWithDirtyCheck(ctx)
-	if err != nil {
-		logger.Debugf("failed to detect current version: %v", err)
-		currentVersion = "none"
+		return err
 	}
 
-	if dryRun {
-		logger.Infof("dry-run: %s → %s", currentVersion, tag)
-		return nil
+	req := forge.ReleaseRequest{
+		NextVersionRequest: forge.NextVersionRequest{
+			App:     selectApp(ctx, cmd, repo.Config(), repoDir),
+			Bump:    forge.BumpType(cmd.String("bump")),
+			Channel: channel,
+			// --prefix CLI flag overrides config consistently for both tag discovery and creation.
+			Prefix: cmd.String("prefix"),
+		},
+		Push:  cmd.Bool("push"),
+		Force: cmd.Bool("force"),
 	}
 
 	// Interactive confirmation.
-	isInteractive := interactive.IsInteractive() && !out.IsJSON()
-	var confirmed bool
-	if isInteractive {
-		preview := fmt.Sprintf("Current: %s \u2192 Next: %s", currentVersion, tag)
-		confirmed, err = interactive.PromptConfirmation("Create this tag?", preview)
-		if err != nil {
-			return fmt.Errorf("confirmation: %w", err)
-		}
-		if !confirmed {
-			logger.Infof("Tag creation canceled")
-			return nil
-		}
-	}
-
-	// Update package.json if Node.js integration is enabled.
-	var updated bool
-	if appConfig.NodeJS.Enabled {
-		logger.Debugf("Node.js integration enabled, updating package.json")
-		nodeUpdater := nodejs.NewUpdater(repoDir, dryRun)
-		updated, err = nodeUpdater.Update(ctx, appConfig.NodeJS.PackagePath, cleanVersion)
-		if err != nil {
-			return fmt.Errorf("update package.json: %w", err)
-		}
-		if updated {
-			pkgPath := appConfig.NodeJS.PackagePath
-			if pkgPath == "" {
-				pkgPath = "package.json"
-			}
-			if err = tagger.CommitVersionUpdate(ctx, pkgPath, tag); err != nil {
-				return fmt.Errorf("commit package.json: %w", err)
-			}
-			logger.Infof("committed package.json version update")
-		}
-	}
-
-	if err = tagger.CreateTag(ctx, tag, fmt.Sprintf("forge: release %s", tag)); err != nil {
-		return fmt.Errorf("create tag: %w", err)
-	}
-
-	pushed := cmd.Bool("push")
-	if pushed {
-		if err = tagger.PushTag(ctx, tag); err != nil {
-			return fmt.Errorf("push tag: %w", err)
-		}
-	}
-
-	if out.IsJSON() {
-		result := output.TagResult{
-			Tag:     tag,
-			Pushed:  pushed,
-			Version: cleanVersion,
-			Message: fmt.Sprintf("Tag created%s", map[bool]string{true: " and pushed", false: ""}[pushed]),
-		}
-		return out.Print(result)
-	}
-
-	if pushed {
-		logger.Success("Tag created and pushed: %s", tag)
-	} else {
-		logger.Success("Tag created: %s", tag)
-	}
-	return nil
-}
-
-// createInitialTag creates the first version tag for a project
-func createInitialTag(ctx context.Context, repoDir, tagPrefix, version string, dryRun, push bool) error {
-	logger := log.FromContext(ctx)
-
-	// Validate version format
-	if version == "" {
-		version = "1.0.0"
-	}
-
-	// Add prefix if not present
-	fullTag := version
-	if tagPrefix != "" && !strings.HasPrefix(version, tagPrefix) {
-		fullTag = tagPrefix + version
-	}
-
-	logger.Infof("creating initial version tag: %s", fullTag)
-
-	if dryRun {
-		logger.Infof("dry-run: would create tag %s", fullTag)
-		if push {
-			logger.Infof("dry-run: would push tag to remote")
+	if interactive.IsInteractive() && !out.IsJSON() && !dryRun {
+		if err = repo.CheckRelease(ctx, req); err != nil {
+			return err
 		}
-		return nil
-	}
-
-	// Create tagger
-	tagger := git.NewTagger(repoDir, tagPrefix, dryRun)
-
-	// Create the tag
-	if err := tagger.CreateTag(ctx, fullTag, fmt.Sprintf("forge: initial release %s", fullTag)); err != nil {
-		return fmt.Errorf("create initial tag: %w", err)
-	}
-
-	logger.Success("Created initial tag: %s", fullTag)
-
-	// Push if requested
-	if push {
-		if err := tagger.PushTag(ctx, fullTag); err != nil {
-			return fmt.Errorf("push tag: %w", err)
+		confirmed, confirmErr := confirmRelease(ctx, repo, req.NextVersionRequest)
+		if confirmErr != nil || !confirmed {
+			return confirmErr
 		}
-		logger.Success("Pushed tag to remote: %s", fullTag)
-	} else {
-		logger.Infof("tag created locally - use --push to push to remote")
 	}
 
-	return nil
+	_, err = repo.CreateRelease(ctx, req)
+	return err
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/alexjoedt/forge/internal/git"
 	"github.com/alexjoedt/forge/internal/log"
 	"github.com/alexjoedt/forge/internal/output"
+	"github.com/alexjoedt/forge/internal/version"
 	"github.com/urfave/cli/v3"
 )
 
@@ -17,11 +18,6 @@ func Validate() *cli.Command {
 		Name:  "validate",
 		Usage: "Validate forge.yaml configuration and git repository state",
 		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			appFlag,
 		},
 		Action: validateAction,
@@ -33,7 +29,10 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
 	out := output.FromContext(ctx)
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Track validation issues
 	issues := []string{}
@@ -42,7 +41,7 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 	// Check git repository
 	logger.Debugf("Checking git repository...")
 	tagger := git.NewTagger(repoDir, "", false)
-	_, err := tagger.CurrentCommit(ctx)
+	_, err = tagger.CurrentCommit(ctx)
 	if err != nil {
 		issues = append(issues, fmt.Sprintf("Not a git repository: %v", err))
 	} else {
@@ -74,7 +73,7 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 	logger.Debugf("✓ forge.yaml loaded successfully")
 
 	// Validate app configuration
-	appName := cmd.String("app")
+	appName := selectApp(ctx, cmd, cfg, repoDir)
 	appConfig, appErr := cfg.GetAppConfig(appName)
 	if appErr != nil {
 		issues = append(issues, fmt.Sprintf("Failed to get app config: %v", appErr))
@@ -105,7 +104,8 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 		}
 
 		// Check for existing tags
-		appTagger := git.NewTagger(repoDir, appConfig.Prefix, false)
+		appTagger := git.NewTagger(repoDir, appConfig.Prefix, false).
+			WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat)
 		tags, err := appTagger.ListAllTags(ctx)
 		if err != nil {
 			warnings = append(warnings, fmt.Sprintf("Failed to list tags: %v", err))
@@ -12,6 +12,7 @@ import (
 	"github.com/alexjoedt/forge/internal/output"
 	"github.com/alexjoedt/forge/internal/table"
 	"github.com/alexjoedt/forge/internal/version"
+	"github.com/alexjoedt/forge/pkg/forge"
 	"github.com/urfave/cli/v3"
 )
 
@@ -21,11 +22,6 @@ func Version() *cli.Command {
 		Name:  "version",
 		Usage: "Show current version from git tags",
 		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			appFlag,
 		},
 		Action: versionAction,
@@ -40,7 +36,10 @@ func versionAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
 	out := output.FromContext(ctx)
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate requirements
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
@@ -54,7 +53,7 @@ func versionAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	appName := cmd.String("app")
+	appName := selectApp(ctx, cmd, cfg, repoDir)
 
 	// Check if multi-app and no specific app requested
 	if cfg.IsMultiApp() && appName == "" && !out.IsJSON() {
@@ -70,7 +69,8 @@ func versionAction(ctx context.Context, cmd *cli.Command) error {
 	tagPrefix := appConfig.Prefix
 
 	// Create tagger
-	tagger := git.NewTagger(repoDir, tagPrefix, false)
+	tagger := git.NewTagger(repoDir, tagPrefix, false).
+		WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat)
 
 	// Get version with dirty check (same logic as build/image commands)
 	versionStr, err := tagger.GetVersionWithDirtyCheck(ctx)
@@ -143,7 +143,8 @@ func versionMultiAppAction(ctx context.Context, cfg *config.Config, repoDir stri
 	apps := cfg.GetAllApps()
 	for appName, appConfig := range apps {
 		tagPrefix := appConfig.Prefix
-		tagger := git.NewTagger(repoDir, tagPrefix, false)
+		tagger := git.NewTagger(repoDir, tagPrefix, false).
+			WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat)
 
 		// Get version
 		versionStr, err := tagger.GetVersionWithDirtyCheck(ctx)
@@ -204,11 +205,6 @@ func versionListCommand() *cli.Command {
 		Usage:   "List all version tags in history",
 		Aliases: []string{"ls"},
 		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			&cli.IntFlag{
 				Name:    "limit",
 				Aliases: []string{"n"},
@@ -225,7 +221,10 @@ func versionListAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
 	out := output.FromContext(ctx)
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate requirements
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
@@ -238,14 +237,15 @@ func versionListAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	appName := cmd.String("app")
+	appName := selectApp(ctx, cmd, cfg, repoDir)
 	appConfig, err := cfg.GetAppConfig(appName)
 	if err != nil {
 		return fmt.Errorf("get app config: %w", err)
 	}
 
 	tagPrefix := appConfig.Prefix
-	tagger := git.NewTagger(repoDir, tagPrefix, false)
+	tagger := git.NewTagger(repoDir, tagPrefix, false).
+		WithScheme(version.Scheme(appConfig.Scheme), appConfig.CalVerFormat)
 
 	// Get all tags
 	tags, err := tagger.ListAllTags(ctx)
@@ -325,11 +325,6 @@ func versionNextCommand() *cli.Command {
 		Name:  "next",
 		Usage: "Preview the next version without creating a tag",
 		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "repo-dir",
-				Usage: "repository directory",
-				Value: ".",
-			},
 			&cli.StringFlag{
 				Name:  "scheme",
 				Usage: "version scheme: semver or calver",
@@ -363,112 +358,48 @@ func versionNextCommand() *cli.Command {
 
 func versionNextAction(ctx context.Context, cmd *cli.Command) error {
 	logger := log.FromContext(ctx)
-	out := output.FromContext(ctx)
 
-	repoDir := cmd.String("repo-dir")
+	repoDir, err := findRepoDir(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Validate requirements
 	if err := ValidateRequirements(ctx, repoDir); err != nil {
 		return err
 	}
 
-	// Load config
-	cfg, err := config.LoadFromDir(repoDir)
+	repo, err := openRepo(ctx, cmd, repoDir, true)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
 
-	appName := cmd.String("app")
-	appConfig, err := cfg.GetAppConfig(appName)
+	bump, err := forge.ParseBump(cmd.String("bump"))
 	if err != nil {
-		return fmt.Errorf("get app config: %w", err)
-	}
-
-	// Override config with flags
-	scheme := cmd.String("scheme")
-	if scheme == "" {
-		scheme = appConfig.Scheme
-	}
-
-	prefix := appConfig.Prefix
-	calverFormat := cmd.String("calver-format")
-	if calverFormat == "" {
-		calverFormat = appConfig.CalVerFormat
-	}
-
-	pre := cmd.String("pre")
-	if pre == "" {
-		pre = appConfig.Pre
-	}
-
-	meta := cmd.String("meta")
-	if meta == "" {
-		meta = appConfig.Meta
+		return err
 	}
 
-	bumpStr := cmd.String("bump")
-	var bump version.BumpType
-	switch bumpStr {
-	case "major":
-		bump = version.BumpMajor
-	case "minor":
-		bump = version.BumpMinor
-	case "patch":
-		bump = version.BumpPatch
-	default:
-		return fmt.Errorf("invalid bump type: %s", bumpStr)
+	req := forge.NextVersionRequest{
+		App:          selectApp(ctx, cmd, repo.Config(), repoDir),
+		Scheme:       forge.Scheme(cmd.String("scheme")),
+		Bump:         bump,
+		CalVerFormat: cmd.String("calver-format"),
+		Pre:          cmd.String("pre"),
+		Meta:         cmd.String("meta"),
 	}
 
-	// Validate scheme
-	var versionScheme version.Scheme
-	switch scheme {
-	case "semver":
-		versionScheme = version.SchemeSemVer
-	case "calver":
-		versionScheme = version.SchemeCalVer
-		if cmd.IsSet("bump") {
+	if cmd.IsSet("bump") {
+		appConfig, err := repo.App(req.App)
+		if err != nil {
+			return err
+		}
+		if req.Scheme == forge.SchemeCalVer || (req.Scheme == "" && appConfig.Scheme == string(forge.SchemeCalVer)) {
 			logger.Warnf(
 				"--bump flag is ignored for calver scheme (versions are automatically determined by date/week)",
 			)
 		}
-	default:
-		return fmt.Errorf("invalid scheme: %s (must be semver or calver)", scheme)
-	}
-
-	// Create tagger (dry-run doesn't matter here since we're only calculating)
-	tagger := git.NewTagger(repoDir, prefix, true)
-
-	// Get current version
-	currentVersion, err := tagger.GetVersionWithDirtyCheck(ctx)
-	if err != nil {
-		logger.Warnf("failed to detect current version from git: %v", err)
-		currentVersion = "none"
-	}
-
-	// Calculate next version
-	nextVersion, err := tagger.CalculateNextVersion(ctx, versionScheme, bump, calverFormat, pre, meta)
-	if err != nil {
-		return fmt.Errorf("calculate next version: %w", err)
-	}
-
-	tag := version.WithPrefix(nextVersion.String(), prefix)
-
-	// Output based on format
-	if out.IsJSON() {
-		result := map[string]any{
-			"current": currentVersion,
-			"next":    nextVersion.String(),
-			"tag":     tag,
-			"scheme":  scheme,
-		}
-		return out.Print(result)
 	}
 
-	// Text output
-	fmt.Fprintf(os.Stdout, "Current:  %s\n", currentVersion)
-	fmt.Fprintf(os.Stdout, "Next:     %s\n", nextVersion.String())
-	fmt.Fprintf(os.Stdout, "Tag:      %s\n", tag)
-	fmt.Fprintf(os.Stdout, "Scheme:   %s\n", scheme)
-
-	return nil
+	_, err = repo.NextVersion(ctx, req)
+	return err
 }
@@ -3,16 +3,22 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alexjoedt/forge/internal/log"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	DefaultApp string               `yaml:"defaultApp"`
+	DefaultApp string               `yaml:"defaultApp,omitempty"`
 	Apps       map[string]AppConfig `yaml:",inline"`
 }
 
@@ -20,25 +26,137 @@ type Config struct {
 
 // AppConfig represents the forge.yaml configuration file structure.
 type AppConfig struct {
-	Scheme        string        `yaml:"scheme"`                    // "semver" or "calver"
-	Prefix        string        `yaml:"prefix"`                    // Tag prefix, e.g., "v", "api/v"
-	DefaultBranch string        `yaml:"default_branch"`            // e.g., "main"
-	CalVerFormat  string        `yaml:"calver_format,omitempty"`   // e.g., "2006.01.02", "2006.WW"
-	Pre           string        `yaml:"pre,omitempty"`             // [ALPHA] prerelease identifier
-	Meta          string        `yaml:"meta,omitempty"`            // [ALPHA] build metadata
-	Hotfix        *HotfixConfig `yaml:"hotfix,omitempty"`          // Hotfix workflow settings
-	NodeJS        NodeJSConfig  `yaml:"nodejs,omitempty"`          // Node.js package.json sync
+	Scheme        string           `yaml:"scheme"`                  // "semver" or "calver"
+	Prefix        string           `yaml:"prefix"`                  // Tag prefix, e.g., "v", "api/v"
+	DefaultBranch string           `yaml:"default_branch"`          // e.g., "main"
+	CalVerFormat  string           `yaml:"calver_format,omitempty"` // e.g., "2006.01.02", "2006.WW"
+	Pre           string           `yaml:"pre,omitempty"`           // [ALPHA] prerelease identifier
+	Meta          string           `yaml:"meta,omitempty"`          // [ALPHA] build metadata
+	Hotfix        *HotfixConfig    `yaml:"hotfix,omitempty"`        // Hotfix workflow settings
+	NodeJS        NodeJSConfig     `yaml:"nodejs,omitempty"`        // Node.js package.json sync
+	Changelog     *ChangelogConfig `yaml:"changelog,omitempty"`     // Changelog generation settings
+	Lint          *LintConfig      `yaml:"lint,omitempty"`          // Commit message lint rules
+	Extends       string           `yaml:"extends,omitempty"`       // Multi-app only: app to inherit settings from
+	Dir           string           `yaml:"dir,omitempty"`           // App directory relative to the repo root
 }
 
 // HotfixConfig holds hotfix workflow configuration.
 type HotfixConfig struct {
 	// Branch naming prefix. The full branch name is prefix + tag.
 	// Examples: "release/" creates "release/v1.0.0"
-	BranchPrefix string `yaml:"branch_prefix"` // Default: "release/"
+	BranchPrefix string `yaml:"branch_prefix,omitempty"` // Default: "release/"
 
 	// Version suffix for hotfix tags
 	// Examples: "hotfix", "patch", "fix"
-	Suffix string `yaml:"suffix"` // Default: "hotfix"
+	Suffix string `yaml:"suffix,omitempty"` // Default: "hotfix"
+
+	// Style selects how hotfix versions are named: "suffix" creates v1.5.0-hotfix.1,
+	// "patch" creates the next free patch version, v1.5.1. For calver, "patch" adds a
+	// hotfix number to the release: 2025.44.1.1.
+	Style string `yaml:"style,omitempty"` // Default: "suffix", "patch" for calver
+
+	// Fallback names patch-style hotfixes whose patch version is already released:
+	// "metadata" creates v1.5.0+hotfix.1, "four-part" creates v1.5.0.1.
+	Fallback string `yaml:"fallback,omitempty"` // Default: "metadata"
+
+	// Archive keeps finished hotfix branches as tags: finishing "release/v1.5.0"
+	// creates ArchivePrefix + "release/v1.5.0" before the branch is deleted.
+	Archive bool `yaml:"archive,omitempty"` // Default: false
+
+	// Tag name prefix for archived hotfix branches.
+	ArchivePrefix string `yaml:"archive_prefix,omitempty"` // Default: "archive/"
+
+	// SupportWindow is how long a release gets hotfixes, e.g. "180d" or "26w".
+	// Hotfix lines of older bases are reported as unsupported; empty means no policy.
+	SupportWindow string `yaml:"support_window,omitempty"`
+}
+
+// Hotfix styles and fallbacks.
+const (
+	// HotfixStyleSuffix names hotfixes as prereleases of the base: v1.5.0-hotfix.1.
+	HotfixStyleSuffix = "suffix"
+	// HotfixStylePatch names hotfixes as the next free patch version: v1.5.1.
+	// CalVer hotfixes number the release instead: 2025.44.1.1.
+	HotfixStylePatch = "patch"
+	// HotfixFallbackMetadata adds build metadata to the base: v1.5.0+hotfix.1.
+	HotfixFallbackMetadata = "metadata"
+	// HotfixFallbackFourPart adds a fourth number to the base: v1.5.0.1.
+	HotfixFallbackFourPart = "four-part"
+)
+
+// Changelog modes.
+const (
+	// ChangelogModeCommits lists every non-merge commit in the range.
+	ChangelogModeCommits = "commits"
+	// ChangelogModeFirstParent follows only the first parent and uses merge commit PR titles as entries.
+	ChangelogModeFirstParent = "first-parent"
+)
+
+// ChangelogConfig holds changelog generation settings.
+type ChangelogConfig struct {
+	// Mode selects how commits are collected: "commits" (default) or "first-parent".
+	Mode string `yaml:"mode,omitempty"`
+
+	// GroupByPR collapses all commits belonging to the same pull request into a single entry.
+	GroupByPR bool `yaml:"group_by_pr,omitempty"`
+
+	// Contributors adds a contributors section listing everyone who authored commits in the range.
+	Contributors bool `yaml:"contributors,omitempty"`
+
+	// CoAuthors also credits people named in Co-authored-by trailers.
+	CoAuthors bool `yaml:"co_authors,omitempty"`
+
+	// Bots lists author names or emails that are never credited as contributors.
+	// A "*" matches any sequence of characters. Default: ["*[bot]"]
+	Bots []string `yaml:"bots,omitempty"`
+
+	// Package holds the metadata used by the debian and rpm output formats.
+	Package PackageConfig `yaml:"package,omitempty"`
+
+	// Exclude leaves matching commits out of changelogs.
+	Exclude ExcludeConfig `yaml:"exclude,omitempty"`
+
+	// Issues lists the issue tracker references to extract and link, e.g. Jira keys.
+	Issues []IssueConfig `yaml:"issues,omitempty"`
+}
+
+// IssueConfig describes an issue tracker reference pattern.
+type IssueConfig struct {
+	Pattern string `yaml:"pattern"`       // Regular expression, e.g. PAY-\d+; the first group is the ID if present
+	URL     string `yaml:"url,omitempty"` // Link template, "{id}" is replaced with the issue ID
+}
+
+// ExcludeConfig lists commits to leave out of changelogs. A commit is excluded if any rule matches.
+type ExcludeConfig struct {
+	Subjects []string `yaml:"subjects,omitempty"` // Regular expressions matched against the subject line
+	Authors  []string `yaml:"authors,omitempty"`  // Author names or emails, "*" matches anything
+	Scopes   []string `yaml:"scopes,omitempty"`   // Conventional Commit scopes
+	Paths    []string `yaml:"paths,omitempty"`    // Commits that only touch these paths are excluded
+}
+
+// PackageConfig holds Debian/RPM package metadata for changelog entries.
+type PackageConfig struct {
+	Name         string `yaml:"name,omitempty"`         // Package name (default: app name or repository directory)
+	Maintainer   string `yaml:"maintainer,omitempty"`   // "Name <email>" (default: git user.name/user.email)
+	Distribution string `yaml:"distribution,omitempty"` // Debian distribution (default: "unstable")
+	Urgency      string `yaml:"urgency,omitempty"`      // Debian urgency (default: "medium")
+	Revision     string `yaml:"revision,omitempty"`     // Package revision (default: "1")
+}
+
+// DefaultMaxSubjectLength is the default lint limit for commit subject lines.
+const DefaultMaxSubjectLength = 72
+
+// LintConfig holds Conventional Commit lint rules.
+type LintConfig struct {
+	// Types lists the allowed commit types.
+	// Default: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
+	Types []string `yaml:"types,omitempty"`
+
+	// Scopes lists the allowed scopes. Empty allows any scope.
+	Scopes []string `yaml:"scopes,omitempty"`
+
+	// MaxSubjectLength limits the subject line length. Default: 72
+	MaxSubjectLength int `yaml:"max_subject_length,omitempty"`
 }
 
 // NodeJSConfig holds Node.js/npm package.json version sync settings.
@@ -98,6 +216,79 @@ func (ac *AppConfig) Validate() error {
 			"        Sequence numbers are auto-incremented for same-period releases")
 	}
 
+	if ac.Dir != "" {
+		dir := path.Clean(filepath.ToSlash(ac.Dir))
+		if filepath.IsAbs(ac.Dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+			return fmt.Errorf("invalid dir: '%s'\n\n"+
+				"  dir is the app directory relative to the repository root:\n"+
+				"    dir: services/api", ac.Dir)
+		}
+	}
+
+	if ac.Hotfix != nil {
+		switch ac.Hotfix.Style {
+		case "", HotfixStyleSuffix:
+		case HotfixStylePatch:
+		default:
+			return fmt.Errorf("invalid hotfix style: '%s'\n\n"+
+				"  Valid styles:\n"+
+				"    • suffix - prerelease of the base, e.g. v1.5.0-hotfix.1 (semver default)\n"+
+				"    • patch  - next free patch version, e.g. v1.5.1, or 2025.44.1.1 (calver default)",
+				ac.Hotfix.Style)
+		}
+
+		switch ac.Hotfix.Fallback {
+		case "", HotfixFallbackMetadata, HotfixFallbackFourPart:
+		default:
+			return fmt.Errorf("invalid hotfix fallback: '%s'\n\n"+
+				"  Valid fallbacks for taken patch versions:\n"+
+				"    • metadata  - build metadata, e.g. v1.5.0+hotfix.1 (default)\n"+
+				"    • four-part - fourth version number, e.g. v1.5.0.1",
+				ac.Hotfix.Fallback)
+		}
+
+		if ac.Hotfix.SupportWindow != "" {
+			if _, err := ParseDuration(ac.Hotfix.SupportWindow); err != nil {
+				return fmt.Errorf("invalid hotfix support_window: '%s'\n\n"+
+					"  Use a number of days or weeks, e.g. 180d or 26w", ac.Hotfix.SupportWindow)
+			}
+		}
+	}
+
+	if ac.Changelog != nil {
+		switch ac.Changelog.Mode {
+		case "", ChangelogModeCommits, ChangelogModeFirstParent:
+		default:
+			return fmt.Errorf("invalid changelog mode: '%s'\n\n"+
+				"  Valid modes:\n"+
+				"    • commits      - list every non-merge commit (default)\n"+
+				"    • first-parent - use merge commit / PR titles as entries",
+				ac.Changelog.Mode)
+		}
+
+		for _, pattern := range ac.Changelog.Exclude.Subjects {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid changelog.exclude.subjects pattern: '%s'\n\n"+
+					"  %v\n\n"+
+					"  Patterns are Go regular expressions, e.g.:\n"+
+					"    • '^chore\\(release\\)'\n"+
+					"    • '^Merge branch '",
+					pattern, err)
+			}
+		}
+
+		for _, issue := range ac.Changelog.Issues {
+			if _, err := regexp.Compile(issue.Pattern); err != nil || issue.Pattern == "" {
+				return fmt.Errorf("invalid changelog.issues pattern: '%s'\n\n"+
+					"  Each issue needs a Go regular expression and an optional URL template:\n"+
+					"    issues:\n"+
+					"      - pattern: 'PAY-\\d+'\n"+
+					"        url: https://example.atlassian.net/browse/{id}",
+					issue.Pattern)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -107,7 +298,6 @@ func Default() *AppConfig {
 		Scheme:        "semver",
 		Prefix:        "v",
 		DefaultBranch: "main",
-		CalVerFormat:  "2006.01.02",
 	}
 }
 
@@ -186,14 +376,19 @@ func Load(path string) (*Config, error) {
 			"  Run 'forge init' to generate a new configuration file.")
 	}
 
-	// Check if this is a multi-app config by looking for defaultApp or multiple app configs
+	// Check if this is a multi-app config by looking for defaultApp, a defaults block or
+	// multiple app configs
 	hasDefaultApp := false
+	_, hasDefaults := raw[defaultsKey]
 	appCount := 0
 	for key := range raw {
 		if key == "defaultApp" {
 			hasDefaultApp = true
 			continue
 		}
+		if key == defaultsKey {
+			continue
+		}
 		// Check if this key looks like an app config (has nested structure with scheme/prefix/etc.)
 		if val, ok := raw[key].(map[string]interface{}); ok {
 			// Detect old nested format inside a multi-app entry
@@ -216,26 +411,33 @@ func Load(path string) (*Config, error) {
 					"  Run 'forge init' to generate a new configuration file.",
 					key, key, key)
 			}
-			if _, hasScheme := val["scheme"]; hasScheme {
-				appCount++
-			} else if _, hasPrefix := val["prefix"]; hasPrefix {
-				appCount++
+			for _, appKey := range []string{"scheme", "prefix", "extends"} {
+				if _, ok := val[appKey]; ok {
+					appCount++
+					break
+				}
 			}
 		}
 	}
 
-	// If we have defaultApp or multiple apps, treat as multi-app config
-	if hasDefaultApp || appCount > 1 {
+	// If we have defaultApp, defaults or multiple apps, treat as multi-app config
+	if hasDefaultApp || hasDefaults || appCount > 1 {
 		log.DefaultLogger.Debugf(
-			"loading multi app configuration (detected: defaultApp=%v, apps=%d)",
+			"loading multi app configuration (detected: defaultApp=%v, defaults=%v, apps=%d)",
 			hasDefaultApp,
+			hasDefaults,
 			appCount,
 		)
-		cfg := &Config{Apps: make(map[string]AppConfig)}
+		cfg := &Config{}
 		if err = yaml.Unmarshal(data, cfg); err != nil {
 			return nil, fmt.Errorf("unmarshal multi-app config: %w", err)
 		}
 
+		// Apply the defaults block and extends before validating
+		if cfg.Apps, err = resolveApps(raw); err != nil {
+			return nil, fmt.Errorf("resolve multi-app config: %w", err)
+		}
+
 		// Validate each app config
 		for appName, appCfg := range cfg.Apps {
 			if err = appCfg.Validate(); err != nil {
@@ -253,6 +455,18 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("unmarshal single-app config: %w", err)
 	}
 
+	if single.Extends != "" {
+		return nil, fmt.Errorf("invalid config: extends is only supported in multi-app configs\n\n" +
+			"  Put the apps under their names to inherit settings:\n" +
+			"    api:\n" +
+			"      scheme: semver\n" +
+			"      prefix: api/v\n" +
+			"      default_branch: main\n" +
+			"    worker:\n" +
+			"      extends: api\n" +
+			"      prefix: worker/v")
+	}
+
 	// Validate single app config
 	if err = single.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
@@ -266,15 +480,49 @@ func Load(path string) (*Config, error) {
 // LoadFromDir loads the forge.yaml configuration from the specified directory.
 // It looks for forge.yaml or .forge.yaml in that directory.
 func LoadFromDir(dir string) (*Config, error) {
-	// Try forge.yaml first, then .forge.yaml
+	path, err := FindFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// FindRoot returns the directory of the config file that applies to dir: dir itself or
+// the closest parent with a config file, searching up to the git top-level. Without a
+// config file it returns the git top-level, or dir outside of git repositories.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolve directory: %w", err)
+	}
+
+	for current := dir; ; {
+		if _, err = FindFile(current); err == nil {
+			return current, nil
+		}
+		// .git is a directory, or a file in worktrees and submodules
+		if _, err = os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir, nil
+		}
+		current = parent
+	}
+}
+
+// FindFile returns the path of the config file in dir: forge.yaml, or .forge.yaml if
+// there is none.
+func FindFile(dir string) (string, error) {
 	for _, name := range []string{"forge.yaml", ".forge.yaml"} {
 		path := filepath.Join(dir, name)
 		if _, err := os.Stat(path); err == nil {
-			return Load(path)
+			return path, nil
 		}
 	}
-	// No config file found, return defaults
-	return nil, fmt.Errorf("no config found in repo: %s", dir)
+	return "", fmt.Errorf("no config found in repo: %s", dir)
 }
 
 func (c *Config) GetFirst() (*AppConfig, error) {
@@ -303,24 +551,124 @@ func (c *Config) GetAppConfig(app string) (*AppConfig, error) {
 	return nil, fmt.Errorf("no config found for '%s'", app)
 }
 
+// ParseDuration parses a duration like "90d" or "12w", or any Go duration such as "36h".
+func ParseDuration(s string) (time.Duration, error) {
+	const day = 24 * time.Hour
+	for suffix, unit := range map[string]time.Duration{"d": day, "w": 7 * day} {
+		if n, found := strings.CutSuffix(s, suffix); found {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
 // GetHotfixConfig returns hotfix config with defaults applied.
+// CalVer apps default to the patch style, whose tags sort after the release they fix.
 func (ac *AppConfig) GetHotfixConfig() HotfixConfig {
+	var cfg HotfixConfig
 	if ac.Hotfix != nil {
-		cfg := *ac.Hotfix
-		// Apply defaults for empty fields
-		if cfg.BranchPrefix == "" {
-			cfg.BranchPrefix = "release/"
+		cfg = *ac.Hotfix
+	}
+
+	// Apply defaults for empty fields
+	if cfg.BranchPrefix == "" {
+		cfg.BranchPrefix = "release/"
+	}
+	if cfg.Suffix == "" {
+		cfg.Suffix = "hotfix"
+	}
+	if cfg.Style == "" {
+		cfg.Style = HotfixStyleSuffix
+		if ac.Scheme == "calver" {
+			cfg.Style = HotfixStylePatch
+		}
+	}
+	if cfg.Fallback == "" {
+		cfg.Fallback = HotfixFallbackMetadata
+	}
+	if cfg.ArchivePrefix == "" {
+		cfg.ArchivePrefix = "archive/"
+	}
+	return cfg
+}
+
+// GetChangelogConfig returns changelog config with defaults applied.
+func (ac *AppConfig) GetChangelogConfig() ChangelogConfig {
+	cfg := ChangelogConfig{}
+	if ac.Changelog != nil {
+		cfg = *ac.Changelog
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ChangelogModeCommits
+	}
+	if cfg.Bots == nil {
+		cfg.Bots = []string{"*[bot]"}
+	}
+	if cfg.Package.Distribution == "" {
+		cfg.Package.Distribution = "unstable"
+	}
+	if cfg.Package.Urgency == "" {
+		cfg.Package.Urgency = "medium"
+	}
+	if cfg.Package.Revision == "" {
+		cfg.Package.Revision = "1"
+	}
+	return cfg
+}
+
+// GetLintConfig returns lint config with defaults applied.
+func (ac *AppConfig) GetLintConfig() LintConfig {
+	cfg := LintConfig{}
+	if ac.Lint != nil {
+		cfg = *ac.Lint
+	}
+	if len(cfg.Types) == 0 {
+		cfg.Types = []string{
+			"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert",
+		}
+	}
+	if cfg.MaxSubjectLength == 0 {
+		cfg.MaxSubjectLength = DefaultMaxSubjectLength
+	}
+	return cfg
+}
+
+// GetLintConfig returns the lint rules of commits to the repository. A commit of a
+// multi-app repository may touch any app, so it follows the combined rules of all
+// apps: the types and scopes any app allows, and the longest subject limit.
+func (c *Config) GetLintConfig() LintConfig {
+	var merged LintConfig
+	anyScope := false
+	for _, name := range slices.Sorted(maps.Keys(c.Apps)) {
+		app := c.Apps[name]
+		rules := app.GetLintConfig()
+		for _, typ := range rules.Types {
+			if !slices.Contains(merged.Types, typ) {
+				merged.Types = append(merged.Types, typ)
+			}
 		}
-		if cfg.Suffix == "" {
-			cfg.Suffix = "hotfix"
+		if len(rules.Scopes) == 0 {
+			anyScope = true
 		}
-		return cfg
+		for _, scope := range rules.Scopes {
+			if !slices.Contains(merged.Scopes, scope) {
+				merged.Scopes = append(merged.Scopes, scope)
+			}
+		}
+		merged.MaxSubjectLength = max(merged.MaxSubjectLength, rules.MaxSubjectLength)
 	}
-	// Return all defaults
-	return HotfixConfig{
-		BranchPrefix: "release/",
-		Suffix:       "hotfix",
+	if anyScope {
+		merged.Scopes = nil
 	}
+	return merged
 }
 
 // IsMultiApp returns true if this is a multi-app configuration
@@ -344,6 +692,28 @@ func (c *Config) GetAllAppConfigs() []*AppConfig {
 	return configs
 }
 
+// DetectAppFromDir returns the app whose dir contains dir, a slash-separated path
+// relative to the repository root. The app with the deepest matching dir wins; apps
+// without a dir never match. Returns empty string if no app matches.
+func (c *Config) DetectAppFromDir(dir string) string {
+	dir = path.Clean(dir)
+
+	var match, matchDir string
+	for name, app := range c.Apps {
+		if app.Dir == "" {
+			continue
+		}
+		appDir := path.Clean(filepath.ToSlash(app.Dir))
+		if dir != appDir && !strings.HasPrefix(dir, appDir+"/") && appDir != "." {
+			continue
+		}
+		if match == "" || len(appDir) > len(matchDir) || (len(appDir) == len(matchDir) && name < match) {
+			match, matchDir = name, appDir
+		}
+	}
+	return match
+}
+
 // DetectAppFromTag determines which app config to use based on tag prefix.
 // Returns empty string for single-app configs.
 func (c *Config) DetectAppFromTag(tag string) (string, error) {
@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadMultiAppConfig(t *testing.T) {
@@ -406,6 +408,25 @@ func TestAppConfig_GetHotfixConfig(t *testing.T) {
 			want: HotfixConfig{
 				BranchPrefix: "release/",
 				Suffix:       "hotfix",
+				Style:        HotfixStyleSuffix,
+			},
+		},
+		{
+			name:   "calver defaults to patch style",
+			config: AppConfig{Scheme: "calver", Hotfix: &HotfixConfig{Suffix: "fix"}},
+			want: HotfixConfig{
+				BranchPrefix: "release/",
+				Suffix:       "fix",
+				Style:        HotfixStylePatch,
+			},
+		},
+		{
+			name:   "explicit style wins over calver default",
+			config: AppConfig{Scheme: "calver", Hotfix: &HotfixConfig{Style: HotfixStyleSuffix}},
+			want: HotfixConfig{
+				BranchPrefix: "release/",
+				Suffix:       "hotfix",
+				Style:        HotfixStyleSuffix,
 			},
 		},
 	}
@@ -419,6 +440,9 @@ func TestAppConfig_GetHotfixConfig(t *testing.T) {
 			if got.Suffix != tt.want.Suffix {
 				t.Errorf("GetHotfixConfig().Suffix = %q, want %q", got.Suffix, tt.want.Suffix)
 			}
+			if tt.want.Style != "" && got.Style != tt.want.Style {
+				t.Errorf("GetHotfixConfig().Style = %q, want %q", got.Style, tt.want.Style)
+			}
 		})
 	}
 }
@@ -546,3 +570,434 @@ func TestConfig_ValidateAppTag(t *testing.T) {
 		})
 	}
 }
+
+// Changelog Config Tests
+
+func TestAppConfig_GetChangelogConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config AppConfig
+		want   ChangelogConfig
+	}{
+		{
+			name:   "nil changelog config returns defaults",
+			config: AppConfig{},
+			want:   ChangelogConfig{Mode: ChangelogModeCommits},
+		},
+		{
+			name:   "empty mode gets default",
+			config: AppConfig{Changelog: &ChangelogConfig{GroupByPR: true}},
+			want:   ChangelogConfig{Mode: ChangelogModeCommits, GroupByPR: true},
+		},
+		{
+			name:   "explicit first-parent mode",
+			config: AppConfig{Changelog: &ChangelogConfig{Mode: ChangelogModeFirstParent}},
+			want:   ChangelogConfig{Mode: ChangelogModeFirstParent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.GetChangelogConfig()
+			if got.Mode != tt.want.Mode {
+				t.Errorf("GetChangelogConfig().Mode = %q, want %q", got.Mode, tt.want.Mode)
+			}
+			if got.GroupByPR != tt.want.GroupByPR {
+				t.Errorf("GetChangelogConfig().GroupByPR = %v, want %v", got.GroupByPR, tt.want.GroupByPR)
+			}
+		})
+	}
+}
+
+func TestValidateChangelogMode(t *testing.T) {
+	cfg := AppConfig{
+		Scheme:        "semver",
+		Prefix:        "v",
+		DefaultBranch: "main",
+		Changelog:     &ChangelogConfig{Mode: "squash"},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error for invalid changelog mode")
+	}
+	if !strings.Contains(err.Error(), "invalid changelog mode") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg.Changelog.Mode = ChangelogModeFirstParent
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error for valid mode: %v", err)
+	}
+}
+
+func TestValidateChangelogExclude(t *testing.T) {
+	cfg := AppConfig{
+		Scheme:        "semver",
+		Prefix:        "v",
+		DefaultBranch: "main",
+		Changelog: &ChangelogConfig{
+			Exclude: ExcludeConfig{Subjects: []string{`^chore\(release\)`, "(unclosed"}},
+		},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error for invalid exclude pattern")
+	}
+	if !strings.Contains(err.Error(), "(unclosed") {
+		t.Errorf("error should name the invalid pattern: %v", err)
+	}
+
+	cfg.Changelog.Exclude.Subjects = cfg.Changelog.Exclude.Subjects[:1]
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error for valid patterns: %v", err)
+	}
+}
+
+func TestValidateChangelogIssues(t *testing.T) {
+	cfg := AppConfig{
+		Scheme:        "semver",
+		Prefix:        "v",
+		DefaultBranch: "main",
+		Changelog: &ChangelogConfig{
+			Issues: []IssueConfig{{Pattern: `PAY-\d+`, URL: "https://jira.example.com/browse/{id}"}},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error for valid issue pattern: %v", err)
+	}
+
+	for _, pattern := range []string{"", "PAY-("} {
+		cfg.Changelog.Issues = []IssueConfig{{Pattern: pattern}}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid changelog.issues pattern") {
+			t.Errorf("Validate() with pattern %q = %v, want invalid pattern error", pattern, err)
+		}
+	}
+}
+
+func TestValidateHotfixStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  string
+		hotfix  HotfixConfig
+		wantErr string
+	}{
+		{name: "patch style", scheme: "semver", hotfix: HotfixConfig{Style: "patch", Fallback: "four-part"}},
+		{name: "default style", scheme: "calver", hotfix: HotfixConfig{Suffix: "fix"}},
+		{name: "unknown style", scheme: "semver", hotfix: HotfixConfig{Style: "minor"}, wantErr: "invalid hotfix style"},
+		{name: "calver patch style", scheme: "calver", hotfix: HotfixConfig{Style: "patch"}},
+		{name: "unknown fallback", scheme: "semver", hotfix: HotfixConfig{Fallback: "x"}, wantErr: "invalid hotfix fallback"},
+		{name: "support window", scheme: "semver", hotfix: HotfixConfig{SupportWindow: "26w"}},
+		{name: "bad support window", scheme: "semver", hotfix: HotfixConfig{SupportWindow: "6 months"},
+			wantErr: "invalid hotfix support_window"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := AppConfig{
+				Scheme:        tt.scheme,
+				Prefix:        "v",
+				DefaultBranch: "main",
+				CalVerFormat:  "2006.01.02",
+				Hotfix:        &tt.hotfix,
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "90d", want: 90 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "0d", want: 0},
+		{in: "d", wantErr: true},
+		{in: "-3d", wantErr: true},
+		{in: "3 months", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMultiAppDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	configContent := `defaults:
+  scheme: semver
+  default_branch: main
+  hotfix:
+    branch_prefix: hotfix/
+    support_window: 26w
+  nodejs:
+    enabled: true
+
+api:
+  prefix: api/v
+  hotfix:
+    style: patch
+
+web:
+  extends: api
+  prefix: web/v
+  nodejs:
+    package_path: web/package.json
+
+worker:
+  extends: web
+  scheme: calver
+  calver_format: "2006.WW"
+  prefix: worker/v
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "forge.yaml"), []byte(configContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadFromDir() error = %v", err)
+	}
+	if len(cfg.Apps) != 3 {
+		t.Fatalf("Apps = %v, want api, web and worker", cfg.Apps)
+	}
+
+	tests := []struct {
+		app           string
+		scheme        string
+		hotfixStyle   string
+		packagePath   string
+		supportWindow string
+	}{
+		{app: "api", scheme: "semver", hotfixStyle: "patch"},
+		{app: "web", scheme: "semver", hotfixStyle: "patch", packagePath: "web/package.json"},
+		{app: "worker", scheme: "calver", hotfixStyle: "patch", packagePath: "web/package.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.app, func(t *testing.T) {
+			app := cfg.Apps[tt.app]
+			if app.Scheme != tt.scheme || app.DefaultBranch != "main" || app.Extends != "" {
+				t.Errorf("app = %+v, want scheme %s on main", app, tt.scheme)
+			}
+			if app.Hotfix == nil || app.Hotfix.Style != tt.hotfixStyle ||
+				app.Hotfix.BranchPrefix != "hotfix/" || app.Hotfix.SupportWindow != "26w" {
+				t.Errorf("hotfix = %+v, want merged with the defaults", app.Hotfix)
+			}
+			if !app.NodeJS.Enabled || app.NodeJS.PackagePath != tt.packagePath {
+				t.Errorf("nodejs = %+v, want enabled with package path %q", app.NodeJS, tt.packagePath)
+			}
+		})
+	}
+}
+
+func TestLoadMultiAppDefaultsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown app",
+			content: "defaults:\n  scheme: semver\napi:\n  extends: core\n  prefix: api/v\n",
+			wantErr: `app "api" extends unknown app core`,
+		},
+		{
+			name:    "cycle",
+			content: "api:\n  extends: web\n  prefix: api/v\nweb:\n  extends: api\n  prefix: web/v\n",
+			wantErr: "apps extend each other in a cycle",
+		},
+		{
+			name:    "extends the defaults block",
+			content: "defaults:\n  scheme: semver\napi:\n  extends: defaults\n  prefix: api/v\n",
+			wantErr: `extends unknown app defaults`,
+		},
+		{
+			name:    "missing setting after merging",
+			content: "defaults:\n  scheme: semver\napi:\n  prefix: api/v\n",
+			wantErr: "invalid config for app 'api': default_branch is required",
+		},
+		{
+			name:    "single app",
+			content: "scheme: semver\nprefix: v\ndefault_branch: main\nextends: api\n",
+			wantErr: "extends is only supported in multi-app configs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "forge.yaml"), []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadFromDir(tmpDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFromDir() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindRoot(t *testing.T) {
+	repo := t.TempDir()
+	for _, dir := range []string{".git", "services/api/internal", "tools/gen"} {
+		if err := os.MkdirAll(filepath.Join(repo, dir), 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"forge.yaml", "tools/.forge.yaml"} {
+		if err := os.WriteFile(filepath.Join(repo, file), []byte("scheme: semver\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outside := t.TempDir()
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{name: "repository root", dir: repo, want: repo},
+		{name: "app directory", dir: filepath.Join(repo, "services/api/internal"), want: repo},
+		{name: "nested config", dir: filepath.Join(repo, "tools/gen"), want: filepath.Join(repo, "tools")},
+		{name: "outside a repository", dir: outside, want: outside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindRoot(tt.dir)
+			if err != nil {
+				t.Fatalf("FindRoot() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindRoot(%s) = %s, want %s", tt.dir, got, tt.want)
+			}
+		})
+	}
+
+	// The search stops at the git top-level even if a parent has a config file
+	sub := filepath.Join(repo, "vendor", "lib")
+	for _, dir := range []string{filepath.Join(sub, ".git"), filepath.Join(sub, "src")} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := FindRoot(filepath.Join(sub, "src")); got != sub {
+		t.Errorf("FindRoot() in a nested repository = %s, want %s", got, sub)
+	}
+}
+
+func TestConfig_DetectAppFromDir(t *testing.T) {
+	cfg := &Config{Apps: map[string]AppConfig{
+		"api":    {Prefix: "api/v", Dir: "services/api"},
+		"admin":  {Prefix: "admin/v", Dir: "services/api/admin/"},
+		"worker": {Prefix: "worker/v", Dir: "services/worker"},
+		"docs":   {Prefix: "docs/v"},
+	}}
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: "services/api", want: "api"},
+		{dir: "services/api/internal/db", want: "api"},
+		{dir: "services/api/admin/ui", want: "admin"},
+		{dir: "services/worker", want: "worker"},
+		{dir: "services/apiv2", want: ""},
+		{dir: "services", want: ""},
+		{dir: ".", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			if got := cfg.DetectAppFromDir(tt.dir); got != tt.want {
+				t.Errorf("DetectAppFromDir(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDir(t *testing.T) {
+	tests := []struct {
+		dir     string
+		wantErr bool
+	}{
+		{dir: "services/api"},
+		{dir: "./services/api/"},
+		{dir: "."},
+		{dir: "../other", wantErr: true},
+		{dir: "services/../..", wantErr: true},
+		{dir: "/srv/api", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			cfg := AppConfig{Scheme: "semver", Prefix: "v", DefaultBranch: "main", Dir: tt.dir}
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigGetLintConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		apps map[string]AppConfig
+		want LintConfig
+	}{
+		{
+			name: "single app",
+			apps: map[string]AppConfig{"main": {Lint: &LintConfig{Types: []string{"feat", "fix"}, Scopes: []string{"api"}}}},
+			want: LintConfig{Types: []string{"feat", "fix"}, Scopes: []string{"api"}, MaxSubjectLength: 72},
+		},
+		{
+			name: "rules of all apps",
+			apps: map[string]AppConfig{
+				"api":    {Lint: &LintConfig{Types: []string{"feat", "fix"}, Scopes: []string{"api"}}},
+				"worker": {Lint: &LintConfig{Types: []string{"fix", "perf"}, Scopes: []string{"jobs"}, MaxSubjectLength: 100}},
+			},
+			want: LintConfig{Types: []string{"feat", "fix", "perf"}, Scopes: []string{"api", "jobs"}, MaxSubjectLength: 100},
+		},
+		{
+			name: "app allowing any scope",
+			apps: map[string]AppConfig{
+				"api":    {Lint: &LintConfig{Types: []string{"feat"}, Scopes: []string{"api"}}},
+				"worker": {Lint: &LintConfig{Types: []string{"feat"}}},
+			},
+			want: LintConfig{Types: []string{"feat"}, MaxSubjectLength: 72},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Apps: tt.apps}
+			if got := cfg.GetLintConfig(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLintConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultsKey is the top-level block of a multi-app config whose settings every app
+// inherits.
+const defaultsKey = "defaults"
+
+// resolveApps builds the app configs of a raw multi-app config. Each app is deep
+// merged over the app it extends, or over the defaults block if it extends none:
+// nested blocks such as hotfix are merged key by key, while scalars and lists of the
+// app replace inherited ones.
+func resolveApps(raw map[string]any) (map[string]AppConfig, error) {
+	defaults := map[string]any{}
+	if value, ok := raw[defaultsKey]; ok && value != nil {
+		if defaults, ok = value.(map[string]any); !ok {
+			return nil, fmt.Errorf("the defaults block must be a mapping of app settings\n\n" +
+				"  Fix your forge.yaml:\n" +
+				"    defaults:\n" +
+				"      scheme: semver\n" +
+				"      default_branch: main")
+		}
+		if _, ok := defaults["extends"]; ok {
+			return nil, fmt.Errorf("the defaults block cannot extend an app")
+		}
+	}
+
+	r := &resolver{raw: raw, defaults: defaults, resolved: make(map[string]map[string]any)}
+	apps := make(map[string]AppConfig)
+	for name := range raw {
+		if name == "defaultApp" || name == defaultsKey {
+			continue
+		}
+
+		settings, err := r.resolve(name, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := yaml.Marshal(settings)
+		if err != nil {
+			return nil, fmt.Errorf("app %q: %w", name, err)
+		}
+		var app AppConfig
+		if err = yaml.Unmarshal(data, &app); err != nil {
+			return nil, fmt.Errorf("app %q: %w", name, err)
+		}
+		apps[name] = app
+	}
+	return apps, nil
+}
+
+// resolver resolves the inheritance of the apps in a raw config, once per app.
+type resolver struct {
+	raw      map[string]any
+	defaults map[string]any
+	resolved map[string]map[string]any
+}
+
+// resolve returns the settings of app merged over everything it inherits. chain holds
+// the apps whose resolution led here, to report cycles.
+func (r *resolver) resolve(app string, chain []string) (map[string]any, error) {
+	if settings, ok := r.resolved[app]; ok {
+		return settings, nil
+	}
+	if slices.Contains(chain, app) {
+		return nil, fmt.Errorf("apps extend each other in a cycle: %s -> %s", strings.Join(chain, " -> "), app)
+	}
+
+	settings, ok := r.raw[app].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("app %q must be a mapping of app settings", app)
+	}
+
+	base := r.defaults
+	if value, ok := settings["extends"]; ok {
+		parent, isString := value.(string)
+		if _, exists := r.raw[parent]; !isString || !exists || parent == "defaultApp" || parent == defaultsKey {
+			return nil, fmt.Errorf("app %q extends unknown app %v\n\n"+
+				"  extends must name another app in forge.yaml:\n"+
+				"    %s:\n"+
+				"      extends: api\n"+
+				"      prefix: %s/v",
+				app, value, app, app)
+		}
+
+		var err error
+		if base, err = r.resolve(parent, append(chain, app)); err != nil {
+			return nil, err
+		}
+	}
+
+	merged := mergeSettings(base, settings)
+	delete(merged, "extends")
+	r.resolved[app] = merged
+	return merged, nil
+}
+
+// mergeSettings returns base with override merged in, leaving both unchanged. Mappings
+// present in both are merged recursively; any other value of override replaces the one
+// of base.
+func mergeSettings(base, override map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeSettings(baseMap, overrideMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
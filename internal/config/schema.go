@@ -0,0 +1,241 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SchemaURL is where the JSON Schema of forge.yaml is published. forge init points
+// editors at it with a yaml-language-server modeline.
+const SchemaURL = "https://raw.githubusercontent.com/alexjoedt/forge/main/schema/forge.schema.json"
+
+// schemaHint adds what the struct types can't express to the schema of a field.
+type schemaHint struct {
+	description string
+	enum        []string
+	examples    []string
+	required    bool
+}
+
+// schemaHints are keyed by struct type name and yaml key, e.g. "AppConfig.scheme".
+// Fields without a hint get a schema for their type only.
+//
+//nolint:gochecknoglobals
+var schemaHints = map[string]schemaHint{
+	"AppConfig.scheme": {
+		description: "Versioning scheme: semver (v1.2.3) or calver (2025.44.1)",
+		enum:        []string{"semver", "calver"},
+	},
+	"AppConfig.prefix": {
+		description: "Git tag prefix, e.g. v for v1.2.3 or api/v for monorepo tags like api/v1.2.3",
+		examples:    []string{"v", "api/v"},
+	},
+	"AppConfig.default_branch": {
+		description: "Default branch name",
+		examples:    []string{"main", "master"},
+	},
+	"AppConfig.calver_format": {
+		description: "CalVer layout, required by and only allowed with the calver scheme. WW is the ISO week",
+		examples:    []string{"2006.WW", "2006.01.02", "2006.01"},
+	},
+	"AppConfig.pre":       {description: "[ALPHA] Prerelease identifier"},
+	"AppConfig.meta":      {description: "[ALPHA] Build metadata"},
+	"AppConfig.hotfix":    {description: "Hotfix workflow settings"},
+	"AppConfig.nodejs":    {description: "Node.js package.json version sync"},
+	"AppConfig.changelog": {description: "Changelog generation settings"},
+	"AppConfig.lint":      {description: "Conventional Commit lint rules"},
+	"AppConfig.extends":   {description: "Multi-app only: app whose settings this app inherits"},
+	"AppConfig.dir": {
+		description: "App directory relative to the repository root. Commands run inside it select the app " +
+			"when --app is not given",
+		examples: []string{"services/api"},
+	},
+
+	"HotfixConfig.branch_prefix": {description: "Hotfix branch name prefix, the tag is appended. Default: release/"},
+	"HotfixConfig.suffix":        {description: "Version suffix of hotfix tags. Default: hotfix"},
+	"HotfixConfig.style": {
+		description: "suffix creates v1.5.0-hotfix.1, patch the next free patch version v1.5.1. " +
+			"Default: suffix, patch for calver",
+		enum: []string{HotfixStyleSuffix, HotfixStylePatch},
+	},
+	"HotfixConfig.fallback": {
+		description: "Naming of patch-style hotfixes whose patch version is taken: " +
+			"metadata (v1.5.0+hotfix.1) or four-part (v1.5.0.1). Default: metadata",
+		enum: []string{HotfixFallbackMetadata, HotfixFallbackFourPart},
+	},
+	"HotfixConfig.archive":        {description: "Keep finished hotfix branches as tags"},
+	"HotfixConfig.archive_prefix": {description: "Tag name prefix of archived hotfix branches. Default: archive/"},
+	"HotfixConfig.support_window": {
+		description: "How long a release gets hotfixes, in days or weeks",
+		examples:    []string{"180d", "26w"},
+	},
+
+	"ChangelogConfig.mode": {
+		description: "commits lists every non-merge commit, first-parent uses merge commit / PR titles. " +
+			"Default: commits",
+		enum: []string{ChangelogModeCommits, ChangelogModeFirstParent},
+	},
+	"ChangelogConfig.group_by_pr":  {description: "Collapse the commits of a pull request into one entry"},
+	"ChangelogConfig.contributors": {description: "Add a contributors section"},
+	"ChangelogConfig.co_authors":   {description: "Also credit Co-authored-by trailers"},
+	"ChangelogConfig.bots": {
+		description: "Author names or emails never credited as contributors, * matches anything. " +
+			"Default: [\"*[bot]\"]",
+	},
+	"ChangelogConfig.package": {description: "Package metadata of the debian and rpm formats"},
+	"ChangelogConfig.exclude": {description: "Leave matching commits out of changelogs"},
+	"ChangelogConfig.issues":  {description: "Issue tracker references to extract and link"},
+
+	"IssueConfig.pattern": {
+		description: "Regular expression; the first group is the ID if present",
+		examples:    []string{`PAY-\d+`},
+		required:    true,
+	},
+	"IssueConfig.url": {
+		description: "Link template, {id} is replaced with the issue ID",
+		examples:    []string{"https://example.atlassian.net/browse/{id}"},
+	},
+
+	"ExcludeConfig.subjects": {description: "Regular expressions matched against the subject line"},
+	"ExcludeConfig.authors":  {description: "Author names or emails, * matches anything"},
+	"ExcludeConfig.scopes":   {description: "Conventional Commit scopes"},
+	"ExcludeConfig.paths":    {description: "Commits that only touch these paths are excluded"},
+
+	"LintConfig.types":              {description: "Allowed commit types. Default: the Conventional Commits types"},
+	"LintConfig.scopes":             {description: "Allowed scopes. Empty allows any scope"},
+	"LintConfig.max_subject_length": {description: "Maximum subject line length. Default: 72"},
+
+	"NodeJSConfig.enabled":      {description: "Update the version in package.json on release"},
+	"NodeJSConfig.package_path": {description: "Path to package.json relative to the repository root"},
+}
+
+// Schema returns the JSON Schema of forge.yaml, generated from the config types. It
+// accepts both a single app configuration and the multi-app layout of a defaultApp, a
+// defaults block and one configuration per app. Every app that sets a scheme other than
+// calver is rejected with a calver_format. Apps of multi-app configs may inherit any
+// setting, so only single app configurations are checked for required settings.
+func Schema() map[string]any {
+	appRef := map[string]any{"$ref": "#/definitions/app"}
+	single := map[string]any{
+		"required": []string{"scheme", "prefix", "default_branch"},
+		// calver_format is required by calver
+		"if": map[string]any{
+			"properties": map[string]any{"scheme": map[string]any{"const": "calver"}},
+			"required":   []string{"scheme"},
+		},
+		"then": map[string]any{"required": []string{"calver_format"}},
+		"not":  map[string]any{"required": []string{"extends"}},
+	}
+
+	// calver_format is a mistake on an app that sets another scheme. Apps without a
+	// scheme may inherit calver, so they can set it.
+	app := objectSchema(reflect.TypeFor[AppConfig]())
+	app["if"] = map[string]any{
+		"properties": map[string]any{"scheme": map[string]any{"not": map[string]any{"const": "calver"}}},
+		"required":   []string{"scheme"},
+	}
+	app["then"] = map[string]any{"not": map[string]any{"required": []string{"calver_format"}}}
+
+	return map[string]any{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$id":         SchemaURL,
+		"title":       "forge.yaml",
+		"description": "Configuration of the forge release tool",
+		"oneOf": []any{
+			map[string]any{
+				"description": "Single app configuration",
+				"allOf":       []any{appRef, single},
+			},
+			map[string]any{
+				"type":        "object",
+				"description": "Multi-app configuration: one app configuration per key",
+				"properties": map[string]any{
+					"defaultApp": map[string]any{
+						"type":        "string",
+						"description": "App used when no --app flag is given",
+					},
+					defaultsKey: map[string]any{
+						"$ref":        "#/definitions/app",
+						"description": "Settings every app inherits unless it extends another app",
+					},
+				},
+				"additionalProperties": appRef,
+			},
+		},
+		"definitions": map[string]any{"app": app},
+	}
+}
+
+// SchemaJSON returns the indented JSON encoding of Schema.
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// typeSchema returns the schema of a config field type.
+func typeSchema(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return objectSchema(t)
+	default:
+		panic(fmt.Sprintf("config: no schema for field type %s", t))
+	}
+}
+
+// objectSchema returns the schema of a config struct: its yaml keys, with unknown
+// keys rejected so typos are reported.
+func objectSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := typeSchema(field.Type)
+		hint := schemaHints[t.Name()+"."+name]
+		if hint.description != "" {
+			property["description"] = hint.description
+		}
+		if len(hint.enum) > 0 {
+			property["enum"] = hint.enum
+		}
+		if len(hint.examples) > 0 {
+			property["examples"] = hint.examples
+		}
+		if hint.required {
+			required = append(required, name)
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
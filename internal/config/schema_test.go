@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaAt follows keys through nested schema objects.
+func schemaAt(t *testing.T, schema map[string]any, keys ...string) any {
+	t.Helper()
+
+	var node any = schema
+	for _, key := range keys {
+		m, ok := node.(map[string]any)
+		if !ok {
+			t.Fatalf("schema has no object at %q", key)
+		}
+		node = m[key]
+	}
+	return node
+}
+
+func TestSchema(t *testing.T) {
+	schema := Schema()
+	app := []string{"definitions", "app"}
+
+	tests := []struct {
+		name string
+		path []string
+		want any
+	}{
+		{name: "scheme enum", path: []string{"properties", "scheme", "enum"}, want: []string{"semver", "calver"}},
+		{name: "extends", path: []string{"properties", "extends", "type"}, want: "string"},
+		{name: "unknown keys rejected", path: []string{"additionalProperties"}, want: false},
+		{
+			name: "nested unknown keys rejected",
+			path: []string{"properties", "hotfix", "additionalProperties"},
+			want: false,
+		},
+		{
+			name: "hotfix style enum",
+			path: []string{"properties", "hotfix", "properties", "style", "enum"},
+			want: []string{HotfixStyleSuffix, HotfixStylePatch},
+		},
+		{
+			name: "nodejs fields",
+			path: []string{"properties", "nodejs", "properties", "package_path", "type"},
+			want: "string",
+		},
+		{
+			name: "lint integer",
+			path: []string{"properties", "lint", "properties", "max_subject_length", "type"},
+			want: "integer",
+		},
+		{
+			name: "calver_format only with calver",
+			path: []string{"then", "not", "required"},
+			want: []string{"calver_format"},
+		},
+		{
+			name: "issue pattern required",
+			path: []string{"properties", "changelog", "properties", "issues", "items", "required"},
+			want: []string{"pattern"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schemaAt(t, schema, append(app, tt.path...)...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schema at %v = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	// Single app configs need every required setting themselves
+	single := schemaAt(t, schema, "oneOf").([]any)[0].(map[string]any)
+	rules := schemaAt(t, single, "allOf").([]any)[1].(map[string]any)
+	for _, tt := range []struct {
+		path []string
+		want []string
+	}{
+		{path: []string{"required"}, want: []string{"scheme", "prefix", "default_branch"}},
+		{path: []string{"then", "required"}, want: []string{"calver_format"}},
+		{path: []string{"not", "required"}, want: []string{"extends"}},
+	} {
+		if got := schemaAt(t, rules, tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("single app schema at %v = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	// Apps of multi-app configs inherit from the defaults block
+	multi := schemaAt(t, schema, "oneOf").([]any)[1].(map[string]any)
+	for _, path := range [][]string{{"additionalProperties", "$ref"}, {"properties", "defaults", "$ref"}} {
+		if got := schemaAt(t, multi, path...); got != "#/definitions/app" {
+			t.Errorf("multi-app schema at %v = %v, want app reference", path, got)
+		}
+	}
+}
+
+// TestSchemaDefaults checks that the configurations forge init writes only use keys
+// the schema knows, and no calver_format on semver apps.
+func TestSchemaDefaults(t *testing.T) {
+	properties := schemaAt(t, Schema(), "definitions", "app", "properties").(map[string]any)
+
+	for name, app := range DefaultMulti().Apps {
+		data, err := yaml.Marshal(app)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw map[string]any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			t.Fatal(err)
+		}
+
+		for key := range raw {
+			if _, ok := properties[key]; !ok {
+				t.Errorf("app %s: key %q is not in the schema", name, key)
+			}
+		}
+		if _, ok := raw["calver_format"]; ok && app.Scheme != "calver" {
+			t.Errorf("app %s: calver_format set on a %s app", name, app.Scheme)
+		}
+	}
+}
+
+// TestSchemaFile keeps the published schema in sync with the config types. Regenerate
+// it with: go run . config schema -o schema/forge.schema.json
+func TestSchemaFile(t *testing.T) {
+	want, err := SchemaJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join("..", "..", "schema", "forge.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("schema/forge.schema.json is out of date, run: go run . config schema -o schema/forge.schema.json")
+	}
+}
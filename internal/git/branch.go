@@ -0,0 +1,117 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/alexjoedt/forge/internal/log"
+)
+
+// ErrMergeConflict is returned when a merge stops on conflicts. The merge is left in
+// progress so the conflicts can be resolved and committed.
+var ErrMergeConflict = errors.New("merge stopped on conflicts")
+
+// MergeBranch merges branch into the checked out branch with a merge commit, even if
+// a fast-forward is possible. Returns ErrMergeConflict if the merge stops on conflicts.
+func MergeBranch(ctx context.Context, repoDir, branch, message string) error {
+	return Open(ctx, repoDir).Merge(ctx, branch, message)
+}
+
+// DeleteBranch deletes a local branch, whether or not it is merged.
+func DeleteBranch(ctx context.Context, repoDir, branch string) error {
+	if err := Open(ctx, repoDir).DeleteBranch(ctx, branch); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Debugf("deleted branch: %s", branch)
+	return nil
+}
+
+// RemoteBranchExists reports whether the remote has branch. A repository without the
+// remote has no remote branches.
+func RemoteBranchExists(ctx context.Context, repoDir, remote, branch string) (bool, error) {
+	branches, err := Open(ctx, repoDir).RemoteBranches(ctx, remote)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(branches, branch), nil
+}
+
+// DeleteRemoteBranch deletes branch on the remote.
+func DeleteRemoteBranch(ctx context.Context, repoDir, remote, branch string) error {
+	if err := Open(ctx, repoDir).Push(ctx, remote, []string{":" + branch}, false); err != nil {
+		return fmt.Errorf("delete %s on %s: %w", branch, remote, err)
+	}
+	log.FromContext(ctx).Debugf("deleted branch %s on %s", branch, remote)
+	return nil
+}
+
+// PushBranch pushes branch to the remote.
+func PushBranch(ctx context.Context, repoDir, remote, branch string) error {
+	return Open(ctx, repoDir).Push(ctx, remote, []string{branch}, false)
+}
+
+// RemoteTracking compares branch with its remote-tracking branch remote/branch as of the
+// last fetch. Returns the commits only on branch, the commits only on the remote, and
+// whether the remote-tracking branch exists at all.
+func RemoteTracking(ctx context.Context, repoDir, remote, branch string) (int, int, bool, error) {
+	tracking := "refs/remotes/" + remote + "/" + branch
+	if _, err := Open(ctx, repoDir).ResolveRef(ctx, tracking); errors.Is(err, ErrUnknownRef) {
+		return 0, 0, false, nil
+	} else if err != nil {
+		return 0, 0, false, err
+	}
+
+	ahead, err := CountCommits(ctx, repoDir, tracking+".."+branch)
+	if err != nil {
+		return 0, 0, true, err
+	}
+	behind, err := CountCommits(ctx, repoDir, branch+".."+tracking)
+	if err != nil {
+		return 0, 0, true, err
+	}
+	return ahead, behind, true, nil
+}
+
+// CountCommits returns the number of commits in a revision range like "v1.5.0..HEAD".
+func CountCommits(ctx context.Context, repoDir, revRange string) (int, error) {
+	count := 0
+	for _, err := range Open(ctx, repoDir).Log(ctx, LogOptions{Range: revRange}) {
+		if err != nil {
+			return 0, fmt.Errorf("count commits in %s: %w", revRange, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// RefDate returns when ref was created: the tagger date of an annotated tag, or the
+// committer date of a commit.
+func RefDate(ctx context.Context, repoDir, ref string) (time.Time, error) {
+	repo := Open(ctx, repoDir)
+
+	tags, err := repo.Tags(ctx, ref)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, tag := range tags {
+		if tag.Name != ref {
+			continue
+		}
+		date := tag.CommitDate
+		if tag.Annotated {
+			date = tag.TaggerDate
+		}
+		return time.Parse(DateFormat, date)
+	}
+
+	for entry, err := range repo.Log(ctx, LogOptions{Range: ref}) {
+		if err != nil {
+			return time.Time{}, fmt.Errorf("read date of %s: %w", ref, err)
+		}
+		return entry.CommitDate, nil
+	}
+	return time.Time{}, fmt.Errorf("read date of %s: no commits", ref)
+}
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	mainBranch := git("branch", "--show-current")
+	commit("a.txt", "a\n", "feat: a")
+	git("checkout", "-q", "-b", "release/v1.0.0")
+	fix := commit("b.txt", "b\n", "fix: b")
+	git("checkout", "-q", "-b", "conflict")
+	commit("a.txt", "conflict\n", "fix: a on hotfix")
+
+	must(t, NewRepository(dir).Checkout(ctx, mainBranch))
+	commit("a.txt", "main\n", "feat: a on main")
+
+	t.Run("merge commit", func(t *testing.T) {
+		must(t, MergeBranch(ctx, dir, "release/v1.0.0", "Merge hotfix branch 'release/v1.0.0'"))
+		if parents := git("log", "-1", "--format=%P"); len(parents) != 81 {
+			t.Errorf("HEAD parents = %q, want a merge commit", parents)
+		}
+		git("merge-base", "--is-ancestor", fix, "HEAD")
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		if err := MergeBranch(ctx, dir, "conflict", "Merge"); !errors.Is(err, ErrMergeConflict) {
+			t.Fatalf("MergeBranch() error = %v, want ErrMergeConflict", err)
+		}
+		git("merge", "--abort")
+	})
+}
+
+func TestDeleteRemoteBranch(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	commit("a.txt", "a\n", "feat: a")
+	git("branch", "release/v1.0.0")
+
+	exists, err := RemoteBranchExists(ctx, dir, "origin", "release/v1.0.0")
+	must(t, err)
+	if exists {
+		t.Fatal("RemoteBranchExists() = true without an origin remote")
+	}
+
+	remote := filepath.Join(t.TempDir(), "origin.git")
+	git("init", "-q", "--bare", remote)
+	git("remote", "add", "origin", remote)
+	must(t, PushBranch(ctx, dir, "origin", "release/v1.0.0"))
+
+	exists, err = RemoteBranchExists(ctx, dir, "origin", "release/v1.0.0")
+	must(t, err)
+	if !exists {
+		t.Fatal("RemoteBranchExists() = false after push")
+	}
+
+	must(t, DeleteRemoteBranch(ctx, dir, "origin", "release/v1.0.0"))
+	must(t, DeleteBranch(ctx, dir, "release/v1.0.0"))
+
+	exists, err = RemoteBranchExists(ctx, dir, "origin", "release/v1.0.0")
+	must(t, err)
+	if exists {
+		t.Error("RemoteBranchExists() = true after delete")
+	}
+	if branches, _ := ListBranches(ctx, dir); len(branches) != 1 {
+		t.Errorf("ListBranches() = %v, want only the default branch", branches)
+	}
+}
+
+func TestRemoteTracking(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	commit("a.txt", "a\n", "feat: a")
+	git("branch", "release/v1.0.0")
+
+	_, _, exists, err := RemoteTracking(ctx, dir, "origin", "release/v1.0.0")
+	must(t, err)
+	if exists {
+		t.Fatal("RemoteTracking() exists = true without an origin remote")
+	}
+
+	remote := filepath.Join(t.TempDir(), "origin.git")
+	git("init", "-q", "--bare", remote)
+	git("remote", "add", "origin", remote)
+	git("push", "-q", "origin", "release/v1.0.0")
+
+	must(t, NewRepository(dir).Checkout(ctx, "release/v1.0.0"))
+	commit("b.txt", "b\n", "fix: b")
+	commit("c.txt", "c\n", "fix: c")
+
+	ahead, behind, exists, err := RemoteTracking(ctx, dir, "origin", "release/v1.0.0")
+	must(t, err)
+	if !exists || ahead != 2 || behind != 0 {
+		t.Errorf("RemoteTracking() = %d, %d, %v, want 2, 0, true", ahead, behind, exists)
+	}
+
+	count, err := CountCommits(ctx, dir, "origin/release/v1.0.0..release/v1.0.0")
+	must(t, err)
+	if count != 2 {
+		t.Errorf("CountCommits() = %d, want 2", count)
+	}
+
+	git("tag", "-a", "v1.0.1", "-m", "Release v1.0.1")
+	for _, ref := range []string{"v1.0.1", "release/v1.0.0", "HEAD"} {
+		date, dateErr := RefDate(ctx, dir, ref)
+		must(t, dateErr)
+		if time.Since(date) > time.Hour {
+			t.Errorf("RefDate(%s) = %v, want about now", ref, date)
+		}
+	}
+}
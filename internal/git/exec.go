@@ -0,0 +1,521 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+// execRepository is the Repository that runs the git binary in a directory.
+type execRepository struct {
+	dir string
+}
+
+// NewRepository returns the Repository that runs git in repoDir.
+func NewRepository(repoDir string) Repository {
+	return &execRepository{dir: repoDir}
+}
+
+// git runs a git command in the repository.
+func (r *execRepository) git(ctx context.Context, args ...string) run.Result {
+	return run.CmdInDir(ctx, r.dir, "git", args...)
+}
+
+// Field and record separators of the for-each-ref and log formats; messages may
+// contain newlines.
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// tagRefFormat prints the fields of TagRef. The %(*...) atoms describe the tagged
+// commit of an annotated tag and are empty for lightweight tags, which point at the
+// commit directly.
+//
+//nolint:gochecknoglobals // fixed for-each-ref format
+var tagRefFormat = strings.Join([]string{
+	"%(refname:strip=2)",
+	"%(objecttype)",
+	"%(objectname)",
+	"%(*objectname)",
+	"%(taggerdate:iso)",
+	"%(committerdate:iso)",
+	"%(*committerdate:iso)",
+	"%(subject)",
+	"%(*subject)",
+	"%(contents)",
+}, "%1f") + "%1e"
+
+// Tags loads all tags starting with prefix with a single git for-each-ref call.
+func (r *execRepository) Tags(ctx context.Context, prefix string) ([]TagRef, error) {
+	// for-each-ref globs don't descend into slashes, but a pattern ending in a slash
+	// matches everything below it, so select the deepest directory of the prefix
+	pattern := "refs/tags/" + prefix[:strings.LastIndex(prefix, "/")+1]
+	result := r.git(ctx, "for-each-ref", "--format="+tagRefFormat, pattern)
+	if !result.Success() {
+		if strings.Contains(result.Stderr, "not a git repository") {
+			return nil, fmt.Errorf("not a git repository or git not available: %s", strings.TrimSpace(result.Stderr))
+		}
+		return nil, fmt.Errorf("failed to list tags: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	var tags []TagRef
+	for _, record := range strings.Split(result.Stdout, recordSep) {
+		fields := strings.Split(strings.TrimPrefix(record, "\n"), fieldSep)
+		if len(fields) != 10 || !strings.HasPrefix(fields[0], prefix) {
+			continue
+		}
+		ref := TagRef{
+			Name:       fields[0],
+			Commit:     fields[2],
+			CommitDate: fields[5],
+			Subject:    fields[7],
+		}
+		if fields[1] == "tag" {
+			ref.Annotated = true
+			ref.Commit = fields[3]
+			ref.TaggerDate = fields[4]
+			ref.CommitDate = fields[6]
+			ref.Subject = fields[8]
+			ref.Annotation = strings.TrimSpace(fields[9])
+		}
+		tags = append(tags, ref)
+	}
+	return tags, nil
+}
+
+// MergedTags lists the tags reachable from ref.
+func (r *execRepository) MergedTags(ctx context.Context, ref string) ([]string, error) {
+	result := r.git(ctx, "tag", "-l", "--merged", ref)
+	if !result.Success() {
+		return nil, fmt.Errorf("list tags reachable from %s: %s", ref, strings.TrimSpace(result.Stderr))
+	}
+	return strings.Fields(result.Stdout), nil
+}
+
+// ContainingTags lists the tags that contain commit.
+func (r *execRepository) ContainingTags(ctx context.Context, commit string) ([]string, error) {
+	result := r.git(ctx, "tag", "-l", "--contains", commit)
+	if !result.Success() {
+		return nil, fmt.Errorf("list tags containing %s: %s", commit, strings.TrimSpace(result.Stderr))
+	}
+	return strings.Fields(result.Stdout), nil
+}
+
+// CreateTag creates an annotated tag, replacing an existing one with force.
+func (r *execRepository) CreateTag(ctx context.Context, name, target, message string, force bool) error {
+	args := []string{"tag", "-a", name, target, "-m", message}
+	if force {
+		args = append(args, "-f")
+	}
+	return r.git(ctx, args...).MustSucceed("create tag")
+}
+
+// ResolveRef resolves ref to a full commit hash.
+func (r *execRepository) ResolveRef(ctx context.Context, ref string) (string, error) {
+	result := r.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if !result.Success() {
+		if result.ExitCode == 1 && result.Stderr == "" {
+			return "", fmt.Errorf("%w: %s", ErrUnknownRef, ref)
+		}
+		return "", fmt.Errorf("cannot resolve ref %q: %s", ref, strings.TrimSpace(result.Stderr))
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// logFormat emits one record per commit: hash, short hash, author name and email
+// (mailmap-aware), author date, committer date, parent hashes, subject and body.
+// Records are NUL-terminated via git log -z, so neither separators in subjects nor
+// arbitrary body content can break parsing.
+const logFormat = "%H%x1f%h%x1f%aN%x1f%aE%x1f%aI%x1f%cI%x1f%P%x1f%s%x1f%b"
+
+const (
+	// logFields is the number of fields in each log record (see logFormat).
+	logFields = 9
+	// maxRecordSize bounds the size of a single commit record (mostly its body).
+	maxRecordSize = 16 << 20
+	// initialRecordBuffer is the scanner buffer size allocated up front.
+	initialRecordBuffer = 64 << 10
+)
+
+// errStopIteration signals that the consumer stopped ranging over commits early.
+//
+//nolint:gochecknoglobals // sentinel error
+var errStopIteration = errors.New("iteration stopped")
+
+// Log streams git log. Commits are parsed while git is still producing output, so
+// the full log is never held in memory. Stopping the iteration early terminates git.
+func (r *execRepository) Log(ctx context.Context, opts LogOptions) iter.Seq2[LogEntry, error] {
+	return func(yield func(LogEntry, error) bool) {
+		var files map[string][]string
+		if opts.Files {
+			var err error
+			if files, err = r.changedFiles(ctx, opts); err != nil {
+				yield(LogEntry{}, err)
+				return
+			}
+		}
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		args := append([]string{"log", opts.Range, "-z", "--pretty=format:" + logFormat}, logFlags(opts)...)
+		result := run.StreamInDir(streamCtx, r.dir, func(out io.Reader) error {
+			scanner := bufio.NewScanner(out)
+			scanner.Buffer(make([]byte, 0, initialRecordBuffer), maxRecordSize)
+			scanner.Split(scanRecords)
+
+			for scanner.Scan() {
+				entry, err := parseLogRecord(scanner.Text())
+				entry.Files = files[entry.Hash]
+				if !yield(entry, err) {
+					cancel()
+					return errStopIteration
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				return fmt.Errorf("read git log: %w", err)
+			}
+			return nil
+		}, "git", args...)
+
+		switch {
+		case errors.Is(result.Err, errStopIteration):
+			return
+		case result.Err != nil && result.Stderr == "":
+			yield(LogEntry{}, fmt.Errorf("git log failed: %w", result.Err))
+		case !result.Success():
+			yield(LogEntry{}, fmt.Errorf("git log failed: %s", strings.TrimSpace(result.Stderr)))
+		}
+	}
+}
+
+// logFlags returns the git log flags for the history options.
+func logFlags(opts LogOptions) []string {
+	var flags []string
+	if opts.FirstParent {
+		flags = append(flags, "--first-parent")
+	}
+	if opts.NoMerges {
+		flags = append(flags, "--no-merges")
+	}
+	return flags
+}
+
+// changedFiles returns the files changed by each commit of the log, keyed by hash.
+// Merge commits report their changes against the first parent.
+func (r *execRepository) changedFiles(ctx context.Context, opts LogOptions) (map[string][]string, error) {
+	args := []string{"log", opts.Range, "-z", "--name-only", "--format=%x1e%H", "--diff-merges=first-parent"}
+	result := r.git(ctx, append(args, logFlags(opts)...)...)
+	if !result.Success() {
+		return nil, fmt.Errorf("list changed files: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	// Each record is "\x1e<hash>\x00" followed by NUL-terminated file names.
+	files := make(map[string][]string)
+	for record := range strings.SplitSeq(result.Stdout, recordSep) {
+		fields := strings.Split(record, "\x00")
+		if fields[0] == "" {
+			continue
+		}
+		var names []string
+		for _, name := range fields[1:] {
+			if name = strings.TrimLeft(name, "\n"); name != "" {
+				names = append(names, name)
+			}
+		}
+		files[fields[0]] = names
+	}
+	return files, nil
+}
+
+// scanRecords is a [bufio.SplitFunc] that splits NUL-terminated records.
+func scanRecords(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// parseLogRecord parses a single log record produced with logFormat.
+func parseLogRecord(record string) (LogEntry, error) {
+	// git separates -z records with a NUL but may still emit the newline
+	// that terminates the previous commit's body.
+	record = strings.TrimLeft(record, "\n")
+
+	parts := strings.SplitN(record, fieldSep, logFields)
+	if len(parts) != logFields {
+		return LogEntry{}, fmt.Errorf("malformed git log record: expected %d fields, got %d", logFields, len(parts))
+	}
+
+	authorDate, err := time.Parse(time.RFC3339, parts[4])
+	if err != nil {
+		return LogEntry{}, fmt.Errorf("parse author date of %s: %w", parts[0], err)
+	}
+
+	commitDate, err := time.Parse(time.RFC3339, parts[5])
+	if err != nil {
+		return LogEntry{}, fmt.Errorf("parse committer date of %s: %w", parts[0], err)
+	}
+
+	return LogEntry{
+		Hash:        parts[0],
+		ShortHash:   parts[1],
+		AuthorName:  parts[2],
+		AuthorEmail: parts[3],
+		AuthorDate:  authorDate,
+		CommitDate:  commitDate,
+		Parents:     strings.Fields(parts[6]),
+		Subject:     parts[7],
+		Body:        strings.TrimSpace(parts[8]),
+	}, nil
+}
+
+// CheckMailmap maps identities through the .mailmap in a single git call. They are
+// passed on stdin, so any number fits.
+func (r *execRepository) CheckMailmap(ctx context.Context, identities []string) ([]string, error) {
+	if len(identities) == 0 {
+		return nil, nil
+	}
+
+	input := strings.Join(identities, "\n") + "\n"
+	result := run.CmdInDirWithInput(ctx, r.dir, input, "git", "check-mailmap", "--stdin")
+	if !result.Success() {
+		return nil, fmt.Errorf("git check-mailmap failed: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	mapped := strings.Split(strings.TrimSuffix(result.Stdout, "\n"), "\n")
+	if len(mapped) != len(identities) {
+		return nil, fmt.Errorf("git check-mailmap returned %d identities for %d", len(mapped), len(identities))
+	}
+	return mapped, nil
+}
+
+// IsDirty reports whether git status shows any changes.
+func (r *execRepository) IsDirty(ctx context.Context) (bool, error) {
+	result := r.git(ctx, "status", "--porcelain")
+	if !result.Success() {
+		return false, result.MustSucceed("check git status")
+	}
+	return strings.TrimSpace(result.Stdout) != "", nil
+}
+
+// Commit stages paths and commits them.
+func (r *execRepository) Commit(ctx context.Context, message string, paths ...string) error {
+	if len(paths) > 0 {
+		if err := r.git(ctx, append([]string{"add", "--"}, paths...)...).MustSucceed("stage file"); err != nil {
+			return err
+		}
+	}
+	return r.git(ctx, "commit", "-m", message).MustSucceed("commit")
+}
+
+// CurrentBranch returns the checked out branch, or "HEAD" when detached.
+func (r *execRepository) CurrentBranch(ctx context.Context) (string, error) {
+	result := r.git(ctx, "rev-parse", "--abbrev-ref", "HEAD")
+	if !result.Success() {
+		return "", fmt.Errorf("failed to get current branch: %s", result.Stderr)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// Branches lists the local branches.
+func (r *execRepository) Branches(ctx context.Context) ([]string, error) {
+	result := r.git(ctx, "branch", "--format=%(refname:short)")
+	if !result.Success() {
+		return nil, fmt.Errorf("failed to list branches: %s", result.Stderr)
+	}
+	return strings.Fields(result.Stdout), nil
+}
+
+// CreateBranch creates a branch on target.
+func (r *execRepository) CreateBranch(ctx context.Context, name, target string) error {
+	result := r.git(ctx, "branch", name, target)
+	if !result.Success() {
+		return fmt.Errorf("failed to create branch: %s", result.Stderr)
+	}
+	return nil
+}
+
+// Checkout switches the working tree to branch.
+func (r *execRepository) Checkout(ctx context.Context, branch string) error {
+	result := r.git(ctx, "checkout", "-q", branch)
+	if !result.Success() {
+		return fmt.Errorf("checkout %s: %s", branch, strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// DeleteBranch deletes a local branch with git branch -D.
+func (r *execRepository) DeleteBranch(ctx context.Context, name string) error {
+	result := r.git(ctx, "branch", "-D", name)
+	if !result.Success() {
+		return fmt.Errorf("delete branch %s: %s", name, strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// Merge merges branch with --no-ff.
+func (r *execRepository) Merge(ctx context.Context, branch, message string) error {
+	result := r.git(ctx, "merge", "--no-ff", "-m", message, branch)
+	if result.Success() {
+		return nil
+	}
+	if r.git(ctx, "rev-parse", "--verify", "--quiet", "MERGE_HEAD").Success() {
+		return ErrMergeConflict
+	}
+	return fmt.Errorf("merge %s: %s", branch, strings.TrimSpace(result.Stderr+result.Stdout))
+}
+
+// ResetHard runs git reset --hard.
+func (r *execRepository) ResetHard(ctx context.Context, ref string) error {
+	result := r.git(ctx, "reset", "--hard", ref)
+	if !result.Success() {
+		return fmt.Errorf("reset to %s: %s", ref, strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// IsAncestor runs git merge-base --is-ancestor, which exits with 1 if commit is not
+// an ancestor of ref.
+func (r *execRepository) IsAncestor(ctx context.Context, commit, ref string) (bool, error) {
+	result := r.git(ctx, "merge-base", "--is-ancestor", commit, ref)
+	switch {
+	case result.Success():
+		return true, nil
+	case result.ExitCode == 1:
+		return false, nil
+	default:
+		return false, fmt.Errorf("check whether %s is in %s: %s", commit, ref, strings.TrimSpace(result.Stderr))
+	}
+}
+
+// Cherry returns the commits git cherry marks with "+".
+func (r *execRepository) Cherry(ctx context.Context, upstream, head, limit string) ([]string, error) {
+	result := r.git(ctx, "cherry", upstream, head, limit)
+	if !result.Success() {
+		return nil, fmt.Errorf("compare %s with %s: %s", head, upstream, strings.TrimSpace(result.Stderr))
+	}
+
+	var commits []string
+	for line := range strings.Lines(result.Stdout) {
+		if hash, found := strings.CutPrefix(strings.TrimSpace(line), "+ "); found {
+			commits = append(commits, hash)
+		}
+	}
+	return commits, nil
+}
+
+// CherryPick runs git cherry-pick -x. A pick that stops without unmerged files and
+// with nothing staged came out empty and is skipped.
+func (r *execRepository) CherryPick(ctx context.Context, commit string) error {
+	result := r.git(ctx, "cherry-pick", "-x", commit)
+	if result.Success() {
+		return nil
+	}
+	if !r.cherryPickInProgress(ctx) {
+		return fmt.Errorf("cherry-pick %s: %s", shortHash(commit), strings.TrimSpace(result.Stderr))
+	}
+
+	unmerged := r.git(ctx, "ls-files", "--unmerged")
+	if !unmerged.Success() {
+		return fmt.Errorf("list unmerged files: %s", strings.TrimSpace(unmerged.Stderr))
+	}
+	if strings.TrimSpace(unmerged.Stdout) != "" || !r.git(ctx, "diff", "--cached", "--quiet").Success() {
+		return ErrPickConflict
+	}
+
+	if skip := r.git(ctx, "cherry-pick", "--skip"); !skip.Success() {
+		return fmt.Errorf("skip empty cherry-pick of %s: %s", shortHash(commit), strings.TrimSpace(skip.Stderr))
+	}
+	return ErrEmptyPick
+}
+
+// CherryPickContinue runs git cherry-pick --continue without opening an editor.
+func (r *execRepository) CherryPickContinue(ctx context.Context) error {
+	if !r.cherryPickInProgress(ctx) {
+		return nil
+	}
+	result := r.git(ctx, "-c", "core.editor=true", "cherry-pick", "--continue")
+	if !result.Success() {
+		return fmt.Errorf("continue cherry-pick: %s", strings.TrimSpace(result.Stderr+result.Stdout))
+	}
+	return nil
+}
+
+// CherryPickAbort runs git cherry-pick --abort.
+func (r *execRepository) CherryPickAbort(ctx context.Context) error {
+	if !r.cherryPickInProgress(ctx) {
+		return nil
+	}
+	if result := r.git(ctx, "cherry-pick", "--abort"); !result.Success() {
+		return fmt.Errorf("abort cherry-pick: %s", strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// cherryPickInProgress reports whether git is in the middle of a cherry-pick.
+func (r *execRepository) cherryPickInProgress(ctx context.Context) bool {
+	return r.git(ctx, "rev-parse", "--verify", "--quiet", "CHERRY_PICK_HEAD").Success()
+}
+
+// GitPath resolves name with git rev-parse --git-path.
+func (r *execRepository) GitPath(ctx context.Context, name string) (string, error) {
+	result := r.git(ctx, "rev-parse", "--git-path", name)
+	if !result.Success() {
+		return "", fmt.Errorf("locate git directory: %s", strings.TrimSpace(result.Stderr))
+	}
+	path := strings.TrimSpace(result.Stdout)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.dir, path)
+	}
+	return path, nil
+}
+
+// Push pushes refs to remote.
+func (r *execRepository) Push(ctx context.Context, remote string, refs []string, force bool) error {
+	args := []string{"push"}
+	if force {
+		args = append(args, "--force")
+	}
+	result := r.git(ctx, append(append(args, remote), refs...)...)
+	if !result.Success() {
+		return fmt.Errorf("push %s to %s: %s", strings.Join(refs, ", "), remote, strings.TrimSpace(result.Stderr))
+	}
+	return nil
+}
+
+// RemoteBranches lists the branches on remote with git ls-remote.
+func (r *execRepository) RemoteBranches(ctx context.Context, remote string) ([]string, error) {
+	if !r.git(ctx, "remote", "get-url", remote).Success() {
+		return nil, nil
+	}
+	result := r.git(ctx, "ls-remote", "--heads", remote)
+	if !result.Success() {
+		return nil, fmt.Errorf("list branches of %s: %s", remote, strings.TrimSpace(result.Stderr))
+	}
+
+	var branches []string
+	for line := range strings.Lines(result.Stdout) {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			branches = append(branches, strings.TrimPrefix(fields[1], "refs/heads/"))
+		}
+	}
+	return branches, nil
+}
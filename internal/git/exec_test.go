@@ -0,0 +1,213 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+func TestRepositoryTags(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	first := commit("a.txt", "a\n", "feat: a")
+	git("tag", "v1.0.0")
+	second := commit("b.txt", "b\n", "fix: b")
+	git("tag", "-a", "v1.0.1", "-m", "Release v1.0.1\n\nHotfix-Base: v1.0.0")
+	git("tag", "-a", "api/v2.0.0", "-m", "Release api/v2.0.0")
+
+	repo := NewRepository(dir)
+	refs, err := repo.Tags(ctx, "v")
+	must(t, err)
+	if len(refs) != 2 {
+		t.Fatalf("Tags() = %d tags, want 2: %+v", len(refs), refs)
+	}
+
+	byName := map[string]TagRef{}
+	for _, ref := range refs {
+		byName[ref.Name] = ref
+	}
+
+	light := byName["v1.0.0"]
+	if light.Annotated || light.Commit != first || light.Subject != "feat: a" || light.Annotation != "" {
+		t.Errorf("lightweight tag = %+v, want commit %s with subject feat: a", light, first)
+	}
+	annotated := byName["v1.0.1"]
+	if !annotated.Annotated || annotated.Commit != second || annotated.Subject != "fix: b" ||
+		annotated.Annotation != "Release v1.0.1\n\nHotfix-Base: v1.0.0" || annotated.TaggerDate == "" {
+		t.Errorf("annotated tag = %+v, want commit %s with the tag message", annotated, second)
+	}
+
+	t.Run("prefix with directory", func(t *testing.T) {
+		refs, err := repo.Tags(ctx, "api/")
+		must(t, err)
+		if len(refs) != 1 || refs[0].Name != "api/v2.0.0" {
+			t.Errorf("Tags(api/) = %+v, want api/v2.0.0", refs)
+		}
+	})
+
+	t.Run("tag info", func(t *testing.T) {
+		tagger := NewTagger(dir, "v", false)
+		info, err := tagger.GetTagInfo(ctx, "1.0.1")
+		must(t, err)
+		if info.Tag != "v1.0.1" || info.Version != "1.0.1" || info.Commit != second || info.Message != "fix: b" {
+			t.Errorf("GetTagInfo(1.0.1) = %+v", info)
+		}
+		if _, err := tagger.GetTagInfo(ctx, "v9.9.9"); err == nil {
+			t.Error("GetTagInfo(v9.9.9) error = nil, want tag not found")
+		}
+	})
+}
+
+func TestParseLogRecord(t *testing.T) {
+	record := func(authorDate, commitDate, subject, body string) string {
+		return strings.Join([]string{
+			"abc123", "abc", "Jane", "jane@example.com", authorDate, commitDate, "p1 p2", subject, body,
+		}, fieldSep)
+	}
+
+	tests := []struct {
+		name        string
+		record      string
+		wantSubject string
+		wantBody    string
+		wantDate    time.Time
+		wantErr     bool
+	}{
+		{
+			name:        "fields",
+			record:      record("2025-01-02T10:00:00+01:00", "2025-01-03T11:00:00Z", "feat: a", "details\n"),
+			wantSubject: "feat: a",
+			wantBody:    "details",
+			wantDate:    time.Date(2025, 1, 3, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "pipes in subject and body",
+			record:      record("2025-01-02T10:00:00Z", "2025-01-02T10:00:00Z", "fix: a | b", "a|b|c|d|e|f\nsecond line"),
+			wantSubject: "fix: a | b",
+			wantBody:    "a|b|c|d|e|f\nsecond line",
+			wantDate:    time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "leading newline from previous record",
+			record:      "\n" + record("2025-01-02T10:00:00Z", "2025-01-02T10:00:00Z", "update readme", ""),
+			wantSubject: "update readme",
+			wantDate:    time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "missing fields",
+			record:  strings.Join([]string{"abc123", "abc", "Jane"}, fieldSep),
+			wantErr: true,
+		},
+		{
+			name:    "invalid committer date",
+			record:  record("2025-01-02T10:00:00Z", "2025-01-02 10:00:00 +0000", "fix: x", ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogRecord(tt.record)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLogRecord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Hash != "abc123" || got.AuthorName != "Jane" || len(got.Parents) != 2 {
+				t.Errorf("parseLogRecord() = %+v", got)
+			}
+			if got.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.wantSubject)
+			}
+			if got.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", got.Body, tt.wantBody)
+			}
+			if !got.CommitDate.Equal(tt.wantDate) {
+				t.Errorf("CommitDate = %v, want %v", got.CommitDate, tt.wantDate)
+			}
+			if got.AuthorDate.IsZero() {
+				t.Errorf("AuthorDate is zero")
+			}
+		})
+	}
+}
+
+// BenchmarkListAllTags lists a repository with thousands of annotated tags, each on
+// its own commit.
+// TestRepositoryCheckMailmap maps more identities than fit on a command line, in order.
+func TestRepositoryCheckMailmap(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	mailmap := []byte("Jane Doe <jane@example.com> <jdoe@old.example>\n")
+	must(t, os.WriteFile(filepath.Join(dir, ".mailmap"), mailmap, 0o600))
+
+	// About 4 MiB of identities, above the usual 2 MiB argument limit
+	identities := []string{"jdoe <jdoe@old.example>"}
+	for i := range 60000 {
+		identities = append(identities, fmt.Sprintf("Contributor %05d %s <c%05d@example.com>", i, strings.Repeat("x", 20), i))
+	}
+
+	mapped, err := NewRepository(dir).CheckMailmap(ctx, identities)
+	must(t, err)
+	if len(mapped) != len(identities) {
+		t.Fatalf("CheckMailmap() = %d identities, want %d", len(mapped), len(identities))
+	}
+	if mapped[0] != "Jane Doe <jane@example.com>" {
+		t.Errorf("CheckMailmap()[0] = %q, want the .mailmap identity", mapped[0])
+	}
+	if last := len(identities) - 1; mapped[last] != identities[last] {
+		t.Errorf("CheckMailmap()[%d] = %q, want %q", last, mapped[last], identities[last])
+	}
+}
+
+func BenchmarkListAllTags(b *testing.B) {
+	const tagCount = 4000
+
+	dir := b.TempDir()
+	ctx := context.Background()
+	if r := run.CmdInDir(ctx, dir, "git", "init", "-q"); !r.Success() {
+		b.Fatalf("git init: %s", r.Stderr)
+	}
+
+	// Build the history in one fast-import stream instead of thousands of git calls
+	var stream strings.Builder
+	for i := range tagCount {
+		version := fmt.Sprintf("%d.%d.%d", i/1000, i/100%10, i%100)
+		msg := "chore: release " + version
+		fmt.Fprintf(&stream, "commit refs/heads/main\ncommitter Bench <bench@example.com> %d +0000\n", 1700000000+i)
+		fmt.Fprintf(&stream, "data %d\n%s\n", len(msg), msg)
+		msg = "Release v" + version
+		fmt.Fprintf(&stream, "tag v%s\nfrom refs/heads/main\ntagger Bench <bench@example.com> %d +0000\n",
+			version, 1700000000+i)
+		fmt.Fprintf(&stream, "data %d\n%s\n", len(msg), msg)
+	}
+	importCmd := exec.CommandContext(ctx, "git", "fast-import", "--quiet")
+	importCmd.Dir = dir
+	importCmd.Stdin = strings.NewReader(stream.String())
+	if out, err := importCmd.CombinedOutput(); err != nil {
+		b.Fatalf("git fast-import: %v: %s", err, out)
+	}
+
+	tagger := NewTagger(dir, "v", false)
+	for b.Loop() {
+		tags, err := tagger.ListAllTags(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(tags) != tagCount {
+			b.Fatalf("ListAllTags() = %d tags, want %d", len(tags), tagCount)
+		}
+	}
+}
@@ -0,0 +1,685 @@
+// Package gittest provides an in-memory git.Repository for tests.
+package gittest
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha1" //nolint:gosec // commit ids only need to look like git hashes
+	"encoding/hex"
+	"fmt"
+	"iter"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexjoedt/forge/internal/git"
+)
+
+// Start is the date of the first commit. Every commit and tag advances the clock by
+// an hour, so dates are deterministic and increase in creation order.
+//
+//nolint:gochecknoglobals // fixed test clock
+var Start = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Push records a call to Repository.Push.
+type Push struct {
+	Remote string
+	Refs   []string
+	Force  bool
+}
+
+type commit struct {
+	hash    string
+	seq     int
+	patch   string // identifies the changes; cherry-picks keep the patch of their source
+	parents []string
+	subject string
+	body    string
+	author  string
+	email   string
+	date    time.Time
+	files   []string
+}
+
+type tag struct {
+	commit  string
+	message string // empty for lightweight tags
+	date    time.Time
+}
+
+// Repository is an in-memory git.Repository. Commits are created on the current
+// branch with AddCommit and AddMerge, and tags with AddTag or CreateTag. The zero
+// value is not usable; create one with New.
+type Repository struct {
+	// AuthorName and AuthorEmail are used for new commits.
+	AuthorName  string
+	AuthorEmail string
+	// Dirty is returned by IsDirty and cleared by Commit.
+	Dirty bool
+	// Pushes records every push, in order.
+	Pushes []Push
+	// Conflicts lists the commits whose cherry-pick, and the branches whose merge, stop
+	// on conflicts. A stopped cherry-pick is committed by CherryPickContinue.
+	Conflicts []string
+	// GitDir stands in for the git directory in GitPath, e.g. t.TempDir() for the
+	// state file of git.Pick.
+	GitDir string
+
+	mu       sync.Mutex
+	commits  map[string]*commit
+	branches map[string]string
+	tags     map[string]tag
+	remotes  map[string]map[string]string // remote -> branch -> commit
+	head     string                       // checked out branch
+	picking  string                       // commit of a cherry-pick stopped on conflicts
+	clock    time.Time
+}
+
+var _ git.Repository = (*Repository)(nil)
+
+// New returns an empty repository with the branch main checked out.
+func New() *Repository {
+	return &Repository{
+		AuthorName:  "Forge Test",
+		AuthorEmail: "test@example.com",
+		commits:     make(map[string]*commit),
+		branches:    make(map[string]string),
+		tags:        make(map[string]tag),
+		remotes:     make(map[string]map[string]string),
+		head:        "main",
+		clock:       Start,
+	}
+}
+
+// AddCommit commits files on the current branch and returns the commit hash. The
+// first line of message is the subject, the rest the body.
+func (r *Repository) AddCommit(message string, files ...string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var parents []string
+	if tip, ok := r.branches[r.head]; ok {
+		parents = []string{tip}
+	}
+	return r.commit(parents, message, files)
+}
+
+// AddMerge merges branch into the current branch with a merge commit and returns its
+// hash. The commit lists the files changed on branch since the merge base.
+func (r *Repository) AddMerge(branch, message string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.merge(branch, message)
+}
+
+// merge adds the merge commit of AddMerge. The caller holds r.mu.
+func (r *Repository) merge(branch, message string) (string, error) {
+	tip, ok := r.branches[branch]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", git.ErrUnknownRef, branch)
+	}
+	head, ok := r.branches[r.head]
+	if !ok {
+		return "", fmt.Errorf("branch %s has no commits", r.head)
+	}
+
+	merged := r.ancestors(head, false)
+	var files []string
+	for hash := range r.ancestors(tip, false) {
+		if !merged[hash] {
+			files = append(files, r.commits[hash].files...)
+		}
+	}
+	slices.Sort(files)
+	return r.commit([]string{head, tip}, message, slices.Compact(files)), nil
+}
+
+// AddTag tags target. An empty message creates a lightweight tag.
+func (r *Repository) AddTag(name, target, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tags[name]; ok {
+		return fmt.Errorf("tag %q already exists", name)
+	}
+	hash, err := r.resolve(target)
+	if err != nil {
+		return err
+	}
+	r.tags[name] = tag{commit: hash, message: strings.TrimSpace(message), date: r.tick()}
+	return nil
+}
+
+// commit adds a commit on the current branch. The caller holds r.mu.
+func (r *Repository) commit(parents []string, message string, files []string) string {
+	date := r.tick()
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	sum := sha1.Sum(fmt.Appendf(nil, "%d\x00%s\x00%s", len(r.commits), strings.Join(parents, " "), message)) //nolint:gosec
+
+	c := &commit{
+		hash:    hex.EncodeToString(sum[:]),
+		seq:     len(r.commits),
+		patch:   hex.EncodeToString(sum[:]),
+		parents: parents,
+		subject: subject,
+		body:    strings.TrimSpace(body),
+		author:  r.AuthorName,
+		email:   r.AuthorEmail,
+		date:    date,
+		files:   slices.Clone(files),
+	}
+	r.commits[c.hash] = c
+	r.branches[r.head] = c.hash
+	return c.hash
+}
+
+// pick commits the changes of src on the current branch with the provenance line of
+// git cherry-pick -x. The caller holds r.mu.
+func (r *Repository) pick(src *commit) {
+	message := src.subject
+	if src.body != "" {
+		message += "\n\n" + src.body
+	}
+	message += "\n\n(cherry picked from commit " + src.hash + ")"
+
+	hash := r.commit([]string{r.branches[r.head]}, message, src.files)
+	r.commits[hash].patch = src.patch
+}
+
+// tick advances the clock by an hour and returns the new time. The caller holds r.mu.
+func (r *Repository) tick() time.Time {
+	r.clock = r.clock.Add(time.Hour)
+	return r.clock
+}
+
+// resolve returns the commit ref points at, looking it up in the same order as git.
+// The caller holds r.mu.
+func (r *Repository) resolve(ref string) (string, error) {
+	name := strings.TrimSuffix(strings.TrimSuffix(ref, "^{}"), "^{commit}")
+
+	// A trailing ^ selects the first parent
+	if base, ok := strings.CutSuffix(name, "^"); ok {
+		hash, err := r.resolve(base)
+		if err != nil {
+			return "", err
+		}
+		if parents := r.commits[hash].parents; len(parents) > 0 {
+			return parents[0], nil
+		}
+		return "", fmt.Errorf("%w: %s", git.ErrUnknownRef, ref)
+	}
+
+	if name == "HEAD" {
+		if hash, ok := r.branches[r.head]; ok {
+			return hash, nil
+		}
+	}
+	if t, ok := r.tags[strings.TrimPrefix(name, "refs/tags/")]; ok && !strings.HasPrefix(name, "refs/heads/") {
+		return t.commit, nil
+	}
+	if hash, ok := r.branches[strings.TrimPrefix(name, "refs/heads/")]; ok && !strings.HasPrefix(name, "refs/tags/") {
+		return hash, nil
+	}
+
+	const minAbbrev = 4
+	if len(name) >= minAbbrev {
+		var matches []string
+		for hash := range r.commits {
+			if strings.HasPrefix(hash, name) {
+				matches = append(matches, hash)
+			}
+		}
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+		if len(matches) > 1 {
+			return "", fmt.Errorf("cannot resolve ref %q: ambiguous commit", ref)
+		}
+	}
+	return "", fmt.Errorf("%w: %s", git.ErrUnknownRef, ref)
+}
+
+// ancestors returns the commits reachable from hash, hash included. The caller holds r.mu.
+func (r *Repository) ancestors(hash string, firstParent bool) map[string]bool {
+	seen := make(map[string]bool)
+	stack := []string{hash}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		parents := r.commits[h].parents
+		if firstParent && len(parents) > 1 {
+			parents = parents[:1]
+		}
+		stack = append(stack, parents...)
+	}
+	return seen
+}
+
+// Tags returns the tags whose name starts with prefix.
+func (r *Repository) Tags(_ context.Context, prefix string) ([]git.TagRef, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var refs []git.TagRef
+	for _, name := range slices.Sorted(maps.Keys(r.tags)) {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		t := r.tags[name]
+		c := r.commits[t.commit]
+		ref := git.TagRef{
+			Name:       name,
+			Commit:     t.commit,
+			CommitDate: c.date.Format(git.DateFormat),
+			Subject:    c.subject,
+		}
+		if t.message != "" {
+			ref.Annotated = true
+			ref.TaggerDate = t.date.Format(git.DateFormat)
+			ref.Annotation = t.message
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// MergedTags returns the tags reachable from ref.
+func (r *Repository) MergedTags(_ context.Context, ref string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash, err := r.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	reachable := r.ancestors(hash, false)
+
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(r.tags)) {
+		if reachable[r.tags[name].commit] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ContainingTags returns the tags that contain commit.
+func (r *Repository) ContainingTags(_ context.Context, commit string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash, err := r.resolve(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(r.tags)) {
+		if r.ancestors(r.tags[name].commit, false)[hash] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// CreateTag creates an annotated tag, replacing an existing one with force.
+func (r *Repository) CreateTag(_ context.Context, name, target, message string, force bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tags[name]; ok && !force {
+		return fmt.Errorf("tag %q already exists", name)
+	}
+	hash, err := r.resolve(target)
+	if err != nil {
+		return err
+	}
+	r.tags[name] = tag{commit: hash, message: strings.TrimSpace(message), date: r.tick()}
+	return nil
+}
+
+// ResolveRef returns the commit ref points at.
+func (r *Repository) ResolveRef(_ context.Context, ref string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.resolve(ref)
+}
+
+// Log returns the commits of opts.Range, newest first. Ranges are "from..to", "from.."
+// or a single ref; an empty range selects the history of HEAD.
+func (r *Repository) Log(_ context.Context, opts git.LogOptions) iter.Seq2[git.LogEntry, error] {
+	r.mu.Lock()
+	entries, err := r.log(opts)
+	r.mu.Unlock()
+
+	return func(yield func(git.LogEntry, error) bool) {
+		if err != nil {
+			yield(git.LogEntry{}, err)
+			return
+		}
+		for _, entry := range entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// log collects the entries of Log. The caller holds r.mu.
+func (r *Repository) log(opts git.LogOptions) ([]git.LogEntry, error) {
+	from, to, isRange := strings.Cut(opts.Range, "..")
+	if !isRange {
+		from, to = "", opts.Range
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	tip, err := r.resolve(to)
+	if err != nil {
+		return nil, err
+	}
+	var excluded map[string]bool
+	if from != "" {
+		base, err := r.resolve(from)
+		if err != nil {
+			return nil, err
+		}
+		excluded = r.ancestors(base, false)
+	}
+
+	var commits []*commit
+	for hash := range r.ancestors(tip, opts.FirstParent) {
+		c := r.commits[hash]
+		if excluded[hash] || (opts.NoMerges && len(c.parents) > 1) {
+			continue
+		}
+		commits = append(commits, c)
+	}
+	slices.SortFunc(commits, func(a, b *commit) int { return cmp.Compare(b.seq, a.seq) })
+
+	entries := make([]git.LogEntry, 0, len(commits))
+	for _, c := range commits {
+		entry := git.LogEntry{
+			Hash:        c.hash,
+			ShortHash:   c.hash[:7],
+			AuthorName:  c.author,
+			AuthorEmail: c.email,
+			AuthorDate:  c.date,
+			CommitDate:  c.date,
+			Parents:     slices.Clone(c.parents),
+			Subject:     c.subject,
+			Body:        c.body,
+		}
+		if opts.Files {
+			entry.Files = slices.Clone(c.files)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// CheckMailmap returns identities unchanged; the fake has no .mailmap.
+func (r *Repository) CheckMailmap(_ context.Context, identities []string) ([]string, error) {
+	return slices.Clone(identities), nil
+}
+
+// IsDirty returns r.Dirty.
+func (r *Repository) IsDirty(context.Context) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Dirty, nil
+}
+
+// Commit commits paths on the current branch and clears r.Dirty.
+func (r *Repository) Commit(_ context.Context, message string, paths ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var parents []string
+	if tip, ok := r.branches[r.head]; ok {
+		parents = []string{tip}
+	}
+	r.commit(parents, message, paths)
+	r.Dirty = false
+	return nil
+}
+
+// CurrentBranch returns the checked out branch.
+func (r *Repository) CurrentBranch(context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.head, nil
+}
+
+// Branches returns the branches, sorted by name.
+func (r *Repository) Branches(context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.branches)), nil
+}
+
+// CreateBranch creates a branch on target.
+func (r *Repository) CreateBranch(_ context.Context, name, target string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.branches[name]; ok {
+		return fmt.Errorf("failed to create branch: branch %q already exists", name)
+	}
+	hash, err := r.resolve(target)
+	if err != nil {
+		return err
+	}
+	r.branches[name] = hash
+	return nil
+}
+
+// Checkout switches to branch.
+func (r *Repository) Checkout(_ context.Context, branch string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.branches[branch]; !ok {
+		return fmt.Errorf("checkout %s: %w", branch, git.ErrUnknownRef)
+	}
+	r.head = branch
+	return nil
+}
+
+// DeleteBranch deletes a branch other than the checked out one.
+func (r *Repository) DeleteBranch(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.branches[name]; !ok {
+		return fmt.Errorf("delete branch %s: %w", name, git.ErrUnknownRef)
+	}
+	if name == r.head {
+		return fmt.Errorf("delete branch %s: branch is checked out", name)
+	}
+	delete(r.branches, name)
+	return nil
+}
+
+// Merge merges branch with a merge commit. Branches listed in r.Conflicts return
+// git.ErrMergeConflict without changing anything.
+func (r *Repository) Merge(_ context.Context, branch, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.Contains(r.Conflicts, branch) {
+		return git.ErrMergeConflict
+	}
+	_, err := r.merge(branch, message)
+	return err
+}
+
+// ResetHard moves the current branch to ref and drops a cherry-pick in progress.
+func (r *Repository) ResetHard(_ context.Context, ref string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash, err := r.resolve(ref)
+	if err != nil {
+		return err
+	}
+	r.branches[r.head] = hash
+	r.picking = ""
+	return nil
+}
+
+// IsAncestor reports whether commit is reachable from ref.
+func (r *Repository) IsAncestor(_ context.Context, commit, ref string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash, err := r.resolve(commit)
+	if err != nil {
+		return false, err
+	}
+	tip, err := r.resolve(ref)
+	if err != nil {
+		return false, err
+	}
+	return r.ancestors(tip, false)[hash], nil
+}
+
+// Cherry returns the commits of limit..head, oldest first, whose patch is not on the
+// commits of head..upstream.
+func (r *Repository) Cherry(_ context.Context, upstream, head, limit string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	upstreamHash, err := r.resolve(upstream)
+	if err != nil {
+		return nil, err
+	}
+	headHash, err := r.resolve(head)
+	if err != nil {
+		return nil, err
+	}
+	limitHash, err := r.resolve(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	onUpstream := r.ancestors(upstreamHash, false)
+	onHead := r.ancestors(headHash, false)
+	patches := make(map[string]bool)
+	for hash := range onUpstream {
+		if !onHead[hash] {
+			patches[r.commits[hash].patch] = true
+		}
+	}
+
+	excluded := r.ancestors(limitHash, false)
+	var commits []*commit
+	for hash := range onHead {
+		c := r.commits[hash]
+		if excluded[hash] || onUpstream[hash] || patches[c.patch] {
+			continue
+		}
+		commits = append(commits, c)
+	}
+	slices.SortFunc(commits, func(a, b *commit) int { return cmp.Compare(a.seq, b.seq) })
+
+	hashes := make([]string, 0, len(commits))
+	for _, c := range commits {
+		hashes = append(hashes, c.hash)
+	}
+	return hashes, nil
+}
+
+// CherryPick commits the changes of commit on the current branch. Commits whose patch
+// is already on the branch return git.ErrEmptyPick; commits listed in r.Conflicts
+// return git.ErrPickConflict and stay in progress.
+func (r *Repository) CherryPick(_ context.Context, commit string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.picking != "" {
+		return fmt.Errorf("cherry-pick %s: a cherry-pick is in progress", commit)
+	}
+	hash, err := r.resolve(commit)
+	if err != nil {
+		return err
+	}
+	src := r.commits[hash]
+
+	for onBranch := range r.ancestors(r.branches[r.head], false) {
+		if r.commits[onBranch].patch == src.patch {
+			return git.ErrEmptyPick
+		}
+	}
+	if slices.Contains(r.Conflicts, hash) {
+		r.picking = hash
+		return git.ErrPickConflict
+	}
+	r.pick(src)
+	return nil
+}
+
+// CherryPickContinue commits the cherry-pick in progress, if any.
+func (r *Repository) CherryPickContinue(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.picking != "" {
+		r.pick(r.commits[r.picking])
+		r.picking = ""
+	}
+	return nil
+}
+
+// CherryPickAbort drops the cherry-pick in progress, if any.
+func (r *Repository) CherryPickAbort(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.picking = ""
+	return nil
+}
+
+// GitPath returns name inside r.GitDir.
+func (r *Repository) GitPath(_ context.Context, name string) (string, error) {
+	if r.GitDir == "" {
+		return "", fmt.Errorf("gittest: set GitDir to store %s", name)
+	}
+	return filepath.Join(r.GitDir, name), nil
+}
+
+// Push records the push in r.Pushes. Pushed branches, and deletions like ":name",
+// update the branches RemoteBranches returns.
+func (r *Repository) Push(_ context.Context, remote string, refs []string, force bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Pushes = append(r.Pushes, Push{Remote: remote, Refs: slices.Clone(refs), Force: force})
+
+	if r.remotes[remote] == nil {
+		r.remotes[remote] = make(map[string]string)
+	}
+	for _, ref := range refs {
+		if name, ok := strings.CutPrefix(ref, ":"); ok {
+			delete(r.remotes[remote], name)
+		} else if hash, ok := r.branches[ref]; ok {
+			r.remotes[remote][ref] = hash
+		}
+	}
+	return nil
+}
+
+// RemoteBranches returns the branches pushed to remote, sorted by name.
+func (r *Repository) RemoteBranches(_ context.Context, remote string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.remotes[remote])), nil
+}
@@ -0,0 +1,378 @@
+package gittest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/git/gittest"
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+// newRepo returns a repository with a release v1.0.0 and one feature commit after it.
+func newRepo(t *testing.T) *gittest.Repository {
+	t.Helper()
+	repo := gittest.New()
+	first := repo.AddCommit("feat: initial", "main.go")
+	if err := repo.AddTag("v1.0.0", first, "forge: release v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	repo.AddCommit("feat: add search", "search.go")
+	return repo
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		name string
+		bump version.BumpType
+		pre  string
+		want string
+	}{
+		{name: "patch", bump: version.BumpPatch, want: "v1.0.1"},
+		{name: "minor", bump: version.BumpMinor, want: "v1.1.0"},
+		{name: "major", bump: version.BumpMajor, want: "v2.0.0"},
+		{name: "prerelease", bump: version.BumpMinor, pre: "rc.1", want: "v1.1.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newRepo(t)
+			tagger := git.NewTaggerWithRepository(repo, "v", false)
+
+			tag, err := tagger.CreateNextTag(ctx, version.SchemeSemVer, tt.bump, "", tt.pre, "")
+			if err != nil {
+				t.Fatalf("CreateNextTag() error = %v", err)
+			}
+			if tag != tt.want {
+				t.Errorf("CreateNextTag() = %q, want %q", tag, tt.want)
+			}
+
+			head, _ := repo.ResolveRef(ctx, "HEAD")
+			onHead, err := tagger.TagsAt(ctx, head)
+			if err != nil || !slices.Contains(onHead, tt.want) {
+				t.Errorf("TagsAt(HEAD) = %v, %v; want %q", onHead, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBumpDryRun(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	tag, err := git.NewTaggerWithRepository(repo, "v", true).
+		CreateNextTag(ctx, version.SchemeSemVer, version.BumpMinor, "", "", "")
+	if err != nil || tag != "v1.1.0" {
+		t.Fatalf("CreateNextTag() = %q, %v", tag, err)
+	}
+	if tags, _ := repo.Tags(ctx, "v"); len(tags) != 1 {
+		t.Errorf("dry run created tags: %v", tags)
+	}
+}
+
+func TestRetag(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	tagger := git.NewTaggerWithRepository(repo, "v", false)
+
+	head, _ := repo.ResolveRef(ctx, "HEAD")
+	if err := tagger.MoveTag(ctx, "v1.0.0", head, "forge: retag v1.0.0"); err != nil {
+		t.Fatalf("MoveTag() error = %v", err)
+	}
+	if err := tagger.PushTagForce(ctx, "v1.0.0"); err != nil {
+		t.Fatalf("PushTagForce() error = %v", err)
+	}
+
+	if commit, _ := tagger.GetTagCommit(ctx, "v1.0.0"); commit != head {
+		t.Errorf("v1.0.0 points at %s, want %s", commit, head)
+	}
+	want := []gittest.Push{{Remote: "origin", Refs: []string{"v1.0.0"}, Force: true}}
+	if !slices.EqualFunc(repo.Pushes, want, func(a, b gittest.Push) bool {
+		return a.Remote == b.Remote && a.Force == b.Force && slices.Equal(a.Refs, b.Refs)
+	}) {
+		t.Errorf("Pushes = %+v, want %+v", repo.Pushes, want)
+	}
+}
+
+func TestHotfix(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	if err := repo.AddTag("v1.1.0", "HEAD", "forge: release v1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	tagger := git.NewTaggerWithRepository(repo, "v", false)
+
+	branch, err := tagger.CreateHotfixBranch(ctx, "v1.0.0", "release/", true)
+	if err != nil {
+		t.Fatalf("CreateHotfixBranch() error = %v", err)
+	}
+	if current, _ := repo.CurrentBranch(ctx); branch != "release/v1.0.0" || current != branch {
+		t.Fatalf("branch = %q, checked out %q", branch, current)
+	}
+	if _, err := tagger.CreateHotfixBranch(ctx, "v1.0.0", "release/", false); err == nil {
+		t.Error("CreateHotfixBranch() created an existing branch")
+	}
+
+	tests := []struct {
+		name   string
+		naming git.HotfixNaming
+		want   []string
+	}{
+		{
+			name:   "prerelease style",
+			naming: git.HotfixNaming{Suffix: "hotfix"},
+			want:   []string{"v1.0.0-hotfix.1", "v1.0.0-hotfix.2"},
+		},
+		{
+			// v1.0.1 is free, then the line continues with the next patch
+			name:   "patch style",
+			naming: git.HotfixNaming{Suffix: "hotfix", Patch: true},
+			want:   []string{"v1.0.1", "v1.0.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := gittest.New()
+			base := repo.AddCommit("feat: initial")
+			if err := repo.AddTag("v1.0.0", base, "forge: release v1.0.0"); err != nil {
+				t.Fatal(err)
+			}
+			tagger := git.NewTaggerWithRepository(repo, "v", false)
+
+			for i, want := range tt.want {
+				target := repo.AddCommit("fix: crash")
+				tag, seq, err := tagger.NextHotfix(ctx, "v1.0.0", tt.naming)
+				if err != nil {
+					t.Fatalf("NextHotfix() error = %v", err)
+				}
+				if tag != want || seq != i+1 {
+					t.Errorf("NextHotfix() = %q, %d; want %q, %d", tag, seq, want, i+1)
+				}
+				if err := tagger.CreateHotfixTag(ctx, tag, "v1.0.0", target, "hotfix"); err != nil {
+					t.Fatalf("CreateHotfixTag() error = %v", err)
+				}
+				if base, _ := tagger.HotfixBaseOf(ctx, tag); base != "v1.0.0" {
+					t.Errorf("HotfixBaseOf(%q) = %q, want v1.0.0", tag, base)
+				}
+			}
+		})
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	if err := repo.CreateBranch(ctx, "feature", "HEAD"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Checkout(ctx, "feature"); err != nil {
+		t.Fatal(err)
+	}
+	repo.AddCommit("fix(ui): align buttons", "ui.go")
+	if err := repo.Checkout(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	merge := "Merge pull request #7 from jane/feature\n\nfix(ui): align buttons"
+	if _, err := repo.AddMerge("feature", merge); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts changelog.Options
+		want []string
+	}{
+		{
+			name: "merges left out",
+			want: []string{"fix(ui): align buttons", "feat: add search"},
+		},
+		{
+			name: "first parent",
+			opts: changelog.Options{FirstParent: true},
+			want: []string{"fix(ui): align buttons", "feat: add search"},
+		},
+		{
+			name: "exclude paths",
+			opts: changelog.Options{Exclude: changelog.ExcludeRules{Paths: []string{"ui.go"}}},
+			want: []string{"feat: add search"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := changelog.NewParserWithRepository(repo, "v", tt.opts).Parse(ctx, "v1.0.0", "HEAD")
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var subjects []string
+			for _, c := range cl.Commits {
+				subjects = append(subjects, c.Subject)
+			}
+			if !slices.Equal(subjects, tt.want) {
+				t.Errorf("subjects = %q, want %q", subjects, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	head, err := repo.ResolveRef(ctx, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"main", "refs/heads/main", head[:7], head} {
+		if got, err := repo.ResolveRef(ctx, ref); err != nil || got != head {
+			t.Errorf("ResolveRef(%q) = %q, %v; want %q", ref, got, err, head)
+		}
+	}
+	for _, ref := range []string{"v1.0.0", "v1.0.0^{}", "refs/tags/v1.0.0"} {
+		if got, err := repo.ResolveRef(ctx, ref); err != nil || got == head {
+			t.Errorf("ResolveRef(%q) = %q, %v", ref, got, err)
+		}
+	}
+	if _, err := repo.ResolveRef(ctx, "refs/heads/v1.0.0"); !errors.Is(err, git.ErrUnknownRef) {
+		t.Errorf("ResolveRef(refs/heads/v1.0.0) error = %v, want ErrUnknownRef", err)
+	}
+}
+
+// newHotfixRepo returns newRepo with a hotfix branch release/v1.0.0 carrying two fixes,
+// and main checked out.
+func newHotfixRepo(t *testing.T) (*gittest.Repository, []string) {
+	t.Helper()
+	ctx := context.Background()
+	repo := newRepo(t)
+	repo.GitDir = t.TempDir()
+	if err := repo.CreateBranch(ctx, "release/v1.0.0", "v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Checkout(ctx, "release/v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	fixes := []string{repo.AddCommit("fix: crash", "crash.go"), repo.AddCommit("fix: leak", "leak.go")}
+	if err := repo.Checkout(ctx, "main"); err != nil {
+		t.Fatal(err)
+	}
+	return repo, fixes
+}
+
+func TestHotfixPick(t *testing.T) {
+	repo, fixes := newHotfixRepo(t)
+	repo.Conflicts = []string{fixes[1]}
+	ctx := git.WithRepository(context.Background(), repo)
+
+	missing := func() []string {
+		t.Helper()
+		hashes, err := git.MissingCommits(ctx, "", "main", "release/v1.0.0", "v1.0.0")
+		if err != nil {
+			t.Fatalf("MissingCommits() error = %v", err)
+		}
+		return hashes
+	}
+	if got := missing(); !slices.Equal(got, fixes) {
+		t.Fatalf("MissingCommits() = %v, want %v", got, fixes)
+	}
+
+	head, err := repo.ResolveRef(ctx, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pick := func() *git.PickState {
+		t.Helper()
+		state := &git.PickState{Branch: "main", OrigHead: head, Pending: slices.Clone(fixes)}
+		if err := git.Pick(ctx, "", state); !errors.Is(err, git.ErrPickConflict) {
+			t.Fatalf("Pick() error = %v, want ErrPickConflict", err)
+		}
+		return state
+	}
+
+	// The first fix applies, the second stops on conflicts
+	state := pick()
+	if !slices.Equal(state.Picked, fixes[:1]) || state.Current != fixes[1] {
+		t.Errorf("Pick() picked %v, stopped at %q", state.Picked, state.Current)
+	}
+	if saved, _ := git.LoadPickState(ctx, ""); saved == nil || saved.Current != fixes[1] {
+		t.Errorf("LoadPickState() = %+v, want the stopped pick", saved)
+	}
+	if got := missing(); !slices.Equal(got, fixes[1:]) {
+		t.Errorf("MissingCommits() after conflict = %v, want %v", got, fixes[1:])
+	}
+
+	// Aborting resets main and drops the saved state
+	if _, err := git.AbortPick(ctx, ""); err != nil {
+		t.Fatalf("AbortPick() error = %v", err)
+	}
+	if got, _ := repo.ResolveRef(ctx, "HEAD"); got != head {
+		t.Errorf("HEAD after AbortPick() = %s, want %s", got, head)
+	}
+	if saved, _ := git.LoadPickState(ctx, ""); saved != nil {
+		t.Errorf("LoadPickState() after AbortPick() = %+v, want nil", saved)
+	}
+
+	// Resuming commits the resolved fix and finishes the pick
+	pick()
+	state, err = git.ResumePick(ctx, "")
+	if err != nil {
+		t.Fatalf("ResumePick() error = %v", err)
+	}
+	if !slices.Equal(state.Picked, fixes) {
+		t.Errorf("ResumePick() picked %v, want %v", state.Picked, fixes)
+	}
+	if got := missing(); len(got) != 0 {
+		t.Errorf("MissingCommits() after ResumePick() = %v, want none", got)
+	}
+
+	// Picking again skips the fixes already on main
+	state = &git.PickState{Branch: "main", Pending: slices.Clone(fixes)}
+	if err := git.Pick(ctx, "", state); err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if !slices.Equal(state.Skipped, fixes) || len(state.Picked) != 0 {
+		t.Errorf("Pick() picked %v, skipped %v; want all skipped", state.Picked, state.Skipped)
+	}
+}
+
+func TestHotfixFinish(t *testing.T) {
+	repo, _ := newHotfixRepo(t)
+	ctx := git.WithRepository(context.Background(), repo)
+	const branch = "release/v1.0.0"
+
+	if err := git.PushBranch(ctx, "", "origin", branch); err != nil {
+		t.Fatalf("PushBranch() error = %v", err)
+	}
+	if ok, _ := git.RemoteBranchExists(ctx, "", "origin", branch); !ok {
+		t.Errorf("RemoteBranchExists() = false after PushBranch()")
+	}
+
+	repo.Conflicts = []string{branch}
+	if err := git.MergeBranch(ctx, "", branch, "Merge "+branch); !errors.Is(err, git.ErrMergeConflict) {
+		t.Errorf("MergeBranch() error = %v, want ErrMergeConflict", err)
+	}
+	repo.Conflicts = nil
+	if err := git.MergeBranch(ctx, "", branch, "Merge "+branch); err != nil {
+		t.Fatalf("MergeBranch() error = %v", err)
+	}
+	if missing, _ := git.MissingCommits(ctx, "", "main", branch, "v1.0.0"); len(missing) != 0 {
+		t.Errorf("MissingCommits() after merge = %v, want none", missing)
+	}
+
+	if err := git.DeleteBranch(ctx, "", branch); err != nil {
+		t.Fatalf("DeleteBranch() error = %v", err)
+	}
+	if err := git.DeleteRemoteBranch(ctx, "", "origin", branch); err != nil {
+		t.Fatalf("DeleteRemoteBranch() error = %v", err)
+	}
+	if ok, _ := git.RemoteBranchExists(ctx, "", "origin", branch); ok {
+		t.Errorf("RemoteBranchExists() = true after DeleteRemoteBranch()")
+	}
+	if branches, _ := git.ListBranches(ctx, ""); !slices.Equal(branches, []string{"main"}) {
+		t.Errorf("ListBranches() = %v, want [main]", branches)
+	}
+}
@@ -0,0 +1,292 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/log"
+)
+
+// pickStateFile is the file in the git directory that records an interrupted pick.
+const pickStateFile = "forge-hotfix-pick.json"
+
+// cherryPickedRegex matches the provenance line added by git cherry-pick -x.
+//
+//nolint:gochecknoglobals // compiled once and reused
+var cherryPickedRegex = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+
+// ErrPickConflict is returned when a cherry-pick stops on conflicts. The pick can be
+// resumed with ResumePick once they are resolved, or rolled back with AbortPick.
+var ErrPickConflict = errors.New("cherry-pick stopped on conflicts")
+
+// ErrEmptyPick is returned by Repository.CherryPick when the changes of a commit are
+// already on the branch, so the pick would create an empty commit.
+var ErrEmptyPick = errors.New("cherry-pick is empty")
+
+// PickState tracks the progress of a hotfix pick.
+type PickState struct {
+	// Branch is the hotfix branch the commits are applied to.
+	Branch string `json:"branch"`
+	// OrigHead is the branch head before the pick started; AbortPick resets to it.
+	OrigHead string `json:"orig_head"`
+	// Current is the commit whose cherry-pick stopped on conflicts.
+	Current string `json:"current,omitempty"`
+	// Pending lists the commits still to apply, oldest first.
+	Pending []string `json:"pending"`
+	// Picked lists the commits applied so far.
+	Picked []string `json:"picked"`
+	// Skipped lists the commits whose changes were already on the branch.
+	Skipped []string `json:"skipped"`
+}
+
+// ResolveCommits expands commit specs into full hashes, oldest first. A spec is a single
+// commit or a range like "abc123..def456"; merge commits in ranges are left out.
+// Duplicates are dropped.
+func ResolveCommits(ctx context.Context, repoDir string, specs []string) ([]string, error) {
+	repo := Open(ctx, repoDir)
+
+	var commits []string
+	for _, spec := range specs {
+		var hashes []string
+		if strings.Contains(spec, "..") {
+			for entry, err := range repo.Log(ctx, LogOptions{Range: spec, NoMerges: true}) {
+				if err != nil {
+					return nil, fmt.Errorf("invalid commit range %q: %w", spec, err)
+				}
+				hashes = append(hashes, entry.Hash)
+			}
+			slices.Reverse(hashes)
+		} else {
+			hash, err := repo.ResolveRef(ctx, spec)
+			if err != nil {
+				return nil, fmt.Errorf("unknown commit %q", spec)
+			}
+			hashes = []string{hash}
+		}
+
+		for _, hash := range hashes {
+			if !slices.Contains(commits, hash) {
+				commits = append(commits, hash)
+			}
+		}
+	}
+	return commits, nil
+}
+
+// IsPatchApplied reports whether the changes of commit are already on HEAD, either
+// because HEAD contains the commit or a commit with the same patch-id.
+func IsPatchApplied(ctx context.Context, repoDir, commit string) (bool, error) {
+	unapplied, err := Open(ctx, repoDir).Cherry(ctx, "HEAD", commit, commit+"^")
+	if err != nil {
+		return false, err
+	}
+	return len(unapplied) == 0, nil
+}
+
+// Pick cherry-picks the pending commits of state onto the checked out branch, recording
+// the source commit with -x. Commits already on the branch are skipped. On conflicts,
+// the state is saved and ErrPickConflict returned; otherwise the saved state is removed.
+func Pick(ctx context.Context, repoDir string, state *PickState) error {
+	logger := log.FromContext(ctx)
+	repo := Open(ctx, repoDir)
+
+	for len(state.Pending) > 0 {
+		commit := state.Pending[0]
+
+		applied, err := IsPatchApplied(ctx, repoDir, commit)
+		if err != nil {
+			return err
+		}
+		if applied {
+			logger.Infof("Skipping %s, already on %s", shortHash(commit), state.Branch)
+			state.Skipped = append(state.Skipped, commit)
+			state.Pending = state.Pending[1:]
+			continue
+		}
+
+		err = repo.CherryPick(ctx, commit)
+		state.Pending = state.Pending[1:]
+		switch {
+		case err == nil:
+			state.Picked = append(state.Picked, commit)
+			continue
+		case errors.Is(err, ErrEmptyPick):
+			// A pick can come out empty when the change landed differently on the branch
+			logger.Infof("Skipping %s, its changes are already on %s", shortHash(commit), state.Branch)
+			state.Skipped = append(state.Skipped, commit)
+			continue
+		case !errors.Is(err, ErrPickConflict):
+			return err
+		}
+
+		state.Current = commit
+		if err := SavePickState(ctx, repoDir, state); err != nil {
+			return err
+		}
+		return ErrPickConflict
+	}
+
+	return removePickState(ctx, repoDir)
+}
+
+// ResumePick completes the cherry-pick that stopped on conflicts and applies the
+// remaining commits. The conflicts must be resolved and staged.
+func ResumePick(ctx context.Context, repoDir string) (*PickState, error) {
+	state, err := LoadPickState(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no hotfix pick in progress")
+	}
+
+	if err := Open(ctx, repoDir).CherryPickContinue(ctx); err != nil {
+		return state, fmt.Errorf("pick %s: %w", shortHash(state.Current), err)
+	}
+	if state.Current != "" {
+		state.Picked = append(state.Picked, state.Current)
+		state.Current = ""
+	}
+
+	return state, Pick(ctx, repoDir, state)
+}
+
+// AbortPick stops the pick in progress and resets the branch to where it was before.
+func AbortPick(ctx context.Context, repoDir string) (*PickState, error) {
+	state, err := LoadPickState(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("no hotfix pick in progress")
+	}
+
+	repo := Open(ctx, repoDir)
+	if err := repo.CherryPickAbort(ctx); err != nil {
+		return state, err
+	}
+	if err := repo.ResetHard(ctx, state.OrigHead); err != nil {
+		return state, err
+	}
+
+	return state, removePickState(ctx, repoDir)
+}
+
+// LoadPickState returns the saved state of an interrupted pick, or nil if there is none.
+//
+//nolint:nilnil // (nil, nil) means "no pick in progress"
+func LoadPickState(ctx context.Context, repoDir string) (*PickState, error) {
+	path, err := pickStatePath(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read pick state: %w", err)
+	}
+
+	var state PickState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse pick state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// SavePickState records the pick state in the git directory.
+func SavePickState(ctx context.Context, repoDir string, state *PickState) error {
+	path, err := pickStatePath(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal pick state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write pick state: %w", err)
+	}
+	return nil
+}
+
+// removePickState deletes the saved pick state, if any.
+func removePickState(ctx context.Context, repoDir string) error {
+	path, err := pickStatePath(ctx, repoDir)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove pick state: %w", err)
+	}
+	return nil
+}
+
+// pickStatePath returns the location of the pick state file inside the git directory.
+func pickStatePath(ctx context.Context, repoDir string) (string, error) {
+	return Open(ctx, repoDir).GitPath(ctx, pickStateFile)
+}
+
+// shortHash abbreviates a commit hash for messages.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// MissingCommits returns the commits in base..branch whose changes are not on target,
+// oldest first. A commit counts as present if target has a commit with the same
+// patch-id, or if it was cherry-picked with -x from a commit reachable from target.
+func MissingCommits(ctx context.Context, repoDir, target, branch, base string) ([]string, error) {
+	repo := Open(ctx, repoDir)
+	unapplied, err := repo.Cherry(ctx, target, branch, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, hash := range unapplied {
+		ported, err := pickedFrom(ctx, repo, hash, target)
+		if err != nil {
+			return nil, err
+		}
+		if !ported {
+			missing = append(missing, hash)
+		}
+	}
+	return missing, nil
+}
+
+// pickedFrom reports whether commit was cherry-picked with -x from a commit reachable
+// from target. This catches picks whose patch changed while resolving conflicts.
+func pickedFrom(ctx context.Context, repo Repository, commit, target string) (bool, error) {
+	var body string
+	for entry, err := range repo.Log(ctx, LogOptions{Range: commit}) {
+		if err != nil {
+			return false, fmt.Errorf("read message of %s: %w", shortHash(commit), err)
+		}
+		body = entry.Body
+		break
+	}
+
+	for _, m := range cherryPickedRegex.FindAllStringSubmatch(body, -1) {
+		ancestor, err := repo.IsAncestor(ctx, m[1], target)
+		if err != nil {
+			return false, err
+		}
+		if ancestor {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,145 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+// repoHelpers returns functions that run git in dir and commit a file, returning the output
+// and the new commit hash respectively.
+func repoHelpers(t *testing.T, dir string) (func(...string) string, func(file, content, subject string) string) {
+	git := func(args ...string) string {
+		t.Helper()
+		r := run.CmdInDir(t.Context(), dir, "git", args...)
+		if !r.Success() {
+			t.Fatalf("git %v failed: %s", args, r.Stderr)
+		}
+		return strings.TrimSpace(r.Stdout)
+	}
+	commit := func(file, content, subject string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		git("add", file)
+		git("commit", "-m", subject)
+		return git("rev-parse", "HEAD")
+	}
+	return git, commit
+}
+
+func TestPick(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	mainBranch := git("branch", "--show-current")
+	commit("a.txt", "a\n", "feat: a")
+	git("tag", "v1.0.0")
+	git("branch", "release/v1.0.0")
+	fixB := commit("b.txt", "b\n", "fix: b")
+	fixA := commit("a.txt", "a2\n", "fix: a")
+	fixC := commit("c.txt", "c\n", "fix: c")
+
+	git("checkout", "-q", "release/v1.0.0")
+	git("cherry-pick", fixB) // already on the branch under another hash
+	commit("a.txt", "conflict\n", "fix: a differently")
+	origHead := git("rev-parse", "HEAD")
+
+	commits, err := ResolveCommits(ctx, dir, []string{fixB, "v1.0.0.." + mainBranch, fixC})
+	must(t, err)
+	if strings.Join(commits, ",") != strings.Join([]string{fixB, fixA, fixC}, ",") {
+		t.Fatalf("ResolveCommits() = %v, want [fixB fixA fixC]", commits)
+	}
+
+	state := &PickState{Branch: "release/v1.0.0", OrigHead: origHead, Pending: commits}
+	if err := Pick(ctx, dir, state); !errors.Is(err, ErrPickConflict) {
+		t.Fatalf("Pick() error = %v, want ErrPickConflict", err)
+	}
+	if state.Current != fixA || len(state.Skipped) != 1 || state.Skipped[0] != fixB {
+		t.Fatalf("state = %+v, want conflict on fixA after skipping fixB", state)
+	}
+
+	saved, err := LoadPickState(ctx, dir)
+	must(t, err)
+	if saved == nil || saved.Current != fixA || len(saved.Pending) != 1 {
+		t.Fatalf("LoadPickState() = %+v", saved)
+	}
+
+	t.Run("abort", func(t *testing.T) {
+		if _, err := AbortPick(ctx, dir); err != nil {
+			t.Fatalf("AbortPick() error = %v", err)
+		}
+		if head := git("rev-parse", "HEAD"); head != origHead {
+			t.Errorf("HEAD = %s after abort, want %s", head, origHead)
+		}
+		if s, _ := LoadPickState(ctx, dir); s != nil {
+			t.Errorf("pick state left behind: %+v", s)
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		state := &PickState{Branch: "release/v1.0.0", OrigHead: origHead, Pending: []string{fixA, fixC}}
+		if err := Pick(ctx, dir, state); !errors.Is(err, ErrPickConflict) {
+			t.Fatalf("Pick() error = %v, want ErrPickConflict", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("resolved\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "a.txt")
+
+		state, err := ResumePick(ctx, dir)
+		if err != nil {
+			t.Fatalf("ResumePick() error = %v", err)
+		}
+		if strings.Join(state.Picked, ",") != fixA+","+fixC {
+			t.Errorf("Picked = %v, want [fixA fixC]", state.Picked)
+		}
+		if msg := git("log", "-1", "--format=%B"); !strings.Contains(msg, "(cherry picked from commit "+fixC+")") {
+			t.Errorf("commit message lacks -x provenance:\n%s", msg)
+		}
+		if s, _ := LoadPickState(ctx, dir); s != nil {
+			t.Errorf("pick state left behind: %+v", s)
+		}
+	})
+}
+
+func TestMissingCommits(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+
+	git, commit := repoHelpers(t, dir)
+
+	mainBranch := git("branch", "--show-current")
+	commit("a.txt", "a\n", "feat: a")
+	git("tag", "v1.0.0")
+	onMain := commit("b.txt", "b\n", "fix: b")
+	reworked := commit("c.txt", "c\n", "fix: c")
+
+	git("checkout", "-q", "-b", "release/v1.0.0", "v1.0.0")
+	git("cherry-pick", "-x", onMain)
+	// Picked with -x, but the patch changed while resolving conflicts
+	commit("c.txt", "c for 1.0\n", "fix: c\n\n(cherry picked from commit "+reworked+")")
+	hotfixOnly := commit("d.txt", "d\n", "fix: d")
+
+	missing, err := MissingCommits(ctx, dir, mainBranch, "release/v1.0.0", "v1.0.0")
+	must(t, err)
+	if len(missing) != 1 || missing[0] != hotfixOnly {
+		t.Errorf("MissingCommits() = %v, want [%s]", missing, hotfixOnly)
+	}
+
+	git("checkout", "-q", mainBranch)
+	git("cherry-pick", hotfixOnly)
+	missing, err = MissingCommits(ctx, dir, mainBranch, "release/v1.0.0", "v1.0.0")
+	must(t, err)
+	if len(missing) != 0 {
+		t.Errorf("MissingCommits() = %v after forward-port, want none", missing)
+	}
+}
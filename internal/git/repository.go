@@ -0,0 +1,139 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+)
+
+// ErrUnknownRef is returned by Repository.ResolveRef for refs that don't exist.
+var ErrUnknownRef = errors.New("unknown ref")
+
+// Repository is the git backend used by Tagger and changelog.Parser. NewRepository
+// runs the git binary in a directory; gittest.Repository keeps a repository in memory
+// so that behaviour built on it can be tested without touching the file system.
+type Repository interface {
+	// Tags returns the tags whose name starts with prefix, in no particular order.
+	Tags(ctx context.Context, prefix string) ([]TagRef, error)
+	// MergedTags returns the names of the tags reachable from ref.
+	MergedTags(ctx context.Context, ref string) ([]string, error)
+	// ContainingTags returns the names of the tags that contain commit.
+	ContainingTags(ctx context.Context, commit string) ([]string, error)
+	// CreateTag creates an annotated tag on the commit target resolves to. With force,
+	// an existing tag of that name is replaced.
+	CreateTag(ctx context.Context, name, target, message string, force bool) error
+
+	// ResolveRef returns the full hash of the commit ref points at. Refs are commit
+	// hashes, branch and tag names, HEAD, or any of these with a ^{} suffix. Returns
+	// an error wrapping ErrUnknownRef if there is no such ref.
+	ResolveRef(ctx context.Context, ref string) (string, error)
+
+	// Log returns the commits selected by opts, newest first.
+	Log(ctx context.Context, opts LogOptions) iter.Seq2[LogEntry, error]
+	// CheckMailmap maps "Name <email>" identities through the .mailmap.
+	CheckMailmap(ctx context.Context, identities []string) ([]string, error)
+
+	// IsDirty reports whether the working tree has uncommitted changes.
+	IsDirty(ctx context.Context) (bool, error)
+	// Commit stages paths and commits them on the current branch.
+	Commit(ctx context.Context, message string, paths ...string) error
+
+	// CurrentBranch returns the checked out branch, or "HEAD" when detached.
+	CurrentBranch(ctx context.Context) (string, error)
+	// Branches returns the names of the local branches.
+	Branches(ctx context.Context) ([]string, error)
+	// CreateBranch creates a branch on the commit target resolves to.
+	CreateBranch(ctx context.Context, name, target string) error
+	// Checkout switches to branch.
+	Checkout(ctx context.Context, branch string) error
+	// DeleteBranch deletes a local branch, whether or not it is merged.
+	DeleteBranch(ctx context.Context, name string) error
+	// Merge merges branch into the checked out branch with a merge commit, even if a
+	// fast-forward is possible. Returns ErrMergeConflict if the merge stops on conflicts.
+	Merge(ctx context.Context, branch, message string) error
+	// ResetHard resets the checked out branch and the working tree to ref.
+	ResetHard(ctx context.Context, ref string) error
+
+	// IsAncestor reports whether commit is reachable from ref.
+	IsAncestor(ctx context.Context, commit, ref string) (bool, error)
+	// Cherry returns the commits of limit..head, oldest first, whose changes have no
+	// equivalent (same patch-id) on upstream. Commits reachable from upstream are left out.
+	Cherry(ctx context.Context, upstream, head, limit string) ([]string, error)
+	// CherryPick applies commit to the checked out branch, recording the source commit
+	// like git cherry-pick -x. Returns ErrEmptyPick, with the pick skipped, if the
+	// changes are already on the branch, and ErrPickConflict, with the pick left in
+	// progress, if it stops on conflicts.
+	CherryPick(ctx context.Context, commit string) error
+	// CherryPickContinue commits a cherry-pick whose conflicts are resolved. It does
+	// nothing if no cherry-pick is in progress.
+	CherryPickContinue(ctx context.Context) error
+	// CherryPickAbort rolls back a cherry-pick in progress. It does nothing if no
+	// cherry-pick is in progress.
+	CherryPickAbort(ctx context.Context) error
+
+	// GitPath returns the path of name inside the git directory, for state files.
+	GitPath(ctx context.Context, name string) (string, error)
+
+	// Push pushes refs, such as tag or branch names, to remote. A ref ":name" deletes
+	// name on the remote.
+	Push(ctx context.Context, remote string, refs []string, force bool) error
+	// RemoteBranches returns the names of the branches on remote. A repository without
+	// the remote has no remote branches.
+	RemoteBranches(ctx context.Context, remote string) ([]string, error)
+}
+
+// TagRef holds the metadata of a tag.
+type TagRef struct {
+	Name       string
+	Commit     string // commit the tag points at, peeled for annotated tags
+	Annotated  bool
+	TaggerDate string // empty for lightweight tags
+	CommitDate string // in DateFormat
+	Subject    string // subject of the tagged commit
+	Annotation string // message of an annotated tag
+}
+
+// LogOptions selects the commits returned by Repository.Log.
+type LogOptions struct {
+	// Range is a revision range like "v1.0.0..HEAD", or a single ref for its whole history.
+	Range string
+	// FirstParent follows only the first parent of merge commits.
+	FirstParent bool
+	// NoMerges leaves out merge commits.
+	NoMerges bool
+	// Files loads the files changed by each commit, against the first parent for merges.
+	Files bool
+}
+
+// LogEntry is a commit as returned by Repository.Log.
+type LogEntry struct {
+	Hash        string
+	ShortHash   string
+	AuthorName  string // normalised through .mailmap
+	AuthorEmail string // normalised through .mailmap
+	AuthorDate  time.Time
+	CommitDate  time.Time
+	Parents     []string
+	Subject     string
+	Body        string
+	Files       []string // only set with LogOptions.Files
+}
+
+type contextKey string
+
+const repositoryKey contextKey = "repository"
+
+// WithRepository returns a new context carrying repo. Open returns it instead of
+// running git, whatever the directory.
+func WithRepository(ctx context.Context, repo Repository) context.Context {
+	return context.WithValue(ctx, repositoryKey, repo)
+}
+
+// Open returns the repository stored in ctx, or the git repository in repoDir.
+func Open(ctx context.Context, repoDir string) Repository {
+	if repo, ok := ctx.Value(repositoryKey).(Repository); ok {
+		return repo
+	}
+	return NewRepository(repoDir)
+}
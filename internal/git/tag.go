@@ -1,13 +1,16 @@
 package git
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alexjoedt/forge/internal/log"
-	"github.com/alexjoedt/forge/internal/run"
 	"github.com/alexjoedt/forge/internal/version"
 )
 
@@ -16,9 +19,21 @@ type Tagger struct {
 	repoDir string
 	prefix  string
 	dryRun  bool
+
+	// repo is the git backend; nil opens repoDir for each call (see Open)
+	repo Repository
+
+	// scheme and calverFormat select how tags are parsed when looking for the
+	// latest version. Without a scheme, tags are tried as SemVer, then CalVer.
+	scheme       version.Scheme
+	calverFormat string
+
+	// reportedInvalid is set once unparseable tags were reported
+	reportedInvalid bool
 }
 
-// NewTagger creates a new Tagger for the given repository directory.
+// NewTagger creates a new Tagger for the given repository directory. It uses the
+// Repository stored in the context of each call, if any (see WithRepository).
 func NewTagger(repoDir, prefix string, dryRun bool) *Tagger {
 	return &Tagger{
 		repoDir: repoDir,
@@ -27,32 +42,165 @@ func NewTagger(repoDir, prefix string, dryRun bool) *Tagger {
 	}
 }
 
-// LatestTag returns the latest tag with the configured prefix, or empty string if none exists.
+// NewTaggerWithRepository creates a new Tagger on the given git backend.
+func NewTaggerWithRepository(repo Repository, prefix string, dryRun bool) *Tagger {
+	return &Tagger{
+		prefix: prefix,
+		dryRun: dryRun,
+		repo:   repo,
+	}
+}
+
+// git returns the git backend of the Tagger.
+func (t *Tagger) git(ctx context.Context) Repository {
+	if t.repo != nil {
+		return t.repo
+	}
+	return Open(ctx, t.repoDir)
+}
+
+// WithScheme sets the versioning scheme used to parse and order tags, and returns
+// the Tagger. For CalVer, calverFormat limits the tags to that format; it may be empty.
+func (t *Tagger) WithScheme(scheme version.Scheme, calverFormat string) *Tagger {
+	t.scheme = scheme
+	t.calverFormat = calverFormat
+	return t
+}
+
+// LatestTag returns the latest tag with the configured prefix by version precedence,
+// or empty string if none exists. Tags that don't parse as a version are skipped.
 func (t *Tagger) LatestTag(ctx context.Context) (string, error) {
 	logger := log.FromContext(ctx)
 
-	// List all tags matching the prefix, sorted by version
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-l", t.prefix+"*", "--sort=-version:refname")
-	if !result.Success() {
-		// If git tag fails, it might be because there are no tags yet
-		if result.ExitCode == 0 || strings.Contains(result.Stderr, "not a git repository") {
-			return "", fmt.Errorf("not a git repository or git not available: %s", result.Stderr)
+	tags, err := t.versionTags(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 {
+		logger.Debugf("no tags found with prefix %s", t.prefix)
+		return "", nil
+	}
+
+	logger.Debugf("found latest tag: %s", tags[0].tag)
+	return tags[0].tag, nil
+}
+
+// versionTag is a tag parsed for ordering. hotfix is the number of a hotfix tag
+// that extends its base version, like v1.0.0-hotfix.2 or the fallback v1.0.0.2;
+// it ranks right after the base.
+type versionTag struct {
+	tag     string
+	version *version.Version
+	hotfix  int
+	ref     TagRef
+}
+
+// versionTags returns the tags with the configured prefix that parse under the
+// scheme, ordered by version precedence, newest first. Tags that look like a version
+// but don't parse are reported once as a warning.
+func (t *Tagger) versionTags(ctx context.Context) ([]versionTag, error) {
+	refs, err := t.git(ctx).Tags(ctx, t.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]versionTag, 0, len(refs))
+	var invalid []string
+	for _, ref := range refs {
+		if tag, ok := t.parseTag(ref.Name); ok {
+			tag.ref = ref
+			tags = append(tags, tag)
+		} else if looksLikeVersion(version.StripPrefix(ref.Name, t.prefix)) {
+			invalid = append(invalid, ref.Name)
 		}
-		// Empty output is fine - no tags yet
-		if result.Stdout == "" {
-			return "", nil
+	}
+
+	if len(invalid) > 0 && !t.reportedInvalid {
+		t.reportedInvalid = true
+		scheme := string(t.scheme)
+		if scheme == "" {
+			scheme = "semver or calver"
 		}
+		if t.calverFormat != "" && t.scheme == version.SchemeCalVer {
+			scheme += " " + t.calverFormat
+		}
+		log.FromContext(ctx).Warnf("ignoring tags that are not %s versions: %s", scheme, strings.Join(invalid, ", "))
 	}
 
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	if len(lines) == 0 || lines[0] == "" {
-		logger.Debugf("no tags found with prefix %s", t.prefix)
-		return "", nil
+	slices.SortFunc(tags, func(a, b versionTag) int {
+		return -compareVersionTags(a, b)
+	})
+	return tags, nil
+}
+
+// parseTag parses a tag under the scheme. A tag that doesn't parse but ends in a
+// number, like v1.0.0.1 or the CalVer hotfix 2025.44.1.1, is a hotfix of the rest.
+func (t *Tagger) parseTag(tag string) (versionTag, bool) {
+	s := version.StripPrefix(tag, t.prefix)
+
+	if version.IsHotfixVersion(s) {
+		if v, err := t.parseVersion(s[:strings.LastIndex(s, "-")]); err == nil {
+			return versionTag{tag: tag, version: v, hotfix: trailingNumber(s)}, true
+		}
+	}
+	if v, err := t.parseVersion(s); err == nil {
+		return versionTag{tag: tag, version: v}, true
+	}
+	if i := strings.LastIndex(s, "."); i > 0 {
+		if n, err := strconv.Atoi(s[i+1:]); err == nil && n > 0 {
+			if v, err := t.parseVersion(s[:i]); err == nil && v.Pre == "" && v.Meta == "" {
+				return versionTag{tag: tag, version: v, hotfix: n}, true
+			}
+		}
 	}
+	return versionTag{}, false
+}
+
+// ValidateTag returns an error if tag does not parse as a version under the scheme.
+// Hotfix tags like v1.0.0-hotfix.1, v1.0.0.1 or the CalVer 2025.44.1.1 are valid if
+// their base version is.
+func (t *Tagger) ValidateTag(tag string) error {
+	if _, ok := t.parseTag(tag); ok {
+		return nil
+	}
+	_, err := t.parseVersion(version.StripPrefix(tag, t.prefix))
+	return err
+}
+
+// parseVersion parses a version string (without prefix) under the scheme.
+func (t *Tagger) parseVersion(s string) (*version.Version, error) {
+	switch t.scheme {
+	case version.SchemeSemVer:
+		return version.ParseSemVer(s)
+	case version.SchemeCalVer:
+		if t.calverFormat != "" {
+			return version.ParseCalVerFormat(s, t.calverFormat)
+		}
+		return version.ParseCalVer(s)
+	default:
+		if v, err := version.ParseSemVer(s); err == nil {
+			return v, nil
+		}
+		return version.ParseCalVer(s)
+	}
+}
+
+// compareVersionTags orders tags by version precedence, then hotfix number. Equal
+// versions, like tags differing only in build metadata, are ordered by name.
+func compareVersionTags(a, b versionTag) int {
+	if c := version.Compare(a.version, b.version); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.hotfix, b.hotfix); c != 0 {
+		return c
+	}
+	return strings.Compare(a.tag, b.tag)
+}
 
-	latestTag := lines[0]
-	logger.Debugf("found latest tag: %s", latestTag)
-	return latestTag, nil
+// looksLikeVersion reports whether a tag (without prefix) was probably meant as a
+// version of this app: it starts with a digit and has no further namespace.
+func looksLikeVersion(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9' && !strings.Contains(s, "/")
 }
 
 // ParseLatestVersion returns the parsed version of the latest tag, or nil if no tag exists.
@@ -75,48 +223,127 @@ func (t *Tagger) ParseLatestVersion(ctx context.Context, scheme version.Scheme)
 	case version.SchemeSemVer:
 		return version.ParseSemVer(versionStr)
 	case version.SchemeCalVer:
+		if t.calverFormat != "" {
+			return version.ParseCalVerFormat(versionStr, t.calverFormat)
+		}
 		return version.ParseCalVer(versionStr)
 	default:
 		return nil, fmt.Errorf("unknown version scheme: %s", scheme)
 	}
 }
 
-// LatestStableTag returns the most recent tag that is a stable (non-prerelease) SemVer version.
-// It iterates over all matching tags (git-sorted, newest first) and returns the first one
-// whose parsed version has no prerelease identifier.
-// Returns an empty string if no stable tags are found.
+// LatestStableTag returns the most recent tag that is a stable release: no prerelease
+// identifier and not a hotfix of another version. Without a scheme, only SemVer tags
+// count. Returns an empty string if no stable tags are found.
 func (t *Tagger) LatestStableTag(ctx context.Context) (string, error) {
-	logger := log.FromContext(ctx)
+	tags, err := t.versionTags(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, tag := range tags {
+		if t.scheme == "" && tag.version.Scheme != version.SchemeSemVer {
+			continue // calver tag without a configured scheme, skip
+		}
+		if tag.version.IsStable() && tag.hotfix == 0 {
+			return tag.tag, nil
+		}
+	}
+	log.FromContext(ctx).Debugf("no stable tags found with prefix %s", t.prefix)
+	return "", nil
+}
+
+// ReleaseTag returns ref if it is a version tag with the configured prefix, otherwise
+// the newest version tag pointing at ref. Returns an empty string if there is none.
+func (t *Tagger) ReleaseTag(ctx context.Context, ref string) (string, error) {
+	if _, ok := t.parseTag(ref); ok && strings.HasPrefix(ref, t.prefix) {
+		return ref, nil
+	}
 
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-l", t.prefix+"*", "--sort=-version:refname")
-	if !result.Success() {
-		// Mirror LatestTag: surface repository/environment issues as errors.
-		if result.ExitCode == 0 || strings.Contains(result.Stderr, "not a git repository") {
-			return "", fmt.Errorf("not a git repository or git not available: %s", result.Stderr)
+	tags, err := t.TagsAt(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	var best *versionTag
+	for _, tag := range tags {
+		if v, ok := t.parseTag(tag); ok && (best == nil || compareVersionTags(v, *best) > 0) {
+			best = &v
 		}
-		// Empty output after a failed command is treated as "no tags yet".
-		if strings.TrimSpace(result.Stdout) == "" {
-			logger.Debugf("no tags found with prefix %s", t.prefix)
-			return "", nil
+	}
+	if best == nil {
+		return "", nil
+	}
+	return best.tag, nil
+}
+
+// PreviousTag returns the newest tag with the configured prefix that is reachable from ref
+// and ranks below the tag current. If current is empty, every reachable tag qualifies.
+// With stableOnly, prerelease tags are skipped. Tags are parsed like LatestTag, so
+// hotfix tags rank right after their base version. Returns an empty string if no tag
+// qualifies.
+func (t *Tagger) PreviousTag(ctx context.Context, ref, current string, stableOnly bool) (string, error) {
+	var cur *versionTag
+	if current != "" {
+		v, ok := t.parseTag(current)
+		if !ok {
+			return "", fmt.Errorf("%s is not a version tag", current)
 		}
+		cur = &v
 	}
 
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	for _, tag := range lines {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
+	tags, err := t.git(ctx).MergedTags(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	var best *versionTag
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, t.prefix) {
 			continue
 		}
-		vStr := version.StripPrefix(tag, t.prefix)
-		v, err := version.ParseSemVer(vStr)
-		if err != nil {
-			continue // non-semver tag (e.g., calver), skip
+		v, ok := t.parseTag(tag)
+		if !ok || (stableOnly && !v.version.IsStable()) {
+			continue
+		}
+		if cur != nil {
+			// Tags differing only in build metadata are the same release
+			c := version.Compare(v.version, cur.version)
+			if c == 0 {
+				c = cmp.Compare(v.hotfix, cur.hotfix)
+			}
+			if c >= 0 {
+				continue
+			}
 		}
-		if v.IsStable() {
-			return tag, nil
+		if best == nil || compareVersionTags(v, *best) > 0 {
+			best = &v
 		}
 	}
-	return "", nil
+	if best == nil {
+		return "", nil
+	}
+	return best.tag, nil
+}
+
+// TagsAt returns the tags with the configured prefix that point at ref.
+func (t *Tagger) TagsAt(ctx context.Context, ref string) ([]string, error) {
+	commit, err := t.git(ctx).ResolveRef(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("list tags at %s: %w", ref, err)
+	}
+	refs, err := t.git(ctx).Tags(ctx, t.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, tag := range refs {
+		if tag.Commit == commit {
+			tags = append(tags, tag.Name)
+		}
+	}
+	slices.Sort(tags)
+	return tags, nil
 }
 
 // ParseLatestStableVersion returns the parsed stable version from the latest stable tag.
@@ -132,45 +359,49 @@ func (t *Tagger) ParseLatestStableVersion(ctx context.Context) (*version.Version
 		return nil, nil
 	}
 	vStr := version.StripPrefix(tag, t.prefix)
-	return version.ParseSemVer(vStr)
+	return t.parseVersion(vStr)
 }
 
 // TagExists checks if a tag already exists.
 func (t *Tagger) TagExists(ctx context.Context, tag string) (bool, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-l", tag)
-	if !result.Success() {
-		return false, result.MustSucceed("check if tag exists")
+	_, err := t.git(ctx).ResolveRef(ctx, "refs/tags/"+tag)
+	if errors.Is(err, ErrUnknownRef) {
+		return false, nil
 	}
-
-	return strings.TrimSpace(result.Stdout) != "", nil
+	if err != nil {
+		return false, fmt.Errorf("check if tag exists: %w", err)
+	}
+	return true, nil
 }
 
 // GetTagCommit returns the full commit hash that the given tag points to.
 // For annotated tags, it dereferences the tag object to the underlying commit.
 func (t *Tagger) GetTagCommit(ctx context.Context, tag string) (string, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", tag+"^{}")
-	if !result.Success() {
-		return "", fmt.Errorf("tag %q not found or invalid: %s", tag, strings.TrimSpace(result.Stderr))
+	commit, err := t.git(ctx).ResolveRef(ctx, tag+"^{}")
+	if err != nil {
+		return "", fmt.Errorf("tag %q not found or invalid: %w", tag, err)
 	}
-	return strings.TrimSpace(result.Stdout), nil
+	return commit, nil
 }
 
 // ResolveCommit resolves any ref (commit hash, branch name, HEAD, etc.) to a full commit hash.
 func (t *Tagger) ResolveCommit(ctx context.Context, ref string) (string, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", ref)
-	if !result.Success() {
-		return "", fmt.Errorf("cannot resolve ref %q: %s", ref, strings.TrimSpace(result.Stderr))
-	}
-	return strings.TrimSpace(result.Stdout), nil
+	return t.git(ctx).ResolveRef(ctx, ref)
 }
 
 // CreateTag creates an annotated tag with the given name and message.
 // If dryRun is true, only logs the operation without creating the tag.
 func (t *Tagger) CreateTag(ctx context.Context, tag, message string) error {
+	return t.CreateTagAt(ctx, tag, "HEAD", message)
+}
+
+// CreateTagAt creates an annotated tag on the target commit-ish.
+// If dryRun is true, only logs the operation without creating the tag.
+func (t *Tagger) CreateTagAt(ctx context.Context, tag, target, message string) error {
 	logger := log.FromContext(ctx)
 
 	if t.dryRun {
-		logger.Debugf("dry-run: would create tag %s with message %s", tag, message)
+		logger.Debugf("dry-run: would create tag %s on %s with message %s", tag, target, message)
 		return nil
 	}
 
@@ -183,8 +414,7 @@ func (t *Tagger) CreateTag(ctx context.Context, tag, message string) error {
 		return fmt.Errorf("tag %s already exists", tag)
 	}
 
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-a", tag, "-m", message)
-	if err := result.MustSucceed("create tag"); err != nil {
+	if err := t.git(ctx).CreateTag(ctx, tag, target, message, false); err != nil {
 		return err
 	}
 
@@ -202,8 +432,7 @@ func (t *Tagger) PushTag(ctx context.Context, tag string) error {
 		return nil
 	}
 
-	result := run.CmdInDir(ctx, t.repoDir, "git", "push", "origin", tag)
-	if err := result.MustSucceed("push tag"); err != nil {
+	if err := t.git(ctx).Push(ctx, "origin", []string{tag}, false); err != nil {
 		return err
 	}
 
@@ -222,9 +451,8 @@ func (t *Tagger) MoveTag(ctx context.Context, tag, target, message string) error
 		return nil
 	}
 
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-f", "-a", tag, target, "-m", message)
-	if err := result.MustSucceed("move tag"); err != nil {
-		return err
+	if err := t.git(ctx).CreateTag(ctx, tag, target, message, true); err != nil {
+		return fmt.Errorf("move tag: %w", err)
 	}
 
 	logger.Debugf("moved tag %s to %s", tag, target)
@@ -242,8 +470,7 @@ func (t *Tagger) PushTagForce(ctx context.Context, tag string) error {
 		return nil
 	}
 
-	result := run.CmdInDir(ctx, t.repoDir, "git", "push", "--force", "origin", tag)
-	if err := result.MustSucceed("force-push tag"); err != nil {
+	if err := t.git(ctx).Push(ctx, "origin", []string{tag}, true); err != nil {
 		return err
 	}
 
@@ -253,30 +480,27 @@ func (t *Tagger) PushTagForce(ctx context.Context, tag string) error {
 
 // CurrentCommit returns the current commit hash.
 func (t *Tagger) CurrentCommit(ctx context.Context) (string, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", "HEAD")
-	if err := result.MustSucceed("get current commit"); err != nil {
-		return "", err
+	commit, err := t.git(ctx).ResolveRef(ctx, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("get current commit: %w", err)
 	}
-	return strings.TrimSpace(result.Stdout), nil
+	return commit, nil
 }
 
 // ShortCommit returns the short commit hash (first 7 characters).
 func (t *Tagger) ShortCommit(ctx context.Context) (string, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", "--short", "HEAD")
-	if err := result.MustSucceed("get short commit"); err != nil {
-		return "", err
+	for entry, err := range t.git(ctx).Log(ctx, LogOptions{Range: "HEAD"}) {
+		if err != nil {
+			return "", fmt.Errorf("get short commit: %w", err)
+		}
+		return entry.ShortHash, nil
 	}
-	return strings.TrimSpace(result.Stdout), nil
+	return "", errors.New("get short commit: no commits yet")
 }
 
 // HasUncommittedChanges checks if there are uncommitted changes in the repository.
 func (t *Tagger) HasUncommittedChanges(ctx context.Context) (bool, error) {
-	// Check for modified/added/deleted files
-	result := run.CmdInDir(ctx, t.repoDir, "git", "status", "--porcelain")
-	if !result.Success() {
-		return false, result.MustSucceed("check git status")
-	}
-	return strings.TrimSpace(result.Stdout) != "", nil
+	return t.git(ctx).IsDirty(ctx)
 }
 
 // IsTagOnCurrentCommit checks if the given tag points to the current commit.
@@ -286,19 +510,17 @@ func (t *Tagger) IsTagOnCurrentCommit(ctx context.Context, tag string) (bool, er
 	}
 
 	// Get commit hash for the tag
-	tagResult := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", tag+"^{}")
-	if !tagResult.Success() {
+	tagCommit, err := t.git(ctx).ResolveRef(ctx, tag+"^{}")
+	if err != nil {
 		// Tag might not exist or be invalid
-		return false, nil
+		return false, nil //nolint:nilerr // a missing tag is not on the current commit
 	}
-	tagCommit := strings.TrimSpace(tagResult.Stdout)
 
 	// Get current commit hash
-	headResult := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", "HEAD")
-	if !headResult.Success() {
-		return false, headResult.MustSucceed("get current commit")
+	headCommit, err := t.CurrentCommit(ctx)
+	if err != nil {
+		return false, err
 	}
-	headCommit := strings.TrimSpace(headResult.Stdout)
 
 	return tagCommit == headCommit, nil
 }
@@ -342,11 +564,11 @@ func (t *Tagger) CalculateNextVersion(
 		}
 
 	case version.SchemeCalVer:
-		current, err := t.ParseLatestVersion(ctx, scheme)
+		var err error
+		next, err = t.nextCalVer(ctx, calverFormat)
 		if err != nil {
-			return nil, fmt.Errorf("parse latest version: %w", err)
+			return nil, err
 		}
-		next = version.NextCalVer(current, calverFormat, time.Now())
 
 	default:
 		return nil, fmt.Errorf("unknown version scheme: %s", scheme)
@@ -363,6 +585,58 @@ func (t *Tagger) CalculateNextVersion(
 	return next, nil
 }
 
+// LatestCalVerTag returns the latest CalVer release among the tags in calverFormat and
+// its version, leaving out hotfix tags. Returns an empty tag if there is none.
+func (t *Tagger) LatestCalVerTag(ctx context.Context, calverFormat string) (string, *version.Version, error) {
+	tags, err := t.listTags(ctx, t.prefix+"*")
+	if err != nil {
+		return "", nil, err
+	}
+	bases, err := t.hotfixBases(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		latest    string
+		latestVer *version.Version
+	)
+	for _, tag := range tags {
+		if _, hotfix := bases[tag]; hotfix || version.IsHotfixVersion(tag) {
+			continue
+		}
+		v, err := version.ParseCalVerFormat(version.StripPrefix(tag, t.prefix), calverFormat)
+		if err != nil {
+			continue // other formats and hotfix numbers
+		}
+		if latestVer == nil || version.Compare(v, latestVer) > 0 {
+			latest, latestVer = tag, v
+		}
+	}
+	return latest, latestVer, nil
+}
+
+// nextCalVer returns the next CalVer release of the default branch, following the
+// latest release. Sequence numbers already taken by a tag are skipped.
+func (t *Tagger) nextCalVer(ctx context.Context, calverFormat string) (*version.Version, error) {
+	_, current, err := t.LatestCalVerTag(ctx, calverFormat)
+	if err != nil {
+		return nil, fmt.Errorf("parse latest version: %w", err)
+	}
+
+	next := version.NextCalVer(current, calverFormat, time.Now())
+	for {
+		exists, err := t.TagExists(ctx, version.WithPrefix(next.String(), t.prefix))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return next, nil
+		}
+		next.CalVerSequence++
+	}
+}
+
 // CommitVersionUpdate commits a version file update (like package.json).
 // It stages the file, creates a commit with a standard message.
 func (t *Tagger) CommitVersionUpdate(ctx context.Context, filePath, version string) error {
@@ -373,17 +647,10 @@ func (t *Tagger) CommitVersionUpdate(ctx context.Context, filePath, version stri
 		return nil
 	}
 
-	// Stage the file
-	result := run.CmdInDir(ctx, t.repoDir, "git", "add", filePath)
-	if err := result.MustSucceed("stage file"); err != nil {
-		return err
-	}
-
-	// Create commit
+	// Stage the file and create the commit
 	commitMsg := fmt.Sprintf("chore: bump version to %s", version)
-	result = run.CmdInDir(ctx, t.repoDir, "git", "commit", "-m", commitMsg)
-	if err := result.MustSucceed("commit version update"); err != nil {
-		return err
+	if err := t.git(ctx).Commit(ctx, commitMsg, filePath); err != nil {
+		return fmt.Errorf("commit version update: %w", err)
 	}
 
 	logger.Debugf("committed version update: %s", commitMsg)
@@ -431,11 +698,11 @@ func (t *Tagger) CreateNextTag(
 		}
 
 	case version.SchemeCalVer:
-		current, err := t.ParseLatestVersion(ctx, scheme)
+		var err error
+		next, err = t.nextCalVer(ctx, calverFormat)
 		if err != nil {
-			return "", fmt.Errorf("parse latest version: %w", err)
+			return "", err
 		}
-		next = version.NextCalVer(current, calverFormat, time.Now())
 
 	default:
 		return "", fmt.Errorf("unknown version scheme: %s", scheme)
@@ -518,6 +785,9 @@ func (t *Tagger) GetVersionWithDirtyCheck(ctx context.Context) (string, error) {
 	return versionStr, nil
 }
 
+// DateFormat is the layout of TagInfo.Date (git's %ci format).
+const DateFormat = "2006-01-02 15:04:05 -0700"
+
 // TagInfo represents information about a version tag.
 type TagInfo struct {
 	Tag     string
@@ -527,77 +797,40 @@ type TagInfo struct {
 	Message string
 }
 
-// ListAllTags returns all tags with the configured prefix, sorted by version (newest first).
-// For each tag, it includes the commit hash, date, and message.
+// ListAllTags returns all version tags with the configured prefix, sorted by version
+// precedence (newest first). For each tag, it includes the commit hash, date, and message.
 func (t *Tagger) ListAllTags(ctx context.Context) ([]TagInfo, error) {
 	logger := log.FromContext(ctx)
 
-	// List all tags matching the prefix, sorted by version
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-l", t.prefix+"*", "--sort=-version:refname")
-	if !result.Success() {
-		// If git tag fails, it might be because there are no tags yet
-		if result.ExitCode == 0 || strings.Contains(result.Stderr, "not a git repository") {
-			return nil, fmt.Errorf("not a git repository or git not available: %s", result.Stderr)
-		}
-		// Empty output is fine - no tags yet
-		if result.Stdout == "" {
-			return []TagInfo{}, nil
-		}
+	versionTags, err := t.versionTags(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	if len(lines) == 0 || lines[0] == "" {
+	if len(versionTags) == 0 {
 		logger.Debugf("no tags found with prefix %s", t.prefix)
 		return []TagInfo{}, nil
 	}
 
-	var tags []TagInfo
-	for _, tag := range lines {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
-			continue
-		}
-
-		// Get commit hash for the tag
-		commitResult := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", tag+"^{}")
-		if !commitResult.Success() {
-			logger.Warnf("failed to get commit for tag %s: %v", tag, commitResult.Stderr)
-			continue
-		}
-		commit := strings.TrimSpace(commitResult.Stdout)
-
-		// Get commit date and message (from the commit, not the tag annotation)
-		dateResult := run.CmdInDir(ctx, t.repoDir, "git", "log", "-1", "--format=%ci", commit)
-		date := strings.TrimSpace(dateResult.Stdout)
-		if !dateResult.Success() {
-			logger.Warnf("failed to get date for tag %s", tag)
-			date = ""
-		}
-
-		// Get commit message (the actual commit message, not the tag annotation)
-		msgResult := run.CmdInDir(ctx, t.repoDir, "git", "log", "-1", "--format=%s", commit)
-		message := strings.TrimSpace(msgResult.Stdout)
-		if !msgResult.Success() {
-			logger.Debugf("failed to get message for tag %s", tag)
-			message = ""
-		}
-
-		// Strip prefix from tag to get version
-		versionStr := version.StripPrefix(tag, t.prefix)
-
-		tags = append(tags, TagInfo{
-			Tag:     tag,
-			Version: versionStr,
-			Commit:  commit,
-			Date:    date,
-			Message: message,
-		})
+	tags := make([]TagInfo, 0, len(versionTags))
+	for _, vt := range versionTags {
+		tags = append(tags, t.tagInfo(vt.ref))
 	}
 
 	logger.Debugf("found %d tags with prefix %s", len(tags), t.prefix)
 	return tags, nil
 }
 
+// tagInfo converts loaded tag metadata to a TagInfo.
+func (t *Tagger) tagInfo(ref TagRef) TagInfo {
+	return TagInfo{
+		Tag:     ref.Name,
+		Version: version.StripPrefix(ref.Name, t.prefix),
+		Commit:  ref.Commit,
+		Date:    ref.CommitDate,
+		Message: ref.Subject,
+	}
+}
+
 // GetTagInfo retrieves detailed information for a specific tag.
 // It handles prefix auto-detection by trying both the exact tag name and with the configured prefix.
 // Returns TagInfo with all fields populated, or an error if the tag doesn't exist.
@@ -620,58 +853,21 @@ func (t *Tagger) GetTagInfo(ctx context.Context, tagName string) (*TagInfo, erro
 		}
 	}
 
-	// Try each variation until we find one that exists
-	var foundTag string
+	// Prefer the variations in order
 	for _, tag := range uniqueTags {
-		exists, err := t.TagExists(ctx, tag)
+		refs, err := t.git(ctx).Tags(ctx, tag)
 		if err != nil {
 			return nil, fmt.Errorf("check tag existence: %w", err)
 		}
-		if exists {
-			foundTag = tag
-			logger.Debugf("found tag: %s", foundTag)
-			break
+		for _, ref := range refs {
+			if ref.Name == tag {
+				logger.Debugf("found tag: %s", tag)
+				info := t.tagInfo(ref)
+				return &info, nil
+			}
 		}
 	}
-
-	// If no tag found, return error
-	if foundTag == "" {
-		return nil, fmt.Errorf("tag not found: %s (tried with prefix '%s')", tagName, t.prefix)
-	}
-
-	// Get commit hash for the tag
-	commitResult := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", foundTag+"^{}")
-	if !commitResult.Success() {
-		return nil, fmt.Errorf("get commit for tag %s: %s", foundTag, commitResult.Stderr)
-	}
-	commit := strings.TrimSpace(commitResult.Stdout)
-
-	// Get commit date
-	dateResult := run.CmdInDir(ctx, t.repoDir, "git", "log", "-1", "--format=%ci", commit)
-	date := strings.TrimSpace(dateResult.Stdout)
-	if !dateResult.Success() {
-		logger.Warnf("failed to get date for tag %s", foundTag)
-		date = ""
-	}
-
-	// Get commit message
-	msgResult := run.CmdInDir(ctx, t.repoDir, "git", "log", "-1", "--format=%s", commit)
-	message := strings.TrimSpace(msgResult.Stdout)
-	if !msgResult.Success() {
-		logger.Debugf("failed to get message for tag %s", foundTag)
-		message = ""
-	}
-
-	// Strip prefix from tag to get version
-	versionStr := version.StripPrefix(foundTag, t.prefix)
-
-	return &TagInfo{
-		Tag:     foundTag,
-		Version: versionStr,
-		Commit:  commit,
-		Date:    date,
-		Message: message,
-	}, nil
+	return nil, fmt.Errorf("tag not found: %s (tried with prefix '%s')", tagName, t.prefix)
 }
 
 // ============================================================================
@@ -679,12 +875,8 @@ func (t *Tagger) GetTagInfo(ctx context.Context, tagName string) (*TagInfo, erro
 // ============================================================================
 
 // GetCurrentBranch returns the currently checked out branch name.
-func GetCurrentBranch(repoDir string) (string, error) {
-	result := run.Cmd(context.Background(), "git", "-C", repoDir, "rev-parse", "--abbrev-ref", "HEAD")
-	if !result.Success() {
-		return "", fmt.Errorf("failed to get current branch: %s", result.Stderr)
-	}
-	return strings.TrimSpace(result.Stdout), nil
+func GetCurrentBranch(ctx context.Context, repoDir string) (string, error) {
+	return Open(ctx, repoDir).CurrentBranch(ctx)
 }
 
 // IsHotfixBranch checks if current branch matches hotfix pattern.
@@ -718,7 +910,7 @@ func (t *Tagger) CreateHotfixBranch(ctx context.Context, tag, branchPrefix strin
 	}
 
 	// Check if branch already exists
-	if t.branchExists(ctx, branchName) {
+	if _, err := t.git(ctx).ResolveRef(ctx, "refs/heads/"+branchName); err == nil {
 		return "", fmt.Errorf("branch %q already exists\nCheckout with: git checkout %s", branchName, branchName)
 	}
 
@@ -728,17 +920,15 @@ func (t *Tagger) CreateHotfixBranch(ctx context.Context, tag, branchPrefix strin
 	}
 
 	// Create branch from tag
-	result := run.CmdInDir(ctx, t.repoDir, "git", "branch", branchName, tag)
-	if err := result.MustSucceed("create hotfix branch"); err != nil {
-		return "", fmt.Errorf("failed to create branch: %w", err)
+	if err := t.git(ctx).CreateBranch(ctx, branchName, tag); err != nil {
+		return "", err
 	}
 
 	logger.Debugf("created hotfix branch: %s", branchName)
 
 	// Checkout if requested
 	if checkout {
-		result := run.CmdInDir(ctx, t.repoDir, "git", "checkout", branchName)
-		if err := result.MustSucceed("checkout hotfix branch"); err != nil {
+		if err := t.git(ctx).Checkout(ctx, branchName); err != nil {
 			return "", fmt.Errorf("failed to checkout branch: %w", err)
 		}
 		logger.Debugf("checked out branch: %s", branchName)
@@ -776,27 +966,311 @@ func (t *Tagger) GetNextHotfixTag(ctx context.Context, baseTag, suffix string) (
 	return nextTag, nextSeq, nil
 }
 
-// CreateHotfixTag creates a hotfix tag from current HEAD.
-func (t *Tagger) CreateHotfixTag(ctx context.Context, tag, message string) error {
-	return t.CreateTag(ctx, tag, message)
+// HotfixTags returns the hotfix tags of a base tag, ordered by sequence number.
+// Example: base "v1.0.0" → ["v1.0.0-hotfix.1", "v1.0.0-hotfix.2", "v1.0.0-hotfix.10"]
+func (t *Tagger) HotfixTags(ctx context.Context, baseTag, suffix string) ([]string, error) {
+	tags, err := t.listTags(ctx, fmt.Sprintf("%s-%s.*", baseTag, suffix))
+	if err != nil {
+		return nil, err
+	}
+
+	seqs := make(map[string]int, len(tags))
+	hotfixes := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		seq, err := parseHotfixSequence(tag, baseTag, suffix)
+		if err != nil {
+			continue // Skip malformed tags
+		}
+		seqs[tag] = seq
+		hotfixes = append(hotfixes, tag)
+	}
+
+	slices.SortFunc(hotfixes, func(a, b string) int { return cmp.Compare(seqs[a], seqs[b]) })
+	return hotfixes, nil
 }
 
-// ListBranches returns all branches in the repository.
-func ListBranches(repoDir string) ([]string, error) {
-	result := run.Cmd(context.Background(), "git", "-C", repoDir, "branch", "--format=%(refname:short)")
-	if !result.Success() {
-		return nil, fmt.Errorf("failed to list branches: %s", result.Stderr)
+// SplitHotfixTag splits a hotfix tag into its base tag, suffix and sequence number.
+// Example: "api/v1.5.0-hotfix.2" → "api/v1.5.0", "hotfix", 2
+func SplitHotfixTag(tag string) (string, string, int, error) {
+	_, suffix, seq, err := version.ParseHotfixVersion(tag)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return tag[:strings.LastIndex(tag, "-")], suffix, seq, nil
+}
+
+// HotfixNaming describes how the hotfix tags of a line are named.
+type HotfixNaming struct {
+	// Suffix is the prerelease or metadata identifier, e.g. "hotfix".
+	Suffix string
+	// Patch names hotfixes as the next free patch version (v1.5.1) instead of a
+	// prerelease of the base (v1.5.0-hotfix.1).
+	Patch bool
+	// FourPart names patch-style hotfixes whose patch is taken v1.5.0.1 instead of
+	// v1.5.0+hotfix.1.
+	FourPart bool
+	// CalVerFormat is the calver_format of CalVer apps. Their patch-style hotfixes
+	// number the release: 2025.44.1.1, or 2025.11.09.0.1 for a release without a
+	// sequence, so they can never be taken by a later release.
+	CalVerFormat string
+}
+
+// HotfixLine returns the hotfix tags of a base tag in release order. It covers both
+// naming styles: suffix tags like v1.5.0-hotfix.2, and patch-style tags like v1.5.1,
+// v1.5.0+hotfix.1 or v1.5.0.1, which are recognized by their Hotfix-Base trailer.
+func (t *Tagger) HotfixLine(ctx context.Context, baseTag, suffix string) ([]string, error) {
+	line, err := t.HotfixTags(ctx, baseTag, suffix)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := t.hotfixBases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var patchTags []string
+	for tag, base := range bases {
+		if base == baseTag && !slices.Contains(line, tag) {
+			patchTags = append(patchTags, tag)
+		}
+	}
+
+	// Patch versions first, then the fallbacks, each by their last number
+	slices.SortFunc(patchTags, func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(fallbackRank(baseTag, a), fallbackRank(baseTag, b)),
+			cmp.Compare(trailingNumber(a), trailingNumber(b)),
+			strings.Compare(a, b),
+		)
+	})
+	return append(line, patchTags...), nil
+}
+
+// HotfixBaseOf returns the base tag of a hotfix tag, or an empty string if tag is not
+// a hotfix. Patch-style hotfixes are identified by their Hotfix-Base trailer.
+func (t *Tagger) HotfixBaseOf(ctx context.Context, tag string) (string, error) {
+	bases, err := t.hotfixBases(ctx)
+	if err != nil {
+		return "", err
+	}
+	if base, ok := bases[tag]; ok {
+		return base, nil
+	}
+	if version.IsHotfixVersion(tag) {
+		base, _, _, err := SplitHotfixTag(tag)
+		if err != nil {
+			return "", err
+		}
+		return base, nil
+	}
+	return "", nil
+}
+
+// NextHotfix determines the next hotfix tag of a base tag and its sequence number in
+// the line. In patch style, it is the next patch after the line's last one; if that
+// version is already released from elsewhere, the line falls back to build metadata
+// (v1.5.0+hotfix.1) or a fourth number (v1.5.0.1) for good.
+func (t *Tagger) NextHotfix(ctx context.Context, baseTag string, naming HotfixNaming) (string, int, error) {
+	if !naming.Patch {
+		return t.GetNextHotfixTag(ctx, baseTag, naming.Suffix)
+	}
+	if naming.CalVerFormat != "" {
+		return t.nextCalVerHotfix(ctx, baseTag, naming)
+	}
+
+	logger := log.FromContext(ctx)
+
+	base, err := version.ParseSemVer(version.StripPrefix(baseTag, t.prefix))
+	if err != nil {
+		return "", 0, fmt.Errorf("patch-style hotfixes need a semver base tag: %w", err)
+	}
+
+	line, err := t.HotfixLine(ctx, baseTag, naming.Suffix)
+	if err != nil {
+		return "", 0, err
+	}
+
+	patch, fallbacks := base.Patch, 0
+	for _, tag := range line {
+		if isHotfixFallback(baseTag, tag) {
+			fallbacks++
+		} else if v, err := version.ParseSemVer(version.StripPrefix(tag, t.prefix)); err == nil && v.Patch > patch {
+			patch = v.Patch
+		}
 	}
 
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	branches := make([]string, 0, len(lines))
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			branches = append(branches, line)
+	if fallbacks == 0 {
+		next := fmt.Sprintf("%s%d.%d.%d", t.prefix, base.Major, base.Minor, patch+1)
+		exists, err := t.TagExists(ctx, next)
+		if err != nil {
+			return "", 0, err
+		}
+		if !exists {
+			return next, len(line) + 1, nil
+		}
+		logger.Warnf("%s is already released, falling back for hotfixes of %s", next, baseTag)
+	}
+
+	for n := fallbacks + 1; ; n++ {
+		next := fmt.Sprintf("%s+%s.%d", baseTag, naming.Suffix, n)
+		if naming.FourPart {
+			next = fmt.Sprintf("%s.%d", baseTag, n)
+		}
+		exists, err := t.TagExists(ctx, next)
+		if err != nil {
+			return "", 0, err
+		}
+		if !exists {
+			return next, len(line) + 1, nil
+		}
+	}
+}
+
+// nextCalVerHotfix returns the next patch-style hotfix of a CalVer base tag, which adds
+// a hotfix number to the release: 2025.44.1 → 2025.44.1.1.
+func (t *Tagger) nextCalVerHotfix(ctx context.Context, baseTag string, naming HotfixNaming) (string, int, error) {
+	base, err := version.ParseCalVerFormat(version.StripPrefix(baseTag, t.prefix), naming.CalVerFormat)
+	if err != nil {
+		return "", 0, fmt.Errorf("calver hotfixes need a release tag as base: %w", err)
+	}
+	if base.IsPrerelease() {
+		return "", 0, fmt.Errorf("calver hotfixes need a release tag as base, %s is a prerelease", baseTag)
+	}
+
+	// Without an explicit sequence, 2025.11.09.1 would be the day's next release
+	stem := baseTag
+	if base.CalVerSequence == 0 {
+		stem += ".0"
+	}
+
+	line, err := t.HotfixLine(ctx, baseTag, naming.Suffix)
+	if err != nil {
+		return "", 0, err
+	}
+
+	n := 0
+	for _, tag := range line {
+		if strings.HasPrefix(tag, stem+".") {
+			n = max(n, trailingNumber(tag))
+		}
+	}
+
+	for n++; ; n++ {
+		next := fmt.Sprintf("%s.%d", stem, n)
+		exists, err := t.TagExists(ctx, next)
+		if err != nil {
+			return "", 0, err
+		}
+		if !exists {
+			return next, len(line) + 1, nil
+		}
+	}
+}
+
+// PreviousHotfixTag returns the hotfix tag preceding tag in the line of baseTag, or
+// baseTag if tag is the first hotfix. A tag that is not released yet follows the
+// line's last hotfix.
+// Example: "v1.5.0-hotfix.2" → "v1.5.0-hotfix.1", "v1.5.0-hotfix.1" → "v1.5.0"
+func (t *Tagger) PreviousHotfixTag(ctx context.Context, baseTag, suffix, tag string) (string, error) {
+	line, err := t.HotfixLine(ctx, baseTag, suffix)
+	if err != nil {
+		return "", err
+	}
+
+	i := slices.Index(line, tag)
+	if i < 0 {
+		i = len(line)
+		// Suffix tags of an unreleased or deleted hotfix still order by sequence
+		if _, _, seq, err := SplitHotfixTag(tag); err == nil {
+			i = 0
+			for i < len(line) {
+				if s, err := parseHotfixSequence(line[i], baseTag, suffix); err != nil || s >= seq {
+					break
+				}
+				i++
+			}
+		}
+	}
+
+	if i == 0 {
+		return baseTag, nil
+	}
+	return line[i-1], nil
+}
+
+// hotfixBaseTrailer is the tag message trailer recording the base tag of a hotfix.
+const hotfixBaseTrailer = "Hotfix-Base"
+
+// CreateHotfixTag creates a hotfix tag of baseTag on the target commit-ish. The base is
+// recorded in a Hotfix-Base trailer, which identifies patch-style hotfixes later.
+func (t *Tagger) CreateHotfixTag(ctx context.Context, tag, baseTag, target, message string) error {
+	message = fmt.Sprintf("%s\n\n%s: %s", strings.TrimRight(message, "\n"), hotfixBaseTrailer, baseTag)
+	return t.CreateTagAt(ctx, tag, target, message)
+}
+
+// hotfixBases maps the tags carrying a Hotfix-Base trailer to their base tag.
+func (t *Tagger) hotfixBases(ctx context.Context) (map[string]string, error) {
+	refs, err := t.git(ctx).Tags(ctx, t.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hotfix tags: %w", err)
+	}
+
+	bases := map[string]string{}
+	for _, ref := range refs {
+		if base := trailerValue(ref.Annotation, hotfixBaseTrailer); base != "" {
+			bases[ref.Name] = base
+		}
+	}
+	return bases, nil
+}
+
+// trailerValue returns the value of the trailer key in the last paragraph of a tag
+// message, ignoring a signature, or empty if there is none.
+func trailerValue(message, key string) string {
+	message, _, _ = strings.Cut(message, "-----BEGIN ")
+	message = strings.TrimSpace(message)
+	i := strings.LastIndex(message, "\n\n")
+	if i < 0 {
+		return "" // the first paragraph is the subject, not trailers
+	}
+
+	for line := range strings.Lines(message[i+2:]) {
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), key) {
+			return strings.TrimSpace(value)
 		}
 	}
-	return branches, nil
+	return ""
+}
+
+// isHotfixFallback reports whether a patch-style hotfix tag uses a fallback name,
+// i.e. build metadata or a fourth number on the base tag.
+func isHotfixFallback(baseTag, tag string) bool {
+	return strings.HasPrefix(tag, baseTag+"+") || strings.HasPrefix(tag, baseTag+".")
+}
+
+// fallbackRank orders patch versions (0) before fallback names (1).
+func fallbackRank(baseTag, tag string) int {
+	if isHotfixFallback(baseTag, tag) {
+		return 1
+	}
+	return 0
+}
+
+// trailingNumber returns the number at the end of a tag, or 0 if there is none.
+func trailingNumber(tag string) int {
+	i := len(tag)
+	for i > 0 && tag[i-1] >= '0' && tag[i-1] <= '9' {
+		i--
+	}
+	n, _ := strconv.Atoi(tag[i:])
+	return n
+}
+
+// ListBranches returns all branches in the repository.
+func ListBranches(ctx context.Context, repoDir string) ([]string, error) {
+	return Open(ctx, repoDir).Branches(ctx)
 }
 
 // ValidateHotfixBaseTag ensures tag is valid for hotfix creation.
@@ -811,17 +1285,13 @@ func ValidateHotfixBaseTag(ctx context.Context, repoDir, tag string) error {
 		return fmt.Errorf("tag %q does not exist", tag)
 	}
 
-	// Cannot be a hotfix tag itself
-	if version.IsHotfixVersion(tag) {
-		base, _, _, _ := version.ParseHotfixVersion(tag)
-		if base != nil {
-			return fmt.Errorf(
-				"cannot create hotfix from hotfix version %q\nUse the base version instead: %s",
-				tag,
-				base.String(),
-			)
-		}
-		return fmt.Errorf("cannot create hotfix from hotfix version %q", tag)
+	// Cannot be a hotfix tag itself, of either naming style
+	base, err := tagger.HotfixBaseOf(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to check tag: %w", err)
+	}
+	if base != "" {
+		return fmt.Errorf("cannot create hotfix from hotfix version %q\nUse the base version instead: %s", tag, base)
 	}
 
 	return nil
@@ -842,32 +1312,43 @@ func ValidateWorkingTreeClean(ctx context.Context, repoDir string) error {
 
 // Helper functions
 
-// branchExists checks if a branch exists.
-func (t *Tagger) branchExists(ctx context.Context, branchName string) bool {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "rev-parse", "--verify", branchName)
-	return result.Success()
-}
-
-// listTags lists all tags matching the pattern.
+// listTags lists all tags matching the pattern, sorted by name. A "*" in the pattern
+// matches any sequence of characters, slashes included, as with git tag -l.
 func (t *Tagger) listTags(ctx context.Context, pattern string) ([]string, error) {
-	result := run.CmdInDir(ctx, t.repoDir, "git", "tag", "-l", pattern)
-	if !result.Success() {
-		return nil, fmt.Errorf("failed to list tags: %s", result.Stderr)
+	literal, _, _ := strings.Cut(pattern, "*")
+	refs, err := t.git(ctx).Tags(ctx, literal)
+	if err != nil {
+		return nil, err
 	}
 
-	if result.Stdout == "" {
-		return []string{}, nil
+	tags := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if matchTagPattern(pattern, ref.Name) {
+			tags = append(tags, ref.Name)
+		}
 	}
+	slices.Sort(tags)
+	return tags, nil
+}
 
-	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
-	tags := make([]string, 0, len(lines))
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			tags = append(tags, line)
+// matchTagPattern reports whether name matches a pattern whose only wildcard is "*".
+func matchTagPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for i, part := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(name, part)
 		}
+		idx := strings.Index(name, part)
+		if idx < 0 {
+			return false
+		}
+		name = name[idx+len(part):]
 	}
-	return tags, nil
+	return name == ""
 }
 
 // parseHotfixSequence extracts the sequence number from a hotfix tag.
@@ -3,7 +3,10 @@ package git
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alexjoedt/forge/internal/run"
 	"github.com/alexjoedt/forge/internal/version"
@@ -412,3 +415,362 @@ func TestCalculatePreRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestPreviousTag(t *testing.T) {
+	dir := initTestRepo(t)
+	for _, tag := range []string{"v1.5.0", "v1.6.0-rc.1", "v1.6.0-rc.2", "v1.6.0-rc.10", "v1.6.0"} {
+		addAnnotatedTag(t, dir, tag)
+	}
+	tagger := NewTagger(dir, "v", false).WithScheme(version.SchemeSemVer, "")
+
+	tests := []struct {
+		name       string
+		ref        string
+		current    string
+		stableOnly bool
+		want       string
+	}{
+		{name: "stable spans prereleases", ref: "v1.6.0", current: "v1.6.0", stableOnly: true, want: "v1.5.0"},
+		{name: "stable preceded by rc", ref: "v1.6.0", current: "v1.6.0", want: "v1.6.0-rc.10"},
+		{name: "rc since stable", ref: "v1.6.0-rc.10", current: "v1.6.0-rc.10", stableOnly: true, want: "v1.5.0"},
+		{name: "rc since previous rc", ref: "v1.6.0-rc.10", current: "v1.6.0-rc.10", want: "v1.6.0-rc.2"},
+		{name: "unreleased ref", ref: "HEAD", stableOnly: true, want: "v1.6.0"},
+		{name: "first release", ref: "v1.5.0", current: "v1.5.0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tagger.PreviousTag(t.Context(), tt.ref, tt.current, tt.stableOnly)
+			if err != nil {
+				t.Fatalf("PreviousTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PreviousTag(%s, stableOnly=%v) = %q, want %q", tt.ref, tt.stableOnly, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPreviousTagHotfix mixes hotfix tags of both naming styles with release candidates.
+// Hotfixes rank right after their base, so they start the range of the next release.
+func TestPreviousTagHotfix(t *testing.T) {
+	tests := []struct {
+		name       string
+		tags       []string
+		scheme     version.Scheme
+		format     string
+		ref        string
+		stableOnly bool
+		want       string
+	}{
+		{
+			name:       "suffix hotfix after its base",
+			tags:       []string{"v1.5.0", "v1.5.0-hotfix.1"},
+			ref:        "v1.5.0-hotfix.1",
+			stableOnly: true,
+			want:       "v1.5.0",
+		},
+		{
+			name:       "four-part hotfix after suffix hotfix",
+			tags:       []string{"v1.5.0", "v1.5.0-hotfix.1", "v1.5.0.2"},
+			ref:        "v1.5.0.2",
+			stableOnly: true,
+			want:       "v1.5.0-hotfix.1",
+		},
+		{
+			name:       "rc since the last hotfix",
+			tags:       []string{"v1.5.0", "v1.5.0-hotfix.1", "v1.5.0.2", "v1.6.0-rc.1"},
+			ref:        "v1.6.0-rc.1",
+			stableOnly: true,
+			want:       "v1.5.0.2",
+		},
+		{
+			name: "stable since the previous rc",
+			tags: []string{"v1.5.0", "v1.5.0-hotfix.1", "v1.6.0-rc.1", "v1.6.0"},
+			ref:  "v1.6.0",
+			want: "v1.6.0-rc.1",
+		},
+		{
+			name:       "calver patch hotfix",
+			tags:       []string{"v2025.44.0", "v2025.44.0.1", "v2025.45.0-rc.1", "v2025.45.0"},
+			scheme:     version.SchemeCalVer,
+			format:     "2006.WW",
+			ref:        "v2025.45.0",
+			stableOnly: true,
+			want:       "v2025.44.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := initTestRepo(t)
+			for _, tag := range tt.tags {
+				addAnnotatedTag(t, dir, tag)
+			}
+			scheme := tt.scheme
+			if scheme == "" {
+				scheme = version.SchemeSemVer
+			}
+			tagger := NewTagger(dir, "v", false).WithScheme(scheme, tt.format)
+
+			got, err := tagger.PreviousTag(t.Context(), tt.ref, tt.ref, tt.stableOnly)
+			if err != nil {
+				t.Fatalf("PreviousTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PreviousTag(%s, stableOnly=%v) = %q, want %q", tt.ref, tt.stableOnly, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseTag(t *testing.T) {
+	dir := initTestRepo(t)
+	addAnnotatedTag(t, dir, "v1.5.0")
+	addAnnotatedTag(t, dir, "v1.5.0.1")
+	if r := run.CmdInDir(t.Context(), dir, "git", "tag", "v1.5.0-hotfix.1", "v1.5.0.1"); !r.Success() {
+		t.Fatalf("git tag failed: %s", r.Stderr)
+	}
+	tagger := NewTagger(dir, "v", false).WithScheme(version.SchemeSemVer, "")
+
+	for ref, want := range map[string]string{"HEAD": "v1.5.0.1", "v1.5.0": "v1.5.0", "HEAD~1": "v1.5.0"} {
+		got, err := tagger.ReleaseTag(t.Context(), ref)
+		must(t, err)
+		if got != want {
+			t.Errorf("ReleaseTag(%s) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestPreviousHotfixTag(t *testing.T) {
+	dir := initTestRepo(t)
+	for _, tag := range []string{"v1.5.0", "v1.5.0-hotfix.1", "v1.5.0-hotfix.2", "v1.5.0-hotfix.10"} {
+		addAnnotatedTag(t, dir, tag)
+	}
+	tagger := NewTagger(dir, "v", false)
+
+	hotfixes, err := tagger.HotfixTags(t.Context(), "v1.5.0", "hotfix")
+	must(t, err)
+	want := []string{"v1.5.0-hotfix.1", "v1.5.0-hotfix.2", "v1.5.0-hotfix.10"}
+	if strings.Join(hotfixes, ",") != strings.Join(want, ",") {
+		t.Errorf("HotfixTags() = %v, want %v", hotfixes, want)
+	}
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "v1.5.0-hotfix.1", want: "v1.5.0"},
+		{tag: "v1.5.0-hotfix.2", want: "v1.5.0-hotfix.1"},
+		{tag: "v1.5.0-hotfix.10", want: "v1.5.0-hotfix.2"},
+		{tag: "v1.5.0-hotfix.11", want: "v1.5.0-hotfix.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := tagger.PreviousHotfixTag(t.Context(), "v1.5.0", "hotfix", tt.tag)
+			if err != nil {
+				t.Fatalf("PreviousHotfixTag() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PreviousHotfixTag(%s) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextHotfixPatchStyle(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+	addAnnotatedTag(t, dir, "v1.5.0")
+	tagger := NewTagger(dir, "v", false)
+	naming := HotfixNaming{Suffix: "hotfix", Patch: true}
+
+	bump := func(want string, wantSeq int, naming HotfixNaming) {
+		t.Helper()
+		got, seq, err := tagger.NextHotfix(ctx, "v1.5.0", naming)
+		if err != nil {
+			t.Fatalf("NextHotfix() error = %v", err)
+		}
+		if got != want || seq != wantSeq {
+			t.Fatalf("NextHotfix() = %s, %d, want %s, %d", got, seq, want, wantSeq)
+		}
+		must(t, tagger.CreateHotfixTag(ctx, got, "v1.5.0", "HEAD", "Hotfix "+got))
+	}
+
+	bump("v1.5.1", 1, naming)
+	bump("v1.5.2", 2, naming)
+
+	// v1.5.3 was released from main, so the line falls back for good
+	addAnnotatedTag(t, dir, "v1.5.3")
+	bump("v1.5.0+hotfix.1", 3, naming)
+	bump("v1.5.0+hotfix.2", 4, naming)
+
+	line, err := tagger.HotfixLine(ctx, "v1.5.0", "hotfix")
+	must(t, err)
+	want := []string{"v1.5.1", "v1.5.2", "v1.5.0+hotfix.1", "v1.5.0+hotfix.2"}
+	if strings.Join(line, ",") != strings.Join(want, ",") {
+		t.Errorf("HotfixLine() = %v, want %v", line, want)
+	}
+
+	base, err := tagger.HotfixBaseOf(ctx, "v1.5.2")
+	must(t, err)
+	if base != "v1.5.0" {
+		t.Errorf("HotfixBaseOf(v1.5.2) = %q, want v1.5.0", base)
+	}
+	if base, _ := tagger.HotfixBaseOf(ctx, "v1.5.3"); base != "" {
+		t.Errorf("HotfixBaseOf(v1.5.3) = %q, want none for a release from main", base)
+	}
+
+	prev, err := tagger.PreviousHotfixTag(ctx, "v1.5.0", "hotfix", "v1.5.0+hotfix.1")
+	must(t, err)
+	if prev != "v1.5.2" {
+		t.Errorf("PreviousHotfixTag(v1.5.0+hotfix.1) = %q, want v1.5.2", prev)
+	}
+
+	next, _, err := tagger.NextHotfix(ctx, "v1.5.0", HotfixNaming{Suffix: "hotfix", Patch: true, FourPart: true})
+	must(t, err)
+	if next != "v1.5.0.3" {
+		t.Errorf("NextHotfix() four-part = %q, want v1.5.0.3", next)
+	}
+}
+
+func TestNextHotfixCalVer(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+	addAnnotatedTag(t, dir, "worker/2025.44.1")
+	addAnnotatedTag(t, dir, "worker/2025.11.09")
+	tagger := NewTagger(dir, "worker/", false)
+
+	tests := []struct {
+		name    string
+		base    string
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "week release", base: "worker/2025.44.1", format: "2006.WW",
+			want: []string{"worker/2025.44.1.1", "worker/2025.44.1.2"}},
+		{name: "day release without sequence", base: "worker/2025.11.09", format: "2006.01.02",
+			want: []string{"worker/2025.11.09.0.1", "worker/2025.11.09.0.2"}},
+		{name: "base in another format", base: "worker/2025.44", format: "2006.01.02", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			naming := HotfixNaming{Suffix: "hotfix", Patch: true, CalVerFormat: tt.format}
+			for i, want := range tt.want {
+				got, seq, err := tagger.NextHotfix(ctx, tt.base, naming)
+				must(t, err)
+				if got != want || seq != i+1 {
+					t.Fatalf("NextHotfix() = %s, %d, want %s, %d", got, seq, want, i+1)
+				}
+				must(t, tagger.CreateHotfixTag(ctx, got, tt.base, "HEAD", "Hotfix "+got))
+			}
+			if _, _, err := tagger.NextHotfix(ctx, tt.base, naming); (err != nil) != tt.wantErr {
+				t.Errorf("NextHotfix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculateNextVersionSkipsHotfixTags(t *testing.T) {
+	dir := initTestRepo(t)
+	ctx := t.Context()
+	tagger := NewTagger(dir, "worker/", false)
+
+	week := version.FormatCalVer(time.Now(), "2006.WW")
+	addAnnotatedTag(t, dir, "worker/"+week+".1")
+	// Hotfixes of this week's release sort above it, but must not count as releases
+	must(t, tagger.CreateHotfixTag(ctx, "worker/"+week+".1.1", "worker/"+week+".1", "HEAD", "Hotfix"))
+	addAnnotatedTag(t, dir, "worker/"+week+".1-hotfix.1")
+
+	next, err := tagger.CalculateNextVersion(ctx, version.SchemeCalVer, "", "2006.WW", "", "")
+	must(t, err)
+	if want := week + ".2"; next.String() != want {
+		t.Errorf("CalculateNextVersion() = %s, want %s", next, want)
+	}
+
+	// A name taken by a tag that is not a release is skipped
+	must(t, tagger.CreateHotfixTag(ctx, "worker/"+week+".2", "worker/"+week+".1", "HEAD", "Hotfix"))
+	next, err = tagger.CalculateNextVersion(ctx, version.SchemeCalVer, "", "2006.WW", "", "")
+	must(t, err)
+	if want := week + ".3"; next.String() != want {
+		t.Errorf("CalculateNextVersion() = %s, want %s", next, want)
+	}
+}
+
+func TestTagPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		scheme     version.Scheme
+		format     string
+		tags       []string
+		wantOrder  []string
+		wantStable string
+	}{
+		{
+			name:   "semver prereleases and hotfixes",
+			prefix: "v",
+			scheme: version.SchemeSemVer,
+			tags: []string{
+				"v0.9.0", "v0.10.0", "v1.0.0-rc.1", "v1.0.0", "v1.0.0-hotfix.1", "v1.0.0-hotfix.2",
+				"v1.1.0-rc.2", "v1.1.0-rc.10", "vnext", "v1.x",
+			},
+			wantOrder: []string{
+				"v1.1.0-rc.10", "v1.1.0-rc.2", "v1.0.0-hotfix.2", "v1.0.0-hotfix.1", "v1.0.0",
+				"v1.0.0-rc.1", "v0.10.0", "v0.9.0",
+			},
+			wantStable: "v1.0.0",
+		},
+		{
+			name:   "calver sequences and hotfixes",
+			prefix: "worker/",
+			scheme: version.SchemeCalVer,
+			format: "2006.WW",
+			tags: []string{
+				"worker/2025.9", "worker/2025.44", "worker/2025.44.1", "worker/2025.44.1.1",
+				"worker/2025.44.10", "worker/2025.44.2-rc.1",
+			},
+			wantOrder: []string{
+				"worker/2025.44.10", "worker/2025.44.2-rc.1", "worker/2025.44.1.1", "worker/2025.44.1",
+				"worker/2025.44", "worker/2025.9",
+			},
+			wantStable: "worker/2025.44.10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := initTestRepo(t)
+			ctx := t.Context()
+			for _, tag := range tt.tags {
+				addAnnotatedTag(t, dir, tag)
+			}
+			tagger := NewTagger(dir, tt.prefix, false).WithScheme(tt.scheme, tt.format)
+
+			latest, err := tagger.LatestTag(ctx)
+			must(t, err)
+			if latest != tt.wantOrder[0] {
+				t.Errorf("LatestTag() = %s, want %s", latest, tt.wantOrder[0])
+			}
+
+			stable, err := tagger.LatestStableTag(ctx)
+			must(t, err)
+			if stable != tt.wantStable {
+				t.Errorf("LatestStableTag() = %s, want %s", stable, tt.wantStable)
+			}
+
+			infos, err := tagger.ListAllTags(ctx)
+			must(t, err)
+			got := make([]string, 0, len(infos))
+			for _, info := range infos {
+				got = append(got, info.Tag)
+			}
+			if !slices.Equal(got, tt.wantOrder) {
+				t.Errorf("ListAllTags() = %v, want %v", got, tt.wantOrder)
+			}
+		})
+	}
+}
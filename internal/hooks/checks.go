@@ -0,0 +1,89 @@
+package hooks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+const (
+	// tagRefPrefix is the ref namespace of tags in pre-push input.
+	tagRefPrefix = "refs/tags/"
+	// pushRefFields is the number of fields per pre-push input line:
+	// <local ref> <local sha> <remote ref> <remote sha>.
+	pushRefFields = 4
+)
+
+// CheckCommitMsg lints the commit message file git passes to the commit-msg hook.
+func CheckCommitMsg(path string, rules changelog.LintRules) ([]changelog.Violation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read commit message: %w", err)
+	}
+	return changelog.LintMessage(string(data), rules), nil
+}
+
+// PushedTags returns the names of the tags in pre-push input. Tag deletions are skipped.
+func PushedTags(r io.Reader) ([]string, error) {
+	tags := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != pushRefFields || !strings.HasPrefix(fields[0], tagRefPrefix) {
+			continue
+		}
+		if strings.Trim(fields[1], "0") == "" {
+			continue // deleting a tag
+		}
+		tags = append(tags, strings.TrimPrefix(fields[0], tagRefPrefix))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read pushed refs: %w", err)
+	}
+	return tags, nil
+}
+
+// CheckTag verifies that a tag matching an app's prefix parses under that app's scheme,
+// the way forge reads its own tags, so hotfix tags of a valid version pass too.
+// The app with the longest matching prefix wins. Tags that match no app are accepted;
+// an empty prefix only claims tags that start with a digit.
+func CheckTag(cfg *config.Config, tag string) error {
+	var app *config.AppConfig
+	for name := range cfg.Apps {
+		ac := cfg.Apps[name]
+		if !matchesPrefix(tag, ac.Prefix) {
+			continue
+		}
+		if app == nil || len(ac.Prefix) > len(app.Prefix) {
+			app = &ac
+		}
+	}
+	if app == nil {
+		return nil
+	}
+
+	scheme := version.SchemeSemVer
+	if version.Scheme(app.Scheme) == version.SchemeCalVer {
+		scheme = version.SchemeCalVer
+	}
+	tagger := git.NewTagger("", app.Prefix, false).WithScheme(scheme, app.CalVerFormat)
+	if err := tagger.ValidateTag(tag); err != nil {
+		return fmt.Errorf("tag %s has prefix %q but is not a valid %s version: %w", tag, app.Prefix, app.Scheme, err)
+	}
+	return nil
+}
+
+// matchesPrefix reports whether tag belongs to an app with the given prefix.
+func matchesPrefix(tag, prefix string) bool {
+	if prefix == "" {
+		return tag != "" && tag[0] >= '0' && tag[0] <= '9'
+	}
+	return strings.HasPrefix(tag, prefix)
+}
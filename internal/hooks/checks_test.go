@@ -0,0 +1,73 @@
+package hooks
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/config"
+)
+
+func TestPushedTags(t *testing.T) {
+	input := strings.Join([]string{
+		"refs/heads/main 1111111 refs/heads/main 2222222",
+		"refs/tags/v1.2.3 3333333 refs/tags/v1.2.3 0000000",
+		"(delete) 0000000 refs/tags/v0.0.1 4444444",
+		"refs/tags/api/v2.0.0 5555555 refs/tags/api/v2.0.0 0000000",
+		"",
+	}, "\n")
+
+	tags, err := PushedTags(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v1.2.3", "api/v2.0.0"}; !slices.Equal(tags, want) {
+		t.Errorf("PushedTags() = %v, want %v", tags, want)
+	}
+}
+
+func TestCheckTag(t *testing.T) {
+	cfg := &config.Config{Apps: map[string]config.AppConfig{
+		"main":   {Scheme: "semver", Prefix: "v"},
+		"api":    {Scheme: "semver", Prefix: "api/v"},
+		"worker": {Scheme: "calver", Prefix: "worker/"},
+		"weekly": {Scheme: "calver", Prefix: "w/", CalVerFormat: "2006.WW"},
+		"daily":  {Scheme: "calver", Prefix: "", CalVerFormat: "2006.WW"},
+	}}
+
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{tag: "v1.2.3"},
+		{tag: "v1.2.3-rc.1"},
+		{tag: "v1.2.3-hotfix.1"},
+		{tag: "api/v2.0.0"},
+		{tag: "worker/2025.01.15"},
+		{tag: "release-candidate"},
+		{tag: "v1.2", wantErr: true},
+		{tag: "vnext", wantErr: true},
+		{tag: "api/v2", wantErr: true},
+		{tag: "worker/latest", wantErr: true},
+		{tag: "w/2025.44.1"},
+		{tag: "w/2025.44.1-rc.1"},
+		{tag: "w/2025.99.1", wantErr: true},
+		{tag: "w/1.2.3", wantErr: true},
+		// Hotfix tags forge creates: CalVer patch style and the four-part fallback
+		{tag: "worker/2025.44.1.1"},
+		{tag: "2025.44.0.1"},
+		{tag: "w/2025.44.1.2"},
+		{tag: "w/2025.99.1.1", wantErr: true},
+		{tag: "v1.5.0.1"},
+		{tag: "api/v2.0.0.3"},
+		{tag: "v1.5.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if err := CheckTag(cfg, tt.tag); (err != nil) != tt.wantErr {
+				t.Errorf("CheckTag(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
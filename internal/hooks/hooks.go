@@ -0,0 +1,215 @@
+// Package hooks installs forge-managed git hooks and implements their checks.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+// Hook names managed by forge.
+const (
+	CommitMsg = "commit-msg"
+	PrePush   = "pre-push"
+)
+
+const (
+	// marker identifies hook scripts written by forge.
+	marker = "# forge-managed-hook"
+	// chainedSuffix is appended to pre-existing hooks that forge chains to.
+	chainedSuffix = ".forge-chained"
+	// hookPerm makes hook scripts executable, as git requires.
+	hookPerm = 0o755
+)
+
+// commitMsgScript runs a chained hook first, then forge's commit message checks.
+const commitMsgScript = `#!/bin/sh
+` + marker + `: commit-msg
+# Installed by 'forge hooks install'. Remove with 'forge hooks uninstall'.
+hook_dir=$(dirname "$0")
+if [ -x "$hook_dir/commit-msg` + chainedSuffix + `" ]; then
+	"$hook_dir/commit-msg` + chainedSuffix + `" "$@" || exit $?
+fi
+if ! command -v forge >/dev/null 2>&1; then
+	echo "forge: not found in PATH, skipping commit-msg checks" >&2
+	exit 0
+fi
+exec forge hooks run commit-msg "$@"
+`
+
+// prePushScript buffers the refs git passes on stdin so that both the chained
+// hook and forge can read them.
+const prePushScript = `#!/bin/sh
+` + marker + `: pre-push
+# Installed by 'forge hooks install'. Remove with 'forge hooks uninstall'.
+hook_dir=$(dirname "$0")
+input=$(cat)
+if [ -x "$hook_dir/pre-push` + chainedSuffix + `" ]; then
+	printf '%s\n' "$input" | "$hook_dir/pre-push` + chainedSuffix + `" "$@" || exit $?
+fi
+if ! command -v forge >/dev/null 2>&1; then
+	echo "forge: not found in PATH, skipping pre-push checks" >&2
+	exit 0
+fi
+printf '%s\n' "$input" | forge hooks run pre-push "$@"
+`
+
+// Names returns the hooks forge manages.
+func Names() []string {
+	return []string{CommitMsg, PrePush}
+}
+
+// Status describes the state of a single hook.
+type Status struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Installed bool   `json:"installed"` // forge-managed hook present
+	Foreign   bool   `json:"foreign"`   // hook not managed by forge present
+	Chained   bool   `json:"chained"`   // a pre-existing hook runs before forge's checks
+}
+
+// Dir returns the directory git runs hooks from, honouring core.hooksPath.
+func Dir(ctx context.Context, repoDir string) (string, error) {
+	result := run.CmdInDir(ctx, repoDir, "git", "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if !result.Success() {
+		return "", fmt.Errorf("resolve hooks directory: %s", strings.TrimSpace(result.Stderr))
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// Inspect reports the state of each managed hook.
+func Inspect(ctx context.Context, repoDir string) ([]Status, error) {
+	dir, err := Dir(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(Names()))
+	for _, name := range Names() {
+		status, err := inspect(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Install writes the managed hooks. Existing hooks that forge did not write are
+// renamed with a ".forge-chained" suffix and run before forge's checks.
+// Re-installing updates managed hooks in place.
+func Install(ctx context.Context, repoDir string, dryRun bool) ([]Status, error) {
+	dir, err := Dir(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		if err := os.MkdirAll(dir, hookPerm); err != nil {
+			return nil, fmt.Errorf("create hooks directory: %w", err)
+		}
+	}
+
+	statuses := make([]Status, 0, len(Names()))
+	for _, name := range Names() {
+		status, err := inspect(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Foreign {
+			if status.Chained {
+				return nil, fmt.Errorf("cannot chain %s: both %s and %s exist", name, status.Path, status.Path+chainedSuffix)
+			}
+			if !dryRun {
+				if err := os.Rename(status.Path, status.Path+chainedSuffix); err != nil {
+					return nil, fmt.Errorf("chain existing %s hook: %w", name, err)
+				}
+			}
+			status.Chained = true
+			status.Foreign = false
+		}
+
+		if !dryRun {
+			//nolint:gosec // git only runs executable hooks
+			if err := os.WriteFile(status.Path, []byte(script(name)), hookPerm); err != nil {
+				return nil, fmt.Errorf("write %s hook: %w", name, err)
+			}
+		}
+		status.Installed = true
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Uninstall removes the managed hooks and restores any hooks they chained to.
+// Hooks that forge did not write are left untouched.
+func Uninstall(ctx context.Context, repoDir string, dryRun bool) ([]Status, error) {
+	dir, err := Dir(ctx, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(Names()))
+	for _, name := range Names() {
+		status, err := inspect(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Installed {
+			if !dryRun {
+				if err := os.Remove(status.Path); err != nil {
+					return nil, fmt.Errorf("remove %s hook: %w", name, err)
+				}
+				if status.Chained {
+					if err := os.Rename(status.Path+chainedSuffix, status.Path); err != nil {
+						return nil, fmt.Errorf("restore chained %s hook: %w", name, err)
+					}
+				}
+			}
+			status.Installed = false
+			status.Foreign = status.Chained
+			status.Chained = false
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// inspect reads the state of a single hook in dir.
+func inspect(dir, name string) (Status, error) {
+	status := Status{Name: name, Path: filepath.Join(dir, name)}
+
+	content, err := os.ReadFile(status.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return status, fmt.Errorf("read %s hook: %w", name, err)
+	case strings.Contains(string(content), marker):
+		status.Installed = true
+	default:
+		status.Foreign = true
+	}
+
+	if _, err := os.Stat(status.Path + chainedSuffix); err == nil {
+		status.Chained = true
+	}
+
+	return status, nil
+}
+
+// script returns the hook script for the given hook name.
+func script(name string) string {
+	if name == PrePush {
+		return prePushScript
+	}
+	return commitMsgScript
+}
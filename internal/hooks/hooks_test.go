@@ -0,0 +1,94 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/run"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if r := run.CmdInDir(context.Background(), dir, "git", "init"); !r.Success() {
+		t.Fatalf("git init failed: %s", r.Stderr)
+	}
+	return dir
+}
+
+func TestInstallUninstall(t *testing.T) {
+	ctx := t.Context()
+	repo := initRepo(t)
+
+	hooksDir, err := Dir(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(hooksDir, CommitMsg)
+	if err := os.WriteFile(existing, []byte("#!/bin/sh\necho existing\n"), hookPerm); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dry run changes nothing
+	if _, err := Install(ctx, repo, true); err != nil {
+		t.Fatalf("Install(dry-run) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, PrePush)); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote pre-push hook")
+	}
+
+	statuses, err := Install(ctx, repo, false)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	want := []Status{
+		{Name: CommitMsg, Path: existing, Installed: true, Chained: true},
+		{Name: PrePush, Path: filepath.Join(hooksDir, PrePush), Installed: true},
+	}
+	if !slices.Equal(statuses, want) {
+		t.Errorf("Install() = %+v, want %+v", statuses, want)
+	}
+
+	// Re-installing updates in place and keeps the chain
+	if _, err := Install(ctx, repo, false); err != nil {
+		t.Fatalf("second Install() error = %v", err)
+	}
+	got, err := Inspect(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Inspect() = %+v, want %+v", got, want)
+	}
+
+	if _, err := Uninstall(ctx, repo, false); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	content, err := os.ReadFile(existing)
+	if err != nil || !strings.Contains(string(content), "echo existing") {
+		t.Errorf("chained hook not restored: %q, %v", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, PrePush)); !os.IsNotExist(err) {
+		t.Errorf("pre-push hook not removed")
+	}
+}
+
+func TestDirHonoursHooksPath(t *testing.T) {
+	ctx := t.Context()
+	repo := initRepo(t)
+	if r := run.CmdInDir(ctx, repo, "git", "config", "core.hooksPath", ".githooks"); !r.Success() {
+		t.Fatal(r.Stderr)
+	}
+
+	dir, err := Dir(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(dir) != ".githooks" || !filepath.IsAbs(dir) {
+		t.Errorf("Dir() = %q, want absolute .githooks path", dir)
+	}
+}
@@ -29,6 +29,17 @@ type Options struct {
 	Force      bool
 	DryRun     bool
 	Multi      bool
+	// Schema is the JSON Schema location written to the yaml-language-server modeline,
+	// so editors validate and complete the file. Empty writes no modeline.
+	Schema string
+}
+
+// schemaModeline returns the yaml-language-server comment pointing at schema.
+func schemaModeline(schema string) string {
+	if schema == "" {
+		return ""
+	}
+	return "# yaml-language-server: $schema=" + schema + "\n\n"
 }
 
 // Init creates a new forge.yaml configuration file with default values.
@@ -78,6 +89,7 @@ func Init(ctx context.Context, opts Options) error {
 			return fmt.Errorf("generate YAML content: %w", err)
 		}
 	}
+	content = schemaModeline(opts.Schema) + content
 
 	if opts.DryRun {
 		logger.Infof("dry-run: would create config file at %s", outputPath)
@@ -145,6 +157,7 @@ func InitWithCustomConfig(ctx context.Context, cfg *config.AppConfig, opts Optio
 	if err != nil {
 		return fmt.Errorf("marshal config to YAML: %w", err)
 	}
+	data = append([]byte(schemaModeline(opts.Schema)), data...)
 
 	if opts.DryRun {
 		logger.Infof("dry-run: would create config file at %s", outputPath)
@@ -235,3 +235,126 @@ func PromptConfirmation(question string, preview string) (bool, error) {
 
 	return result.yes, nil
 }
+
+// MultiSelectChoice is an option of a multi-select prompt
+type MultiSelectChoice struct {
+	Label       string
+	Description string
+	Selected    bool
+}
+
+// multiSelectModel is the Bubble Tea model for multi-select prompts
+type multiSelectModel struct {
+	choices  []MultiSelectChoice
+	cursor   int
+	done     bool
+	canceled bool
+	title    string
+}
+
+func (m multiSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.canceled = true
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.choices)-1 {
+				m.cursor++
+			}
+
+		case " ", "x":
+			m.choices[m.cursor].Selected = !m.choices[m.cursor].Selected
+
+		case "a":
+			// Select all, or none if everything is selected already
+			all := true
+			for _, c := range m.choices {
+				all = all && c.Selected
+			}
+			for i := range m.choices {
+				m.choices[i].Selected = !all
+			}
+
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func (m multiSelectModel) View() string {
+	s := titleStyle.Render(m.title) + "\n\n"
+
+	for i, choice := range m.choices {
+		cursor := "  "
+		if m.cursor == i {
+			cursor = cursorStyle.Render("❯ ")
+		}
+
+		box := "[ ] "
+		label := choice.Label
+		if choice.Selected {
+			box = selectedStyle.Render("[x] ")
+			label = selectedStyle.Render(label)
+		}
+
+		line := cursor + box + label
+		if choice.Description != "" {
+			line += " " + descStyle.Render(choice.Description)
+		}
+
+		s += line + "\n"
+	}
+
+	s += "\n" + helpStyle.Render(
+		"↑/↓ or k/j: navigate • space/x: toggle • a: all • enter: confirm • q/esc: cancel",
+	)
+
+	return s
+}
+
+// PromptMultiSelect shows an interactive multi-select list and returns the indices
+// of the selected choices
+func PromptMultiSelect(title string, choices []MultiSelectChoice) ([]int, error) {
+	if !IsInteractive() {
+		return nil, fmt.Errorf("not running in an interactive terminal")
+	}
+
+	m := multiSelectModel{
+		choices: choices,
+		title:   title,
+	}
+
+	p := tea.NewProgram(m)
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("error running prompt: %w", err)
+	}
+
+	result := finalModel.(multiSelectModel)
+	if result.canceled {
+		return nil, fmt.Errorf("selection canceled")
+	}
+
+	selected := []int{}
+	for i, choice := range result.choices {
+		if choice.Selected {
+			selected = append(selected, i)
+		}
+	}
+	return selected, nil
+}
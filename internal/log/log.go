@@ -23,8 +23,13 @@ var DefaultLogger = New(false)
 
 // New creates a new Logger writing to stdout.
 func New(verbose bool) *Logger {
+	return NewWithWriter(os.Stdout, verbose)
+}
+
+// NewWithWriter creates a new Logger writing to w.
+func NewWithWriter(w io.Writer, verbose bool) *Logger {
 	return &Logger{
-		output:  os.Stdout,
+		output:  w,
 		verbose: verbose,
 	}
 }
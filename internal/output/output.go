@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 )
 
@@ -22,12 +23,19 @@ const (
 // Manager handles output formatting.
 type Manager struct {
 	format Format
+	w      io.Writer
 }
 
-// New creates a new output manager.
+// New creates a new output manager writing to stdout.
 func New(format Format) *Manager {
+	return NewWithWriter(format, os.Stdout)
+}
+
+// NewWithWriter creates a new output manager writing to w.
+func NewWithWriter(format Format, w io.Writer) *Manager {
 	return &Manager{
 		format: format,
+		w:      w,
 	}
 }
 
@@ -39,7 +47,7 @@ func (m *Manager) IsJSON() bool {
 // Print outputs the result in the appropriate format.
 func (m *Manager) Print(result any) error {
 	if m.format == FormatJSON {
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := json.NewEncoder(m.w)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(result); err != nil {
 			return fmt.Errorf("encode JSON output: %w", err)
@@ -79,6 +87,15 @@ type VersionResult struct {
 	Dirty   bool   `json:"dirty,omitempty"`
 }
 
+// NextVersionResult represents the result of a version next command.
+type NextVersionResult struct {
+	Current string `json:"current"`
+	Next    string `json:"next"`
+	Tag     string `json:"tag"`
+	Scheme  string `json:"scheme"`
+	Bump    string `json:"bump,omitempty"`
+}
+
 // VersionHistoryEntry represents a single version in the history.
 type VersionHistoryEntry struct {
 	Version string `json:"version"`
@@ -120,6 +137,30 @@ type RetagResult struct {
 	Message    string `json:"message,omitempty"`
 }
 
+// LintResult represents the result of a lint commits command.
+type LintResult struct {
+	Valid   bool               `json:"valid"`
+	Range   string             `json:"range"`
+	Checked int                `json:"checked"`
+	Failed  int                `json:"failed"`
+	Commits []LintCommitResult `json:"commits"`
+}
+
+// LintCommitResult lists the violations of a single commit.
+type LintCommitResult struct {
+	Hash       string          `json:"hash"`
+	ShortHash  string          `json:"short_hash"`
+	Subject    string          `json:"subject"`
+	Violations []LintViolation `json:"violations"`
+}
+
+// LintViolation describes a single rule violation and how to fix it.
+type LintViolation struct {
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
 // ErrorResult represents an error result.
 type ErrorResult struct {
 	Error   string `json:"error"`
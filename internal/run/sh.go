@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/alexjoedt/forge/internal/log"
 )
@@ -58,11 +60,20 @@ func Cmd(ctx context.Context, name string, args ...string) Result {
 
 // CmdInDir executes a command in the specified directory.
 func CmdInDir(ctx context.Context, dir, name string, args ...string) Result {
+	return CmdInDirWithInput(ctx, dir, "", name, args...)
+}
+
+// CmdInDirWithInput executes a command in the specified directory with input on its
+// stdin, for arguments too many for the command line.
+func CmdInDirWithInput(ctx context.Context, dir, input, name string, args ...string) Result {
 	logger := log.FromContext(ctx)
 	logger.Debugf("executing command in directory %s: %s %v", dir, name, args)
 
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -123,6 +134,64 @@ func CmdStreamInDir(ctx context.Context, dir, name string, args ...string) Resul
 	return result
 }
 
+// StreamInDir executes a command in the specified directory and hands its
+// stdout to consume while the command is still running, so large outputs
+// never have to be buffered in memory. Stderr is captured in the returned
+// Result; Stdout is always empty. An error returned by consume takes
+// precedence over the command's own exit error in Result.Err.
+func StreamInDir(ctx context.Context, dir string, consume func(io.Reader) error, name string, args ...string) Result {
+	logger := log.FromContext(ctx)
+	logger.Debugf("executing (streaming) command in directory %s: %s %v", dir, name, args)
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return Result{ExitCode: -1, Err: fmt.Errorf("open stdout pipe: %w", err)}
+	}
+
+	if err = cmd.Start(); err != nil {
+		logger.Debugf("command failed to start: %s (%v)", name, err)
+		return Result{ExitCode: -1, Err: err}
+	}
+
+	consumeErr := consume(stdout)
+	if consumeErr != nil {
+		// Drain whatever is left so the process is not blocked on a full pipe.
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	err = cmd.Wait()
+
+	result := Result{
+		Stderr:   stderr.String(),
+		ExitCode: 0,
+		Err:      err,
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+		}
+		logger.Debugf("command failed: %s (exit code: %d, stderr: %s)", name, result.ExitCode, result.Stderr)
+	}
+
+	if consumeErr != nil {
+		result.Err = consumeErr
+		if result.ExitCode == 0 {
+			result.ExitCode = -1
+		}
+	}
+
+	return result
+}
+
 // MustSucceed wraps a Result and returns an error if the command failed.
 func (r Result) MustSucceed(cmdDesc string) error {
 	if r.Err != nil {
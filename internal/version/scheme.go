@@ -203,6 +203,75 @@ func ParseCalVer(s string) (*Version, error) {
 	return v, nil
 }
 
+// ParseCalVerFormat parses a calendar version string (without prefix) laid out by a
+// calver_format such as "2006.WW" or "2006.01.02". Unlike ParseCalVer, the date part
+// takes as many numbers as the format has, so "2025.44.1" is week 44, build 1 under
+// "2006.WW". A single extra number is the sequence; versions with more numbers, like
+// the hotfix tag "2025.44.1.2", are rejected, as are dates that don't fit the format
+// such as week 99.
+func ParseCalVerFormat(s, format string) (*Version, error) {
+	v := &Version{
+		Scheme: SchemeCalVer,
+		Raw:    s,
+	}
+
+	if core, meta, found := strings.Cut(s, "+"); found {
+		v.Meta = meta
+		s = core
+	}
+	if core, pre, found := strings.Cut(s, "-"); found {
+		v.Pre = pre
+		s = core
+	}
+
+	n := len(strings.Split(format, "."))
+	parts := strings.Split(s, ".")
+	if len(parts) < n || len(parts) > n+1 {
+		return nil, fmt.Errorf("invalid calver %q: expected format %s[.SEQUENCE]", v.Raw, format)
+	}
+	for _, p := range parts {
+		if !isNumericStr(p) {
+			return nil, fmt.Errorf("invalid calver %q: %q is not a number", v.Raw, p)
+		}
+	}
+	for i, field := range strings.Split(format, ".") {
+		if err := checkCalVerField(field, parts[i]); err != nil {
+			return nil, fmt.Errorf("invalid calver %q: %w", v.Raw, err)
+		}
+	}
+
+	v.CalVerDate = strings.Join(parts[:n], ".")
+	if len(parts) > n {
+		v.CalVerSequence, _ = strconv.Atoi(parts[n])
+	}
+	return v, nil
+}
+
+// checkCalVerField reports whether value can be the calver_format field: a four digit
+// year, an ISO week, a month or a day. Fields forge does not know are not checked.
+func checkCalVerField(field, value string) error {
+	n, _ := strconv.Atoi(value)
+	switch field {
+	case "2006", "YYYY":
+		if len(value) != 4 {
+			return fmt.Errorf("%q is not a four digit year", value)
+		}
+	case "WW":
+		if n < 1 || n > 53 {
+			return fmt.Errorf("%q is not an ISO week (01-53)", value)
+		}
+	case "01":
+		if n < 1 || n > 12 {
+			return fmt.Errorf("%q is not a month (01-12)", value)
+		}
+	case "02":
+		if n < 1 || n > 31 {
+			return fmt.Errorf("%q is not a day (01-31)", value)
+		}
+	}
+	return nil
+}
+
 // BumpSemVer increments the version according to the bump type.
 func (v *Version) BumpSemVer(bump BumpType) *Version {
 	next := &Version{
@@ -374,10 +443,15 @@ func (v *Version) BumpPreRelease(channel string) (*Version, error) {
 	return next, nil
 }
 
-// Compare compares two SemVer versions according to spec §11.
+// Compare compares two versions of the same scheme.
 // Returns -1 if a < b, 0 if a == b, 1 if a > b.
-// Build metadata (§10) is ignored for precedence.
+// SemVer follows the precedence rules of spec §11. CalVer compares the date parts
+// numerically, then the sequence number, and ranks a release above its prereleases,
+// which are ordered like SemVer prereleases. Build metadata (§10) is ignored.
 func Compare(a, b *Version) int {
+	if a.Scheme == SchemeCalVer && b.Scheme == SchemeCalVer {
+		return compareCalVer(a, b)
+	}
 	if c := cmpInt(a.Major, b.Major); c != 0 {
 		return c
 	}
@@ -399,6 +473,40 @@ func Compare(a, b *Version) int {
 	return comparePreRelease(a.Pre, b.Pre)
 }
 
+// compareCalVer orders two CalVer versions by date, sequence and prerelease.
+func compareCalVer(a, b *Version) int {
+	aParts := strings.Split(a.CalVerDate, ".")
+	bParts := strings.Split(b.CalVerDate, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		ai, aErr := strconv.Atoi(aParts[i])
+		bi, bErr := strconv.Atoi(bParts[i])
+		if aErr != nil || bErr != nil {
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := cmpInt(ai, bi); c != 0 {
+			return c
+		}
+	}
+	if c := cmpInt(len(aParts), len(bParts)); c != 0 {
+		return c
+	}
+	if c := cmpInt(a.CalVerSequence, b.CalVerSequence); c != 0 {
+		return c
+	}
+	switch {
+	case a.Pre == "" && b.Pre == "":
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	}
+	return comparePreRelease(a.Pre, b.Pre)
+}
+
 // comparePreRelease compares two prerelease strings identifier by identifier (spec §11.4).
 func comparePreRelease(a, b string) int {
 	aIds := strings.Split(a, ".")
@@ -243,6 +243,48 @@ func TestParseCalVer(t *testing.T) {
 	}
 }
 
+func TestParseCalVerFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		format   string
+		wantDate string
+		wantSeq  int
+		wantPre  string
+		wantErr  bool
+	}{
+		{name: "week with build", input: "2025.44.1", format: "2006.WW", wantDate: "2025.44", wantSeq: 1},
+		{name: "early week", input: "2025.03.2", format: "2006.WW", wantDate: "2025.03", wantSeq: 2},
+		{name: "day without sequence", input: "2025.11.09", format: "2006.01.02", wantDate: "2025.11.09"},
+		{name: "day with sequence", input: "2025.11.09.3", format: "2006.01.02", wantDate: "2025.11.09", wantSeq: 3},
+		{name: "year and month", input: "2025.11", format: "2006.01", wantDate: "2025.11"},
+		{name: "prerelease", input: "2025.44.1-rc.1", format: "2006.WW", wantDate: "2025.44", wantSeq: 1, wantPre: "rc.1"},
+		{name: "hotfix number", input: "2025.44.1.2", format: "2006.WW", wantErr: true},
+		{name: "too short", input: "2025", format: "2006.WW", wantErr: true},
+		{name: "not a number", input: "2025.x.1", format: "2006.WW", wantErr: true},
+		{name: "week out of range", input: "2025.99.1", format: "2006.WW", wantErr: true},
+		{name: "semver shaped", input: "1.2.3", format: "2006.WW", wantErr: true},
+		{name: "month out of range", input: "2025.13.01", format: "2006.01.02", wantErr: true},
+		{name: "day out of range", input: "2025.11.32", format: "2006.01.02", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCalVerFormat(tt.input, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCalVerFormat(%q, %q) error = %v, wantErr %v", tt.input, tt.format, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.CalVerDate != tt.wantDate || got.CalVerSequence != tt.wantSeq || got.Pre != tt.wantPre {
+				t.Errorf("ParseCalVerFormat(%q, %q) = %q seq %d pre %q, want %q seq %d pre %q", tt.input, tt.format,
+					got.CalVerDate, got.CalVerSequence, got.Pre, tt.wantDate, tt.wantSeq, tt.wantPre)
+			}
+		})
+	}
+}
+
 func TestFormatCalVer(t *testing.T) {
 	// October 2, 2025 is in week 40 of 2025 (ISO week)
 	testTime := time.Date(2025, 10, 2, 12, 0, 0, 0, time.UTC)
@@ -750,6 +792,46 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareCalVer(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		order  []string
+	}{
+		{
+			name:   "date",
+			format: "2006.01.02",
+			order:  []string{"2025.9.30", "2025.10.01-rc.2", "2025.10.01-rc.10", "2025.10.01", "2025.10.01.2", "2025.10.01.10"},
+		},
+		{
+			name:   "week",
+			format: "2006.WW",
+			order:  []string{"2025.9", "2025.44", "2025.44.1-beta.1", "2025.44.1", "2025.44.2", "2026.01"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < len(tt.order)-1; i++ {
+				a, err := ParseCalVerFormat(tt.order[i], tt.format)
+				if err != nil {
+					t.Fatalf("ParseCalVerFormat(%q) unexpected error: %v", tt.order[i], err)
+				}
+				b, err := ParseCalVerFormat(tt.order[i+1], tt.format)
+				if err != nil {
+					t.Fatalf("ParseCalVerFormat(%q) unexpected error: %v", tt.order[i+1], err)
+				}
+				if got := Compare(a, b); got != -1 {
+					t.Errorf("Compare(%q, %q) = %d, want -1", tt.order[i], tt.order[i+1], got)
+				}
+				if got := Compare(b, a); got != 1 {
+					t.Errorf("Compare(%q, %q) = %d, want 1", tt.order[i+1], tt.order[i], got)
+				}
+			}
+		})
+	}
+}
+
 func TestIsPrerelease(t *testing.T) {
 	stable, err := ParseSemVer("1.2.3")
 	if err != nil {
@@ -48,6 +48,12 @@ func main() {
 				Name:  "json",
 				Usage: "output results in JSON format for scripting",
 			},
+			&cli.StringFlag{
+				Name:    "repo-dir",
+				Aliases: []string{"C"},
+				Usage:   "run as if forge was started in this directory",
+				Value:   ".",
+			},
 		},
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
 			jsonOutput := c.Bool("json")
@@ -75,6 +81,9 @@ func main() {
 			commands.Changelog(),
 			commands.Retag(),
 			commands.Validate(),
+			commands.Config(),
+			commands.Lint(),
+			commands.Hooks(),
 		},
 	}
 
@@ -0,0 +1,169 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/log"
+)
+
+// Changelog is a parsed changelog: the commits of a range, grouped by type.
+type Changelog = changelog.Changelog
+
+// ChangelogOptions controls how commits are parsed into a changelog.
+type ChangelogOptions = changelog.Options
+
+// ChangelogFormat is a changelog output format.
+type ChangelogFormat = changelog.Format
+
+// Changelog formats supported by FormatChangelog.
+const (
+	ChangelogMarkdown = changelog.MarkdownFormat
+	ChangelogJSON     = changelog.JSONFormat
+	ChangelogPlain    = changelog.PlainFormat
+)
+
+// ChangelogModes overrides the changelog settings of the configuration. A nil field
+// keeps the configured value.
+type ChangelogModes struct {
+	FirstParent  *bool
+	GroupByPR    *bool
+	Contributors *bool
+	CoAuthors    *bool
+}
+
+// NewChangelogOptions builds the parser options from the changelog configuration,
+// letting modes override it.
+func NewChangelogOptions(cfg ChangelogConfig, modes ChangelogModes) ChangelogOptions {
+	opts := changelog.Options{
+		FirstParent:  cfg.Mode == config.ChangelogModeFirstParent,
+		GroupByPR:    cfg.GroupByPR,
+		Contributors: cfg.Contributors,
+		CoAuthors:    cfg.CoAuthors,
+		Bots:         cfg.Bots,
+		Exclude: changelog.ExcludeRules{
+			Subjects: cfg.Exclude.Subjects,
+			Authors:  cfg.Exclude.Authors,
+			Scopes:   cfg.Exclude.Scopes,
+			Paths:    cfg.Exclude.Paths,
+		},
+	}
+	for _, issue := range cfg.Issues {
+		opts.Issues = append(opts.Issues, changelog.IssuePattern{Pattern: issue.Pattern, URL: issue.URL})
+	}
+
+	if modes.FirstParent != nil {
+		opts.FirstParent = *modes.FirstParent
+	}
+	if modes.GroupByPR != nil {
+		opts.GroupByPR = *modes.GroupByPR
+	}
+	if modes.Contributors != nil {
+		opts.Contributors = *modes.Contributors
+	}
+	if modes.CoAuthors != nil {
+		opts.CoAuthors = *modes.CoAuthors
+	}
+	return opts
+}
+
+// ChangelogRequest selects the range of a changelog.
+type ChangelogRequest struct {
+	// App is the app of a multi-app repository; empty selects the default app.
+	App string
+	// From is the tag the range starts after. Empty starts at the previous stable
+	// release, so notes for v1.6.0 cover everything since v1.5.0.
+	From string
+	// To is the tag or commit the range ends at, HEAD if empty. If it is not a version
+	// tag but one points at it, that tag ends the range.
+	To string
+	// SincePrevious starts an open range at the immediately preceding tag, including
+	// prereleases, instead of the previous stable release.
+	SincePrevious bool
+	Modes         ChangelogModes
+}
+
+// Changelog parses the commits of the requested range. The resolved range is in
+// FromTag and ToTag of the result; an empty FromTag covers the full history.
+func (r *Repo) Changelog(ctx context.Context, req ChangelogRequest) (*Changelog, error) {
+	ctx = r.context(ctx)
+
+	appConfig, err := r.App(req.App)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := req.From, req.To
+	if to == "" {
+		to = "HEAD"
+	}
+	if from == "" {
+		from, to, err = r.changelogRange(ctx, appConfig, to, req.SincePrevious)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := NewChangelogOptions(appConfig.GetChangelogConfig(), req.Modes)
+	cl, err := changelog.NewParserWithOptions(r.dir, appConfig.Prefix, opts).Parse(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("parse changelog: %w", err)
+	}
+	return cl, nil
+}
+
+// changelogRange finds the start of the changelog range ending at to. If to is not a
+// version tag but a version tag points at it, that tag becomes the end of the range.
+// The start is the previous stable tag, or with sincePrevious the immediately
+// preceding tag including prereleases. An empty start covers the full history.
+func (r *Repo) changelogRange(
+	ctx context.Context,
+	appConfig *AppConfig,
+	to string,
+	sincePrevious bool,
+) (string, string, error) {
+	logger := log.FromContext(ctx)
+	tagger := git.NewTagger(r.dir, appConfig.Prefix, false).
+		WithScheme(Scheme(appConfig.Scheme), appConfig.CalVerFormat)
+
+	current, err := tagger.ReleaseTag(ctx, to)
+	if err != nil {
+		return "", "", err
+	}
+	if current != "" {
+		to = current
+	}
+
+	from, err := tagger.PreviousTag(ctx, to, current, !sincePrevious)
+	if err != nil {
+		return "", "", err
+	}
+
+	if from == "" {
+		logger.Warnf("No earlier release tag found, using all commits up to %s", to)
+	} else {
+		logger.Infof("Changelog range: %s..%s", from, to)
+	}
+	return from, to, nil
+}
+
+// FormatChangelog renders a changelog as markdown, JSON or plain text.
+func FormatChangelog(cl *Changelog, format ChangelogFormat) (string, error) {
+	switch format {
+	case ChangelogMarkdown:
+		return changelog.FormatMarkdown(cl), nil
+	case ChangelogJSON:
+		formatted, err := changelog.FormatJSON(cl)
+		if err != nil {
+			return "", fmt.Errorf("format JSON: %w", err)
+		}
+		return formatted, nil
+	case ChangelogPlain:
+		return changelog.FormatPlain(cl), nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use markdown, json or plain)", format)
+	}
+}
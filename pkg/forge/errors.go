@@ -0,0 +1,77 @@
+package forge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+// Error is an error a user can fix, with suggestions on how.
+type Error struct {
+	Title       string
+	Description string
+	Suggestions []string
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Error: %s\n", e.Title)
+	if e.Description != "" {
+		fmt.Fprintf(&sb, "\n  %s\n", e.Description)
+	}
+	if len(e.Suggestions) > 0 {
+		sb.WriteString("\n  Suggestions:\n")
+		for _, suggestion := range e.Suggestions {
+			fmt.Fprintf(&sb, "    • %s\n", suggestion)
+		}
+	}
+	return sb.String()
+}
+
+// NoTagsError returns an error for when no version tags are found
+func NoTagsError(tagPrefix, initialVersion string) error {
+	if initialVersion == "" {
+		initialVersion = "1.0.0"
+	}
+
+	return &Error{
+		Title:       "No version tags found",
+		Description: "This appears to be the first version tag for this project.",
+		Suggestions: []string{
+			fmt.Sprintf("Create your first tag: forge bump --initial %s", initialVersion),
+			"Or use: forge bump --initial to use default (1.0.0)",
+			fmt.Sprintf("Or manually: git tag %s%s && git push --tags", tagPrefix, initialVersion),
+		},
+	}
+}
+
+// dirtyError is returned when releasing from a working tree with uncommitted changes.
+func dirtyError() error {
+	return &Error{
+		Title:       "Working directory has uncommitted changes",
+		Description: "Git working directory is not clean. Forge requires a clean state before creating version tags.",
+		Suggestions: []string{
+			"Commit your changes: git add . && git commit -m 'Your message'",
+			"Stash your changes: git stash",
+			"Use --force to create tag anyway (not recommended)",
+		},
+	}
+}
+
+// preReleaseGuardError returns a friendly error when the user tries to do a numeric
+// bump while the current latest tag is a prerelease.
+func preReleaseGuardError(tag string, v *version.Version) error {
+	base := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	channel := strings.SplitN(v.Pre, ".", 2)[0]
+	return &Error{
+		Title:       fmt.Sprintf("Current version %s is a prerelease", tag),
+		Description: "Numeric bumps (major/minor/patch) cannot be applied while on a prerelease tag.",
+		Suggestions: []string{
+			fmt.Sprintf("forge bump pre %-12s advance prerelease (%s → %s.next)", channel, v.Pre, channel),
+			"forge bump pre rc              promote to a different channel",
+			fmt.Sprintf("forge bump pre release         graduate to stable %s", base),
+			"forge bump ... --force         bypass this guard (not recommended)",
+		},
+	}
+}
@@ -0,0 +1,168 @@
+// Package forge is the Go API of forge. It exposes the release workflow of the forge
+// command line tool, so Go programs can compute versions, create release tags and
+// build changelogs without running the binary and parsing its output.
+//
+//	repo, err := forge.Open(".", forge.WithDryRun(true))
+//	if err != nil {
+//		return err
+//	}
+//	next, err := repo.NextVersion(ctx, forge.NextVersionRequest{App: "api", Bump: forge.BumpMinor})
+package forge
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/alexjoedt/forge/internal/config"
+	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/output"
+)
+
+// Result types returned by the operations of a Repo. They are the documents the
+// command line tool prints with --json.
+type (
+	TagResult         = output.TagResult
+	NextVersionResult = output.NextVersionResult
+)
+
+// OutputFormat selects how results are reported to the output writer.
+type OutputFormat = output.Format
+
+// Output formats.
+const (
+	OutputText = output.FormatText
+	OutputJSON = output.FormatJSON
+)
+
+// Repo is a git repository with a forge configuration.
+type Repo struct {
+	dir    string
+	config *Config
+	opts   options
+}
+
+type options struct {
+	dryRun bool
+	logger *log.Logger
+	out    *output.Manager
+	w      io.Writer
+}
+
+// Option configures a Repo.
+type Option func(*options)
+
+// WithDryRun makes operations report what they would do without changing the
+// repository.
+func WithDryRun(dryRun bool) Option {
+	return func(o *options) {
+		o.dryRun = dryRun
+	}
+}
+
+// WithLogger writes log messages to w; verbose includes debug messages. Without it,
+// nothing is logged.
+func WithLogger(w io.Writer, verbose bool) Option {
+	return func(o *options) {
+		o.logger = log.NewWithWriter(w, verbose)
+	}
+}
+
+// WithOutput reports the result of each operation to w, as indented JSON or as the
+// lines the command line tool prints. Without it, or with a nil w, results are only
+// returned.
+func WithOutput(w io.Writer, format OutputFormat) Option {
+	return func(o *options) {
+		if w == nil {
+			o.out, o.w = nil, nil
+			return
+		}
+		o.out = output.NewWithWriter(format, w)
+		o.w = w
+	}
+}
+
+// Open loads the forge configuration of the repository in repoDir.
+func Open(repoDir string, opts ...Option) (*Repo, error) {
+	cfg, err := LoadConfig(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Repo{
+		dir:    repoDir,
+		config: cfg,
+		opts:   options{logger: log.NewWithWriter(io.Discard, false)},
+	}
+	for _, opt := range opts {
+		opt(&r.opts)
+	}
+	return r, nil
+}
+
+// With returns a copy of the repo with opts applied on top of its options.
+func (r *Repo) With(opts ...Option) *Repo {
+	c := *r
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	return &c
+}
+
+// Dir returns the repository directory.
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// Config returns the loaded configuration.
+func (r *Repo) Config() *Config {
+	return r.config
+}
+
+// App returns the configuration of app, or of the default app if app is empty.
+func (r *Repo) App(app string) (*AppConfig, error) {
+	appConfig, err := r.config.GetAppConfig(app)
+	if err != nil {
+		return nil, fmt.Errorf("get app config: %w", err)
+	}
+	return appConfig, nil
+}
+
+// context returns ctx carrying the logger of the repo for the internal packages.
+func (r *Repo) context(ctx context.Context) context.Context {
+	return log.WithLogger(ctx, r.opts.logger)
+}
+
+// report writes result to the output, as JSON or as the given text lines.
+func (r *Repo) report(result any, lines ...string) error {
+	if r.opts.out == nil {
+		return nil
+	}
+	if r.opts.out.IsJSON() {
+		return r.opts.out.Print(result)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(r.opts.w, line); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadConfig loads forge.yaml or .forge.yaml from dir.
+func LoadConfig(dir string) (*Config, error) {
+	cfg, err := config.LoadFromDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Configuration types, as loaded from forge.yaml.
+type (
+	Config          = config.Config
+	AppConfig       = config.AppConfig
+	HotfixConfig    = config.HotfixConfig
+	ChangelogConfig = config.ChangelogConfig
+	NodeJSConfig    = config.NodeJSConfig
+)
@@ -0,0 +1,219 @@
+package forge_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/git/gittest"
+	"github.com/alexjoedt/forge/pkg/forge"
+)
+
+// openRepo opens a repository whose git history is the in-memory repo: v1.0.0
+// followed by a fix and a feature.
+func openRepo(t *testing.T, opts ...forge.Option) (context.Context, *forge.Repo, *gittest.Repository) {
+	t.Helper()
+
+	dir := t.TempDir()
+	cfg := "scheme: semver\nprefix: v\ndefault_branch: main\n"
+	if err := os.WriteFile(filepath.Join(dir, "forge.yaml"), []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := gittest.New()
+	if err := fake.AddTag("v1.0.0", fake.AddCommit("feat: initial"), "forge: release v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	fake.AddCommit("fix: crash on empty input")
+	fake.AddCommit("feat: add search")
+
+	repo, err := forge.Open(dir, opts...)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return git.WithRepository(context.Background(), fake), repo, fake
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      forge.NextVersionRequest
+		wantTag  string
+		wantBump string
+	}{
+		{name: "suggested bump", wantTag: "v1.1.0", wantBump: "minor"},
+		{name: "explicit bump", req: forge.NextVersionRequest{Bump: forge.BumpMajor}, wantTag: "v2.0.0", wantBump: "major"},
+		{name: "prerelease", req: forge.NextVersionRequest{Bump: forge.BumpPatch, Pre: "rc.1"}, wantTag: "v1.0.1-rc.1"},
+		{name: "channel", req: forge.NextVersionRequest{Bump: forge.BumpMinor, Channel: "beta"}, wantTag: "v1.1.0-beta.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, repo, _ := openRepo(t)
+
+			got, err := repo.NextVersion(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("NextVersion() error = %v", err)
+			}
+			// HEAD is two commits past v1.0.0
+			if got.Tag != tt.wantTag || !strings.HasPrefix(got.Current, "1.0.0-dirty-") {
+				t.Errorf("NextVersion() = %+v, want tag %s after 1.0.0", got, tt.wantTag)
+			}
+			if tt.wantBump != "" && got.Bump != tt.wantBump {
+				t.Errorf("Bump = %q, want %q", got.Bump, tt.wantBump)
+			}
+		})
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+	ctx, repo, fake := openRepo(t)
+
+	result, err := repo.CreateRelease(ctx, forge.ReleaseRequest{
+		NextVersionRequest: forge.NextVersionRequest{Bump: forge.BumpMinor},
+		Push:               true,
+	})
+	if err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	if result.Tag != "v1.1.0" || !result.Pushed || result.Message != "Tag created and pushed" {
+		t.Errorf("CreateRelease() = %+v", result)
+	}
+
+	head, _ := fake.ResolveRef(ctx, "HEAD")
+	if commit, err := fake.ResolveRef(ctx, "v1.1.0"); err != nil || commit != head {
+		t.Errorf("v1.1.0 points at %s, %v; want HEAD", commit, err)
+	}
+	if len(fake.Pushes) != 1 || fake.Pushes[0].Refs[0] != "v1.1.0" {
+		t.Errorf("Pushes = %+v", fake.Pushes)
+	}
+}
+
+func TestCreateReleaseRefused(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, fake *gittest.Repository)
+		req     forge.ReleaseRequest
+	}{
+		{
+			name:    "dirty working tree",
+			prepare: func(_ *testing.T, fake *gittest.Repository) { fake.Dirty = true },
+		},
+		{
+			name: "numeric bump from a prerelease",
+			prepare: func(t *testing.T, fake *gittest.Repository) {
+				if err := fake.AddTag("v1.1.0-rc.1", "HEAD", "forge: release v1.1.0-rc.1"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			req: forge.ReleaseRequest{NextVersionRequest: forge.NextVersionRequest{Bump: forge.BumpMinor}},
+		},
+		{
+			name: "no version tags",
+			req:  forge.ReleaseRequest{NextVersionRequest: forge.NextVersionRequest{Prefix: "api/v"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, repo, fake := openRepo(t)
+			if tt.prepare != nil {
+				tt.prepare(t, fake)
+			}
+
+			if err := repo.CheckRelease(ctx, tt.req); !errors.As(err, new(*forge.Error)) {
+				t.Errorf("CheckRelease() error = %v, want *forge.Error", err)
+			}
+			_, err := repo.CreateRelease(ctx, tt.req)
+			if !errors.As(err, new(*forge.Error)) {
+				t.Errorf("CreateRelease() error = %v, want *forge.Error", err)
+			}
+			if tags, _ := fake.Tags(ctx, ""); len(tags) > 2 {
+				t.Errorf("refused release created a tag: %v", tags)
+			}
+		})
+	}
+}
+
+func TestCreateReleaseDryRun(t *testing.T) {
+	var out bytes.Buffer
+	ctx, repo, fake := openRepo(t, forge.WithDryRun(true), forge.WithOutput(&out, forge.OutputJSON))
+	fake.Dirty = true
+
+	result, err := repo.CreateRelease(ctx, forge.ReleaseRequest{Push: true})
+	if err != nil {
+		t.Fatalf("CreateRelease() error = %v", err)
+	}
+	if tags, _ := fake.Tags(ctx, ""); len(tags) != 1 || len(fake.Pushes) != 0 {
+		t.Errorf("dry run changed the repository: tags %v, pushes %v", tags, fake.Pushes)
+	}
+
+	var reported forge.TagResult
+	if err := json.Unmarshal(out.Bytes(), &reported); err != nil {
+		t.Fatalf("output is not a TagResult: %v\n%s", err, out.String())
+	}
+	if reported != *result || reported.Tag != "v1.1.0" || reported.Pushed {
+		t.Errorf("reported %+v, returned %+v", reported, *result)
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	ctx, repo, fake := openRepo(t)
+	if err := fake.AddTag("v1.1.0", "HEAD", "forge: release v1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err := repo.Changelog(ctx, forge.ChangelogRequest{})
+	if err != nil {
+		t.Fatalf("Changelog() error = %v", err)
+	}
+	if cl.FromTag != "v1.0.0" || cl.ToTag != "v1.1.0" {
+		t.Errorf("range = %s..%s, want v1.0.0..v1.1.0", cl.FromTag, cl.ToTag)
+	}
+	if len(cl.Commits) != 2 || cl.SuggestedBump() != forge.BumpMinor {
+		t.Errorf("Commits = %+v", cl.Commits)
+	}
+
+	formatted, err := forge.FormatChangelog(cl, forge.ChangelogPlain)
+	if err != nil || formatted == "" {
+		t.Errorf("FormatChangelog() = %q, %v", formatted, err)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		scheme forge.Scheme
+		a, b   string
+		want   int
+	}{
+		{scheme: forge.SchemeSemVer, a: "1.2.0-rc.1", b: "1.2.0", want: -1},
+		{scheme: forge.SchemeSemVer, a: "1.10.0", b: "1.9.0", want: 1},
+		{scheme: forge.SchemeCalVer, a: "2025.10.02", b: "2025.9.30", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			a, err := forge.ParseVersion(tt.scheme, tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := forge.ParseVersion(tt.scheme, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := forge.Compare(a, b); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := forge.ParseVersion("roman", "IV"); err == nil {
+		t.Error("ParseVersion() accepted an unknown scheme")
+	}
+}
@@ -0,0 +1,332 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexjoedt/forge/internal/changelog"
+	"github.com/alexjoedt/forge/internal/git"
+	"github.com/alexjoedt/forge/internal/log"
+	"github.com/alexjoedt/forge/internal/nodejs"
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+// NextVersionRequest selects the version NextVersion computes. Empty fields fall back
+// to the app configuration.
+type NextVersionRequest struct {
+	// App is the app of a multi-app repository; empty selects the default app.
+	App    string
+	Scheme Scheme
+	// Bump is the SemVer component to increment. Empty uses the bump suggested by the
+	// Conventional Commits since the latest tag. CalVer versions ignore it.
+	Bump BumpType
+	// Channel starts or advances a SemVer prerelease (alpha, beta, rc, ...) instead of
+	// a numeric bump; "release" graduates a prerelease to stable. Bump then selects
+	// the component to increment when starting from a stable version.
+	Channel      string
+	CalVerFormat string
+	Pre          string
+	Meta         string
+	Prefix       string
+}
+
+// release is a NextVersionRequest resolved against the app configuration.
+type release struct {
+	app          *AppConfig
+	scheme       Scheme
+	prefix       string
+	calverFormat string
+	pre          string
+	meta         string
+	tagger       *git.Tagger
+}
+
+// release resolves req against the app configuration.
+func (r *Repo) release(req NextVersionRequest) (*release, error) {
+	appConfig, err := r.App(req.App)
+	if err != nil {
+		return nil, err
+	}
+
+	rel := &release{
+		app:          appConfig,
+		scheme:       Scheme(appConfig.Scheme),
+		prefix:       appConfig.Prefix,
+		calverFormat: appConfig.CalVerFormat,
+		pre:          appConfig.Pre,
+		meta:         appConfig.Meta,
+	}
+	if req.Scheme != "" {
+		rel.scheme = req.Scheme
+	}
+	if req.Prefix != "" {
+		rel.prefix = req.Prefix
+	}
+	if req.CalVerFormat != "" {
+		rel.calverFormat = req.CalVerFormat
+	}
+	if req.Pre != "" {
+		rel.pre = req.Pre
+	}
+	if req.Meta != "" {
+		rel.meta = req.Meta
+	}
+
+	if rel.scheme != SchemeSemVer && rel.scheme != SchemeCalVer {
+		return nil, fmt.Errorf("invalid scheme: %s (must be semver or calver)", rel.scheme)
+	}
+
+	rel.tagger = git.NewTagger(r.dir, rel.prefix, r.opts.dryRun).WithScheme(rel.scheme, rel.calverFormat)
+	return rel, nil
+}
+
+// next calculates the next version of rel, suggesting a SemVer bump if none is given.
+func (r *Repo) next(ctx context.Context, rel *release, req NextVersionRequest) (*Version, BumpType, error) {
+	if req.Channel != "" {
+		if rel.scheme != SchemeSemVer {
+			return nil, "", fmt.Errorf("prereleases only support the semver scheme (configured scheme: %s)", rel.scheme)
+		}
+		next, err := rel.tagger.WithScheme(SchemeSemVer, "").CalculatePreRelease(ctx, req.Channel, string(req.Bump))
+		if err != nil {
+			return nil, "", fmt.Errorf("calculate prerelease version: %w", err)
+		}
+		return next, req.Bump, nil
+	}
+
+	bump := req.Bump
+	if rel.scheme == SchemeCalVer {
+		bump = ""
+	} else if bump == "" {
+		bump = r.suggestBump(ctx, rel)
+	}
+
+	next, err := rel.tagger.CalculateNextVersion(ctx, rel.scheme, bump, rel.calverFormat, rel.pre, rel.meta)
+	if err != nil {
+		return nil, "", fmt.Errorf("calculate next version: %w", err)
+	}
+	return next, bump, nil
+}
+
+// suggestBump infers the bump from the commits since the latest tag (reverted changes
+// don't count), falling back to patch.
+func (r *Repo) suggestBump(ctx context.Context, rel *release) BumpType {
+	logger := log.FromContext(ctx)
+
+	latestTag, err := rel.tagger.LatestTag(ctx)
+	if err != nil || latestTag == "" {
+		return BumpPatch
+	}
+	cl, err := changelog.NewParser(r.dir, rel.prefix).Parse(ctx, latestTag, "HEAD")
+	if err != nil {
+		logger.Debugf("failed to infer bump type: %v", err)
+		return BumpPatch
+	}
+	return cl.SuggestedBump()
+}
+
+// NextVersion previews the version the next release would get.
+func (r *Repo) NextVersion(ctx context.Context, req NextVersionRequest) (*NextVersionResult, error) {
+	ctx = r.context(ctx)
+	logger := log.FromContext(ctx)
+
+	rel, err := r.release(req)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := rel.tagger.GetVersionWithDirtyCheck(ctx)
+	if err != nil {
+		logger.Warnf("failed to detect current version from git: %v", err)
+		current = "none"
+	}
+
+	next, bump, err := r.next(ctx, rel, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NextVersionResult{
+		Current: current,
+		Next:    next.String(),
+		Tag:     version.WithPrefix(next.String(), rel.prefix),
+		Scheme:  string(rel.scheme),
+		Bump:    string(bump),
+	}
+	return result, r.report(result,
+		"Current:  "+result.Current,
+		"Next:     "+result.Next,
+		"Tag:      "+result.Tag,
+		"Scheme:   "+result.Scheme,
+	)
+}
+
+// ReleaseRequest selects the release CreateRelease tags.
+type ReleaseRequest struct {
+	NextVersionRequest
+
+	// Initial creates the first tag of a repository with this version.
+	Initial string
+	// Push pushes the tag to origin.
+	Push bool
+	// Force releases from a dirty working tree and bumps numerically while the latest
+	// tag is a prerelease.
+	Force bool
+}
+
+// CreateRelease tags the next version on HEAD. With Node.js integration, package.json
+// is updated and committed before tagging.
+func (r *Repo) CreateRelease(ctx context.Context, req ReleaseRequest) (*TagResult, error) {
+	ctx = r.context(ctx)
+
+	rel, err := r.release(req.NextVersionRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkRelease(ctx, rel, req); err != nil {
+		return nil, err
+	}
+
+	if req.Initial != "" {
+		return r.createInitialTag(ctx, rel, req)
+	}
+
+	next, _, err := r.next(ctx, rel, req.NextVersionRequest)
+	if err != nil {
+		return nil, err
+	}
+	tag := version.WithPrefix(next.String(), rel.prefix)
+
+	if err := r.updatePackageJSON(ctx, rel, next.String(), tag); err != nil {
+		return nil, err
+	}
+
+	// Tag the current commit, which includes the package.json update if any
+	if err := rel.tagger.CreateTag(ctx, tag, fmt.Sprintf("forge: release %s", tag)); err != nil {
+		return nil, fmt.Errorf("create tag: %w", err)
+	}
+	if req.Push {
+		if err := rel.tagger.PushTag(ctx, tag); err != nil {
+			return nil, fmt.Errorf("push tag: %w", err)
+		}
+	}
+
+	result := &TagResult{Tag: tag, Pushed: req.Push && !r.opts.dryRun, Version: tag}
+	if req.Channel != "" {
+		result.Version = next.String()
+	}
+	return result, r.reportTag(result, "Tag created")
+}
+
+// CheckRelease returns the error CreateRelease would refuse req with before changing
+// anything: uncommitted changes, no version tags yet, or a numeric bump while the
+// latest tag is a prerelease. Interactive callers use it before asking for input.
+func (r *Repo) CheckRelease(ctx context.Context, req ReleaseRequest) error {
+	ctx = r.context(ctx)
+
+	rel, err := r.release(req.NextVersionRequest)
+	if err != nil {
+		return err
+	}
+	return r.checkRelease(ctx, rel, req)
+}
+
+func (r *Repo) checkRelease(ctx context.Context, rel *release, req ReleaseRequest) error {
+	if !r.opts.dryRun && !req.Force {
+		dirty, err := rel.tagger.HasUncommittedChanges(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check git status: %w", err)
+		}
+		if dirty {
+			return dirtyError()
+		}
+	}
+	if req.Initial != "" || req.Channel != "" {
+		return nil
+	}
+
+	refs, err := git.Open(ctx, r.dir).Tags(ctx, rel.prefix)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing tags: %w", err)
+	}
+	if len(refs) == 0 {
+		return NoTagsError(rel.prefix, "1.0.0")
+	}
+
+	// Block numeric bumps while the latest tag is a prerelease, to prevent
+	// accidentally creating e.g. v1.3.0 when you're on v1.3.0-rc.1.
+	if rel.scheme == SchemeSemVer && !req.Force {
+		if latestTag, err := rel.tagger.LatestTag(ctx); err == nil && latestTag != "" {
+			v, err := version.ParseSemVer(version.StripPrefix(latestTag, rel.prefix))
+			if err == nil && v.IsPrerelease() {
+				return preReleaseGuardError(latestTag, v)
+			}
+		}
+	}
+	return nil
+}
+
+// updatePackageJSON writes the version to package.json and commits it, if Node.js
+// integration is enabled.
+func (r *Repo) updatePackageJSON(ctx context.Context, rel *release, newVersion, tag string) error {
+	if !rel.app.NodeJS.Enabled {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	logger.Debugf("Node.js integration enabled, updating package.json")
+
+	updated, err := nodejs.NewUpdater(r.dir, r.opts.dryRun).Update(ctx, rel.app.NodeJS.PackagePath, newVersion)
+	if err != nil {
+		return fmt.Errorf("update package.json: %w", err)
+	}
+	if !updated || r.opts.dryRun {
+		return nil
+	}
+
+	pkgPath := rel.app.NodeJS.PackagePath
+	if pkgPath == "" {
+		pkgPath = "package.json"
+	}
+	if err := rel.tagger.CommitVersionUpdate(ctx, pkgPath, tag); err != nil {
+		return fmt.Errorf("commit package.json: %w", err)
+	}
+	logger.Infof("committed package.json version update")
+	return nil
+}
+
+// createInitialTag creates the first version tag for a project.
+func (r *Repo) createInitialTag(ctx context.Context, rel *release, req ReleaseRequest) (*TagResult, error) {
+	logger := log.FromContext(ctx)
+
+	tag := req.Initial
+	if !strings.HasPrefix(tag, rel.prefix) {
+		tag = version.WithPrefix(tag, rel.prefix)
+	}
+	logger.Infof("creating initial version tag: %s", tag)
+
+	if err := rel.tagger.CreateTag(ctx, tag, fmt.Sprintf("forge: initial release %s", tag)); err != nil {
+		return nil, fmt.Errorf("create initial tag: %w", err)
+	}
+	if req.Push {
+		if err := rel.tagger.PushTag(ctx, tag); err != nil {
+			return nil, fmt.Errorf("push tag: %w", err)
+		}
+	}
+
+	result := &TagResult{Tag: tag, Pushed: req.Push && !r.opts.dryRun, Version: version.StripPrefix(tag, rel.prefix)}
+	return result, r.reportTag(result, "Initial tag created")
+}
+
+// reportTag completes the message of a tag result and reports it.
+func (r *Repo) reportTag(result *TagResult, created string) error {
+	switch {
+	case r.opts.dryRun:
+		result.Message = "Dry run: tag not created"
+		return r.report(result, "Would create tag: "+result.Tag)
+	case result.Pushed:
+		result.Message = created + " and pushed"
+	default:
+		result.Message = created
+	}
+	return r.report(result, result.Message+": "+result.Tag)
+}
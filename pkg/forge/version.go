@@ -0,0 +1,66 @@
+package forge
+
+import (
+	"fmt"
+
+	"github.com/alexjoedt/forge/internal/version"
+)
+
+// Version is a parsed SemVer or CalVer version.
+type Version = version.Version
+
+// Scheme is a versioning scheme.
+type Scheme = version.Scheme
+
+// Versioning schemes.
+const (
+	SchemeSemVer = version.SchemeSemVer
+	SchemeCalVer = version.SchemeCalVer
+)
+
+// BumpType is the SemVer component a release increments.
+type BumpType = version.BumpType
+
+// Bump types.
+const (
+	BumpMajor = version.BumpMajor
+	BumpMinor = version.BumpMinor
+	BumpPatch = version.BumpPatch
+)
+
+// ParseVersion parses a version without tag prefix according to the scheme.
+func ParseVersion(scheme Scheme, s string) (*Version, error) {
+	switch scheme {
+	case SchemeSemVer:
+		return version.ParseSemVer(s)
+	case SchemeCalVer:
+		return version.ParseCalVer(s)
+	default:
+		return nil, fmt.Errorf("invalid scheme: %s (must be semver or calver)", scheme)
+	}
+}
+
+// ParseBump parses a bump type: major, minor or patch.
+func ParseBump(s string) (BumpType, error) {
+	switch s {
+	case "major":
+		return BumpMajor, nil
+	case "minor":
+		return BumpMinor, nil
+	case "patch":
+		return BumpPatch, nil
+	default:
+		return "", fmt.Errorf("invalid bump type: %s", s)
+	}
+}
+
+// Compare returns -1, 0 or 1 as a has lower, equal or higher precedence than b.
+// Build metadata is ignored, and a prerelease comes before its release.
+func Compare(a, b *Version) int {
+	return version.Compare(a, b)
+}
+
+// StripPrefix removes the tag prefix from a tag, leaving its version.
+func StripPrefix(tag, prefix string) string {
+	return version.StripPrefix(tag, prefix)
+}